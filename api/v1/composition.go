@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"slices"
 	"strconv"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -49,6 +50,55 @@ type CompositionSpec struct {
 	// A set of environment variables that will be made available inside the synthesis Pod.
 	// +kubebuilder:validation:MaxItems:=500
 	SynthesisEnv []EnvVar `json:"synthesisEnv,omitempty"`
+
+	// Refs declare additional synthesizer inputs or override refs declared by the synthesizer.
+	// They are merged with the synthesizer's refs when resolving synthesis inputs,
+	// with the composition winning on key conflicts.
+	Refs []Ref `json:"refs,omitempty"`
+
+	// Keys of synthesis result tags that should be promoted to this composition's status.
+	// Tags not listed here are still visible in the synthesis results but are not surfaced in status.
+	PromotedResultTags []string `json:"promotedResultTags,omitempty"`
+
+	// DependsOn defers synthesis of this composition until the referenced
+	// compositions have been fully reconciled. Refs without a namespace default
+	// to this composition's namespace.
+	DependsOn []ObjectRef `json:"dependsOn,omitempty"`
+
+	// HistoryLimit caps the total number of synthesis generations whose resource
+	// slices are retained for auditing: the current and previous syntheses plus
+	// records in status.synthesisHistory. When unset, only the current and
+	// previous syntheses are retained.
+	// +optional
+	HistoryLimit *int `json:"historyLimit,omitempty"`
+
+	// ResynthesisPolicy controls when input changes trigger a new synthesis.
+	// "OnInputChange" (the default) resynthesizes whenever a bound input changes.
+	// "Manual" still records input changes in status.inputRevisions but only
+	// resynthesizes when the eno.azure.io/force-resynthesis annotation is set.
+	// +kubebuilder:validation:Enum=OnInputChange;Manual
+	// +optional
+	ResynthesisPolicy ResynthesisPolicy `json:"resynthesisPolicy,omitempty"`
+
+	// PodOverrides sets values in the pods used to synthesize this composition.
+	// They're merged over the synthesizer's overrides, with the composition
+	// winning on conflict e.g. to pin one composition's synthesis to a
+	// different node pool than its siblings.
+	// +optional
+	PodOverrides PodOverrides `json:"podOverrides,omitempty"`
+}
+
+type ResynthesisPolicy string
+
+const (
+	ResynthesisPolicyOnInputChange ResynthesisPolicy = "OnInputChange"
+	ResynthesisPolicyManual        ResynthesisPolicy = "Manual"
+)
+
+// ObjectRef references another composition by name and optional namespace.
+type ObjectRef struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
 }
 
 type CompositionStatus struct {
@@ -58,8 +108,48 @@ type CompositionStatus struct {
 	PreviousSynthesis  *Synthesis        `json:"previousSynthesis,omitempty"`
 	InputRevisions     []InputRevisions  `json:"inputRevisions,omitempty"`
 	PendingResynthesis *metav1.Time      `json:"pendingResynthesis,omitempty"` // deprecated: will be removed soon
+
+	// Result tags promoted from the current synthesis per spec.promotedResultTags.
+	PromotedTags map[string]string `json:"promotedTags,omitempty"`
+
+	// FirstReconciled is the time at which the composition first became fully
+	// reconciled. Unlike currentSynthesis.reconciled it is never cleared by
+	// subsequent syntheses.
+	FirstReconciled *metav1.Time `json:"firstReconciled,omitempty"`
+
+	// Conditions expose coarse-grained signals about the composition's state
+	// for use by alerting and other tooling.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// SynthesisHistory retains compact records of syntheses that have aged out
+	// of the current/previous pair, newest first, per spec.historyLimit.
+	// +optional
+	SynthesisHistory []RetainedSynthesis `json:"synthesisHistory,omitempty"`
 }
 
+// RetainedSynthesis is a compact record of a past synthesis retained for
+// auditing per spec.historyLimit.
+type RetainedSynthesis struct {
+	UUID           string              `json:"uuid,omitempty"`
+	Synthesized    *metav1.Time        `json:"synthesized,omitempty"`
+	ResourceSlices []*ResourceSliceRef `json:"resourceSlices,omitempty"`
+}
+
+// ConditionInputsUpToDate is true when the current synthesis was computed from
+// the latest observed revisions of the composition's inputs.
+const ConditionInputsUpToDate = "InputsUpToDate"
+
+// ConditionDependenciesReady is true when every composition referenced by
+// spec.dependsOn has been fully reconciled. False while waiting, or when the
+// dependency graph contains a cycle.
+const ConditionDependenciesReady = "DependenciesReady"
+
+// ConditionResourceSlicesReconciled is true when every resource slice of the
+// current synthesis exists and no slices from older syntheses are waiting to
+// be cleaned up.
+const ConditionResourceSlicesReconciled = "ResourceSlicesReconciled"
+
 type SimplifiedStatus struct {
 	Status string `json:"status,omitempty"`
 	Error  string `json:"error,omitempty"`
@@ -105,6 +195,10 @@ type Synthesis struct {
 	// Time at which the synthesis's reconciled resources became ready.
 	Ready *metav1.Time `json:"ready,omitempty"`
 
+	// Failed is set when one of the synthesis's resources exceeded a readiness
+	// check timeout without becoming ready. Cleared once every resource is ready.
+	Failed *metav1.Time `json:"failed,omitempty"`
+
 	// Canceled signals that any running synthesis pods should be deleted,
 	// and new synthesis pods should never be created for this synthesis UUID.
 	Canceled *metav1.Time `json:"canceled,omitempty"`
@@ -112,19 +206,49 @@ type Synthesis struct {
 	// Counter used internally to calculate back off when retrying failed syntheses.
 	Attempts int `json:"attempts,omitempty"`
 
+	// LastAttempt is the time at which the most recent synthesis attempt was dispatched.
+	// Used internally with attempts to calculate retry backoff.
+	LastAttempt *metav1.Time `json:"lastAttempt,omitempty"`
+
 	// References to every resource slice that contains the resources comprising this synthesis.
 	// Immutable.
 	ResourceSlices []*ResourceSliceRef `json:"resourceSlices,omitempty"`
 
 	// Results are passed through opaquely from the synthesizer's KRM function.
+	// Results that would push the composition past the inline diagnostic size cap
+	// are spilled to the ConfigMap referenced by ResultOverflow.
 	Results []Result `json:"results,omitempty"`
 
+	// ResultOverflow names a ConfigMap in the composition's namespace holding the
+	// full set of results when they exceeded the inline diagnostic size cap.
+	// +optional
+	ResultOverflow string `json:"resultOverflow,omitempty"`
+
+	// BundleHash covers the manifests of every resource produced by this synthesis,
+	// independent of their ordering. Two syntheses with identical outputs share a
+	// bundle hash, so controllers can cheaply detect unchanged output.
+	BundleHash string `json:"bundleHash,omitempty"`
+
 	// InputRevisions contains the versions of the input resources that were used for this synthesis.
 	InputRevisions []InputRevisions `json:"inputRevisions,omitempty"`
 
 	// Deferred is true when this synthesis was caused by a change to either the synthesizer
 	// or an input with a ref that sets `Defer == true`.
 	Deferred bool `json:"deferred,omitempty"`
+
+	// LastError captures the terminal state of the most recently failed
+	// synthesizer container, recorded before its pod is garbage collected.
+	// +optional
+	LastError *SynthesisError `json:"lastError,omitempty"`
+}
+
+// SynthesisError describes a failed synthesizer container.
+type SynthesisError struct {
+	// ExitCode of the terminated synthesizer container.
+	ExitCode int32 `json:"exitCode,omitempty"`
+
+	// Message is a truncated copy of the container's termination message.
+	Message string `json:"message,omitempty"`
 }
 
 type Result struct {
@@ -138,6 +262,11 @@ type InputRevisions struct {
 	ResourceVersion       string `json:"resourceVersion,omitempty"`
 	Revision              *int   `json:"revision,omitempty"`
 	SynthesizerGeneration *int64 `json:"synthesizerGeneration,omitempty"`
+
+	// ProjectedHash is a hash of the fields selected by the binding's projection,
+	// when one is configured. It replaces ResourceVersion for change detection so
+	// writes to unprojected fields don't trigger resynthesis.
+	ProjectedHash string `json:"projectedHash,omitempty"`
 }
 
 func (i *InputRevisions) Less(b InputRevisions) bool {
@@ -147,6 +276,9 @@ func (i *InputRevisions) Less(b InputRevisions) bool {
 	if i.Revision != nil && b.Revision != nil {
 		return *i.Revision < *b.Revision
 	}
+	if i.ProjectedHash != "" && b.ProjectedHash != "" {
+		return i.ProjectedHash != b.ProjectedHash
+	}
 	if i.ResourceVersion == b.ResourceVersion {
 		return false
 	}
@@ -158,12 +290,44 @@ func (i *InputRevisions) Less(b InputRevisions) bool {
 	return iInt < bInt
 }
 
+// EffectiveRefs merges the composition's refs with the synthesizer's.
+// The composition's ref wins when both declare the same key.
+func (c *Composition) EffectiveRefs(syn *Synthesizer) []Ref {
+	if len(c.Spec.Refs) == 0 {
+		return syn.Spec.Refs
+	}
+	overridden := map[string]struct{}{}
+	for _, ref := range c.Spec.Refs {
+		overridden[ref.Key] = struct{}{}
+	}
+	refs := slices.Clone(c.Spec.Refs)
+	for _, ref := range syn.Spec.Refs {
+		if _, ok := overridden[ref.Key]; !ok {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
 func (c *Composition) InputsExist(syn *Synthesizer) bool {
+	return len(c.UnresolvedRefs(syn)) == 0
+}
+
+// UnresolvedRefs returns the keys of the refs whose inputs haven't been resolved
+// to a revision yet, sorted alphabetically. Optional refs never block synthesis
+// so they are exempt even when their bound resource doesn't exist.
+func (c *Composition) UnresolvedRefs(syn *Synthesizer) []string {
+	effective := c.EffectiveRefs(syn)
 	refs := map[string]struct{}{}
-	for _, ref := range syn.Spec.Refs {
+	optional := map[string]bool{}
+	for _, ref := range effective {
 		refs[ref.Key] = struct{}{}
+		if ref.Optional {
+			optional[ref.Key] = true
+		}
 	}
 
+	var unresolved []string
 	bound := map[string]struct{}{}
 	for _, binding := range c.Spec.Bindings {
 		if _, ok := refs[binding.Key]; !ok {
@@ -175,30 +339,54 @@ func (c *Composition) InputsExist(syn *Synthesizer) bool {
 			return binding.Key == rev.Key
 		})
 		if !found {
-			return false
+			if !optional[binding.Key] {
+				unresolved = append(unresolved, binding.Key)
+			}
+			continue
 		}
 		bound[binding.Key] = struct{}{}
 	}
 
-	for _, ref := range syn.Spec.Refs {
+	for _, ref := range effective {
 		// Handle missing resources for implied bindings
 		if ref.Resource.Name != "" {
 			found := slices.ContainsFunc(c.Status.InputRevisions, func(rev InputRevisions) bool {
 				return ref.Key == rev.Key
 			})
-			if !found {
-				return false
+			if !found && !ref.Optional {
+				unresolved = append(unresolved, ref.Key)
 			}
 			continue
 		}
 
-		// Every ref must be bound
-		if _, ok := bound[ref.Key]; !ok {
-			return false
+		// Every non-optional ref must be bound
+		if _, ok := bound[ref.Key]; !ok && !ref.Optional {
+			unresolved = append(unresolved, ref.Key)
 		}
 	}
 
-	return true
+	slices.Sort(unresolved)
+	return slices.Compact(unresolved)
+}
+
+// UnsatisfiedRefs returns the keys of the synthesizer's refs that require a binding
+// but are not bound by the composition. Optional refs and implied bindings are exempt.
+func (c *Composition) UnsatisfiedRefs(syn *Synthesizer) []string {
+	bound := map[string]struct{}{}
+	for _, binding := range c.Spec.Bindings {
+		bound[binding.Key] = struct{}{}
+	}
+
+	var unsatisfied []string
+	for _, ref := range c.EffectiveRefs(syn) {
+		if ref.Optional || ref.Resource.Name != "" {
+			continue
+		}
+		if _, ok := bound[ref.Key]; !ok {
+			unsatisfied = append(unsatisfied, ref.Key)
+		}
+	}
+	return unsatisfied
 }
 
 // InputsOutOfLockstep returns true when one or more inputs that specify a revision do not match the others.
@@ -235,6 +423,26 @@ func (c *Composition) InputsOutOfLockstep(synth *Synthesizer) bool {
 	return false
 }
 
+// InputsDrifted returns true when the current synthesis was computed from input
+// revisions that have since been superseded by the given set. Inputs that were
+// not seen by the synthesis are ignored since they cannot have drifted.
+func (s *CompositionStatus) InputsDrifted(current []InputRevisions) bool {
+	if s.CurrentSynthesis == nil {
+		return false
+	}
+	currentByKey := map[string]InputRevisions{}
+	for _, rev := range current {
+		currentByKey[rev.Key] = rev
+	}
+	for _, rev := range s.CurrentSynthesis.InputRevisions {
+		cur, ok := currentByKey[rev.Key]
+		if ok && rev.Less(cur) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *CompositionStatus) GetCurrentSynthesisUUID() string {
 	if s.CurrentSynthesis == nil {
 		return ""
@@ -275,10 +483,64 @@ func (c *Composition) ShouldForceResynthesis() bool {
 	return ok && val == c.Status.getLatestSynthesisUUID()
 }
 
+// ResynthesisInterval returns the interval at which the composition should be
+// periodically resynthesized, or zero when the annotation is unset or invalid.
+func (c *Composition) ResynthesisInterval() time.Duration {
+	interval, err := time.ParseDuration(c.Annotations["eno.azure.io/resynthesis-interval"])
+	if err != nil {
+		return 0
+	}
+	return interval
+}
+
+// NotEligibleForResynthesis returns true when a one-shot composition has already
+// reached a fully reconciled and ready state. One-shot compositions are synthesized
+// and reconciled once, then left alone until resynthesis is explicitly forced.
+func (c *Composition) NotEligibleForResynthesis() bool {
+	if c.Annotations["eno.azure.io/one-shot"] != "true" || c.DeletionTimestamp != nil || c.ShouldForceResynthesis() {
+		return false
+	}
+	syn := c.Status.CurrentSynthesis
+	return syn != nil && syn.Reconciled != nil && syn.Ready != nil
+}
+
+// ManualResynthesisOnly returns true when input changes should not trigger
+// automatic resynthesis. Synthesis still runs when explicitly forced.
+func (c *Composition) ManualResynthesisOnly() bool {
+	return c.Spec.ResynthesisPolicy == ResynthesisPolicyManual
+}
+
+// ShouldDebugSynthesis enables verbose executor diagnostics and retains the
+// synthesizer pod past the normal timeout so operators can inspect it.
+func (c *Composition) ShouldDebugSynthesis() bool {
+	return c.Annotations["eno.azure.io/debug-synthesis"] == "true"
+}
+
+// AllowsProtectedNamespaces opts the composition out of the reconciler's
+// protected namespace denylist, allowing it to write into namespaces like kube-system.
+func (c *Composition) AllowsProtectedNamespaces() bool {
+	return c.Annotations["eno.azure.io/allow-protected-namespaces"] == "true"
+}
+
 func (c *Composition) ShouldOrphanResources() bool {
 	return c.Annotations["eno.azure.io/deletion-strategy"] == "orphan"
 }
 
+// ShouldExposeChangedInputs opts the composition into passing the set of input
+// keys that changed since the last synthesis to the synthesizer, which can use
+// it to short-circuit expensive work. Purely informational - synthesizers are
+// free to ignore it.
+func (c *Composition) ShouldExposeChangedInputs() bool {
+	return c.Annotations["eno.azure.io/expose-changed-inputs"] == "true"
+}
+
+// ShouldPruneStaleResources opts the composition into label-based pruning: synthesized
+// resources are stamped with identity labels, and labeled resources that are no longer
+// part of the current synthesis are deleted even if the synthesis diff missed them.
+func (c *Composition) ShouldPruneStaleResources() bool {
+	return c.Annotations["eno.azure.io/prune-stale-resources"] == "true"
+}
+
 func (s *CompositionStatus) getLatestSynthesisUUID() string {
 	if s.InFlightSynthesis != nil {
 		return s.InFlightSynthesis.UUID
@@ -331,6 +331,85 @@ func TestInputsInLockstep(t *testing.T) {
 	}
 }
 
+func TestInputsDrifted(t *testing.T) {
+	revision1 := 1
+	revision2 := 2
+
+	tests := []struct {
+		Name        string
+		Status      CompositionStatus
+		Current     []InputRevisions
+		Expectation bool
+	}{
+		{
+			Name:        "No current synthesis",
+			Status:      CompositionStatus{},
+			Current:     []InputRevisions{{Key: "foo", Revision: &revision1}},
+			Expectation: false,
+		},
+		{
+			Name: "Matching revisions",
+			Status: CompositionStatus{
+				CurrentSynthesis: &Synthesis{
+					InputRevisions: []InputRevisions{{Key: "foo", Revision: &revision1}},
+				},
+			},
+			Current:     []InputRevisions{{Key: "foo", Revision: &revision1}},
+			Expectation: false,
+		},
+		{
+			Name: "Input advanced past the synthesis",
+			Status: CompositionStatus{
+				CurrentSynthesis: &Synthesis{
+					InputRevisions: []InputRevisions{{Key: "foo", Revision: &revision1}},
+				},
+			},
+			Current:     []InputRevisions{{Key: "foo", Revision: &revision2}},
+			Expectation: true,
+		},
+		{
+			Name: "Resource version advanced past the synthesis",
+			Status: CompositionStatus{
+				CurrentSynthesis: &Synthesis{
+					InputRevisions: []InputRevisions{{Key: "foo", ResourceVersion: "1"}},
+				},
+			},
+			Current:     []InputRevisions{{Key: "foo", ResourceVersion: "2"}},
+			Expectation: true,
+		},
+		{
+			Name: "Input not seen by the synthesis",
+			Status: CompositionStatus{
+				CurrentSynthesis: &Synthesis{
+					InputRevisions: []InputRevisions{{Key: "foo", Revision: &revision1}},
+				},
+			},
+			Current: []InputRevisions{
+				{Key: "foo", Revision: &revision1},
+				{Key: "bar", Revision: &revision2},
+			},
+			Expectation: false,
+		},
+		{
+			Name: "Input no longer observed",
+			Status: CompositionStatus{
+				CurrentSynthesis: &Synthesis{
+					InputRevisions: []InputRevisions{{Key: "foo", Revision: &revision1}},
+				},
+			},
+			Current:     []InputRevisions{},
+			Expectation: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			result := tt.Status.InputsDrifted(tt.Current)
+			assert.Equal(t, tt.Expectation, result)
+		})
+	}
+}
+
 func TestForceSynthesisAnnotation(t *testing.T) {
 	comp := &Composition{}
 	comp.Status.CurrentSynthesis = &Synthesis{UUID: "123"}
@@ -520,6 +599,67 @@ func TestInputsExist(t *testing.T) {
 			},
 			Expectation: true,
 		},
+		{
+			Name: "Unbound optional ref doesn't block synthesis",
+			Composition: Composition{
+				Spec: CompositionSpec{
+					Bindings: []Binding{
+						{Key: "key1"},
+					},
+				},
+				Status: CompositionStatus{
+					InputRevisions: []InputRevisions{
+						{Key: "key1"},
+					},
+				},
+			},
+			Synthesizer: Synthesizer{
+				Spec: SynthesizerSpec{
+					Refs: []Ref{
+						{Key: "key1"},
+						{Key: "key2", Optional: true},
+					},
+				},
+			},
+			Expectation: true,
+		},
+		{
+			Name: "Bound optional ref without a revision doesn't block synthesis",
+			Composition: Composition{
+				Spec: CompositionSpec{
+					Bindings: []Binding{
+						{Key: "key1"},
+					},
+				},
+				Status: CompositionStatus{
+					InputRevisions: []InputRevisions{},
+				},
+			},
+			Synthesizer: Synthesizer{
+				Spec: SynthesizerSpec{
+					Refs: []Ref{
+						{Key: "key1", Optional: true},
+					},
+				},
+			},
+			Expectation: true,
+		},
+		{
+			Name: "Optional implied binding without a revision doesn't block synthesis",
+			Composition: Composition{
+				Status: CompositionStatus{
+					InputRevisions: []InputRevisions{},
+				},
+			},
+			Synthesizer: Synthesizer{
+				Spec: SynthesizerSpec{
+					Refs: []Ref{
+						{Key: "key1", Optional: true, Resource: ResourceRef{Name: "some-resource"}},
+					},
+				},
+			},
+			Expectation: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -529,3 +669,130 @@ func TestInputsExist(t *testing.T) {
 		})
 	}
 }
+
+func TestUnresolvedRefs(t *testing.T) {
+	comp := Composition{
+		Spec: CompositionSpec{
+			Bindings: []Binding{{Key: "bound"}, {Key: "unrevised"}},
+		},
+		Status: CompositionStatus{
+			InputRevisions: []InputRevisions{{Key: "bound"}},
+		},
+	}
+	syn := Synthesizer{
+		Spec: SynthesizerSpec{
+			Refs: []Ref{
+				{Key: "bound"},
+				{Key: "unrevised"},
+				{Key: "unbound"},
+				{Key: "optional", Optional: true},
+			},
+		},
+	}
+	assert.Equal(t, []string{"unbound", "unrevised"}, comp.UnresolvedRefs(&syn))
+}
+
+func TestUnsatisfiedRefs(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Composition Composition
+		Synthesizer Synthesizer
+		Expectation []string
+	}{
+		{
+			Name: "all required refs are bound",
+			Composition: Composition{
+				Spec: CompositionSpec{
+					Bindings: []Binding{
+						{Key: "key1"},
+						{Key: "key2"},
+					},
+				},
+			},
+			Synthesizer: Synthesizer{
+				Spec: SynthesizerSpec{
+					Refs: []Ref{
+						{Key: "key1"},
+						{Key: "key2"},
+					},
+				},
+			},
+			Expectation: nil,
+		},
+		{
+			Name: "missing binding for a required ref",
+			Composition: Composition{
+				Spec: CompositionSpec{
+					Bindings: []Binding{
+						{Key: "key1"},
+					},
+				},
+			},
+			Synthesizer: Synthesizer{
+				Spec: SynthesizerSpec{
+					Refs: []Ref{
+						{Key: "key1"},
+						{Key: "key2"},
+					},
+				},
+			},
+			Expectation: []string{"key2"},
+		},
+		{
+			Name:        "optional refs are exempt",
+			Composition: Composition{},
+			Synthesizer: Synthesizer{
+				Spec: SynthesizerSpec{
+					Refs: []Ref{
+						{Key: "key1", Optional: true},
+					},
+				},
+			},
+			Expectation: nil,
+		},
+		{
+			Name:        "implied bindings are exempt",
+			Composition: Composition{},
+			Synthesizer: Synthesizer{
+				Spec: SynthesizerSpec{
+					Refs: []Ref{
+						{Key: "key1", Resource: ResourceRef{Name: "resource1"}},
+					},
+				},
+			},
+			Expectation: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			assert.Equal(t, tt.Expectation, tt.Composition.UnsatisfiedRefs(&tt.Synthesizer))
+		})
+	}
+}
+
+func TestEffectiveRefs(t *testing.T) {
+	synth := &Synthesizer{
+		Spec: SynthesizerSpec{
+			Refs: []Ref{
+				{Key: "key1", Resource: ResourceRef{Kind: "ConfigMap"}},
+				{Key: "key2", Resource: ResourceRef{Kind: "Secret"}},
+			},
+		},
+	}
+
+	// No composition refs - the synthesizer's refs are returned as-is
+	comp := &Composition{}
+	assert.Equal(t, synth.Spec.Refs, comp.EffectiveRefs(synth))
+
+	// Additive and override refs are merged, with the composition winning on key conflict
+	comp.Spec.Refs = []Ref{
+		{Key: "key2", Resource: ResourceRef{Kind: "ConfigMap"}},
+		{Key: "key3", Resource: ResourceRef{Kind: "ConfigMap"}},
+	}
+	assert.Equal(t, []Ref{
+		{Key: "key2", Resource: ResourceRef{Kind: "ConfigMap"}},
+		{Key: "key3", Resource: ResourceRef{Kind: "ConfigMap"}},
+		{Key: "key1", Resource: ResourceRef{Kind: "ConfigMap"}},
+	}, comp.EffectiveRefs(synth))
+}
@@ -5,4 +5,29 @@ type EnvVar struct {
 	// +kubebuilder:validation:MaxLength:=100
 	Name  string `json:"name"`
 	Value string `json:"value,omitempty"`
+
+	// ValueFrom resolves the value from a ConfigMap or Secret key in the
+	// composition's namespace. Takes precedence over Value when both are set.
+	//
+	// +optional
+	ValueFrom *EnvVarSource `json:"valueFrom,omitempty"`
+}
+
+// EnvVarSource references the source of an env var's value.
+// Exactly one of its fields should be set.
+type EnvVarSource struct {
+	// +optional
+	ConfigMapKeyRef *KeyRef `json:"configMapKeyRef,omitempty"`
+
+	// +optional
+	SecretKeyRef *KeyRef `json:"secretKeyRef,omitempty"`
+}
+
+// KeyRef selects a single key of a ConfigMap or Secret.
+type KeyRef struct {
+	// +required
+	Name string `json:"name"`
+
+	// +required
+	Key string `json:"key"`
 }
@@ -37,12 +37,31 @@ type Binding struct {
 	Key string `json:"key"`
 
 	Resource ResourceBinding `json:"resource"`
+
+	// Projection optionally limits the input to a set of dot-separated field paths.
+	// Only the selected fields are passed to the synthesizer, and changes to
+	// unselected fields do not trigger resynthesis.
+	// +optional
+	Projection []string `json:"projection,omitempty"`
+
+	// TrackContent derives this input's revision from a hash of the resource's
+	// content instead of its resourceVersion. Useful for resources like Secrets
+	// that rotate their data in place, or whose versions change without
+	// meaningful content changes. The content itself is never stored.
+	// +optional
+	TrackContent bool `json:"trackContent,omitempty"`
 }
 
 // A reference to a specific resource name and optionally namespace.
 type ResourceBinding struct {
 	Name      string `json:"name"`
 	Namespace string `json:"namespace,omitempty"`
+
+	// Selector binds to whichever resource matches the label selector instead
+	// of an exact name. Exactly one resource must match at synthesis time.
+	// Mutually exclusive with Name.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
 }
 
 // Ref defines a synthesizer input.
@@ -62,6 +81,9 @@ type Ref struct {
 	// A non-deferred input will trigger a synthesis immediately, whereas a
 	// deferred input will respect the cooldown period.
 	Defer bool `json:"defer,omitempty"`
+
+	// Optional refs do not need to be bound by compositions in order for synthesis to proceed.
+	Optional bool `json:"optional,omitempty"`
 }
 
 // A reference to a resource kind/group.
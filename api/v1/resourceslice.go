@@ -31,6 +31,17 @@ type Manifest struct {
 
 	// Deleted is true when this manifest represents a "tombstone" - a resource that should no longer exist.
 	Deleted bool `json:"deleted,omitempty"`
+
+	// Optional resources are best-effort: failures to reconcile them or unmet readiness
+	// checks don't block the composition's reconciled/ready status.
+	// Set during synthesis from the resource's "eno.azure.io/optional" annotation.
+	Optional bool `json:"optional,omitempty"`
+
+	// ReadinessOptional resources must still be reconciled, but their readiness doesn't
+	// block the composition's ready status or gate later readiness groups. Their
+	// individual readiness state is still recorded.
+	// Set during synthesis from the resource's "eno.azure.io/readiness-optional" annotation.
+	ReadinessOptional bool `json:"readinessOptional,omitempty"`
 }
 
 type ResourceSliceStatus struct {
@@ -42,6 +53,20 @@ type ResourceState struct {
 	Reconciled bool         `json:"reconciled,omitempty"`
 	Ready      *metav1.Time `json:"ready,omitempty"`
 	Deleted    bool         `json:"deleted,omitempty"`
+
+	// Failed is set when a readiness check's timeout elapsed before the
+	// resource became ready. Cleared if the resource later becomes ready.
+	Failed *metav1.Time `json:"failed,omitempty"`
+
+	// NormalizedVersion is set when the resource was applied at a different api
+	// version than the one emitted by the synthesizer, because the synthesized
+	// version is no longer served by the apiserver.
+	NormalizedVersion string `json:"normalizedVersion,omitempty"`
+
+	// FieldConflict describes a server-side apply conflict with another field
+	// manager, when server-side apply is enabled. Cleared once the resource is
+	// applied successfully.
+	FieldConflict string `json:"fieldConflict,omitempty"`
 }
 
 func (r *ResourceState) Equal(rr *ResourceState) bool {
@@ -51,7 +76,10 @@ func (r *ResourceState) Equal(rr *ResourceState) bool {
 	if rr == nil {
 		return false
 	}
-	if r.Reconciled != rr.Reconciled || r.Deleted != rr.Deleted {
+	if r.Reconciled != rr.Reconciled || r.Deleted != rr.Deleted || r.NormalizedVersion != rr.NormalizedVersion || r.FieldConflict != rr.FieldConflict {
+		return false
+	}
+	if (r.Failed == nil) != (rr.Failed == nil) || (r.Failed != nil && !r.Failed.Equal(rr.Failed)) {
 		return false
 	}
 	if r.Ready == nil {
@@ -39,14 +39,54 @@ type SymphonySpec struct {
 	// Copied opaquely into the compositions managed by this symphony.
 	// +kubebuilder:validation:MaxItems:=50
 	SynthesisEnv []EnvVar `json:"synthesisEnv,omitempty"` // deprecated synthesis env should always be variation scoped.
+
+	// Rollout optionally applies variation updates gradually instead of all at once.
+	// +optional
+	Rollout *RolloutStrategy `json:"rollout,omitempty"`
+
+	// Paused stops the controller from creating, updating, or deleting the
+	// symphony's compositions while still reporting status. Useful for freezing
+	// a symphony during incidents without tearing it down.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// RolloutStrategy batches updates to a symphony's compositions, waiting for each
+// batch to be reconciled before updating the next.
+type RolloutStrategy struct {
+	// BatchSize is the max number of compositions that can be updated but not yet
+	// reconciled at any point during the rollout.
+	// +kubebuilder:default:=1
+	BatchSize int `json:"batchSize,omitempty"`
+
+	// FailureWindow halts the rollout when an updated composition hasn't been
+	// reconciled within this duration. Unset means wait forever.
+	// +optional
+	FailureWindow *metav1.Duration `json:"failureWindow,omitempty"`
 }
 
 type SymphonyStatus struct {
-	ObservedGeneration int64            `json:"observedGeneration,omitempty"`
-	Synthesized        *metav1.Time     `json:"synthesized,omitempty"`
-	Reconciled         *metav1.Time     `json:"reconciled,omitempty"`
-	Ready              *metav1.Time     `json:"ready,omitempty"`
-	Synthesizers       []SynthesizerRef `json:"synthesizers,omitempty"` // deprecated
+	ObservedGeneration int64        `json:"observedGeneration,omitempty"`
+	Synthesized        *metav1.Time `json:"synthesized,omitempty"`
+	Reconciled         *metav1.Time `json:"reconciled,omitempty"`
+	Ready              *metav1.Time `json:"ready,omitempty"`
+
+	// Failed is set when any composition's current synthesis has failed a
+	// readiness check timeout. Unlike the other timestamps it surfaces as soon
+	// as any one composition fails rather than waiting for all of them.
+	Failed *metav1.Time `json:"failed,omitempty"`
+
+	// NotReadySynthesizers contains the names of the synthesizers whose
+	// compositions haven't been reconciled and become ready yet, sorted
+	// alphabetically. Empty once the symphony is ready.
+	NotReadySynthesizers []string `json:"notReadySynthesizers,omitempty"`
+
+	// VariationDependencyCycle describes a cycle formed by the variations'
+	// dependsOn references, when one exists. Compositions are not created or
+	// updated until the cycle is removed.
+	VariationDependencyCycle string `json:"variationDependencyCycle,omitempty"`
+
+	Synthesizers []SynthesizerRef `json:"synthesizers,omitempty"` // deprecated
 }
 
 type Variation struct {
@@ -68,4 +108,17 @@ type Variation struct {
 	// It gets merged with the Symhony environment and takes precedence over it.
 	// +kubebuilder:validation:MaxItems:=25
 	SynthesisEnv []EnvVar `json:"synthesisEnv,omitempty"`
+
+	// DependsOn lists the synthesizer names of other variations whose compositions
+	// must be reconciled before this variation's composition is created.
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Replicas creates that many compositions for this variation's synthesizer
+	// instead of one, each labeled with a distinct replica index and given an
+	// ENO_REPLICA_INDEX synthesis env var. Useful for sharding a synthesizer's
+	// work. Scaling down deletes the highest-index compositions first.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int `json:"replicas,omitempty"`
 }
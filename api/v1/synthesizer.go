@@ -3,6 +3,7 @@ package v1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // +kubebuilder:object:root=true
@@ -56,13 +57,135 @@ type SynthesizerSpec struct {
 
 	// PodOverrides sets values in the pods used to execute this synthesizer.
 	PodOverrides PodOverrides `json:"podOverrides,omitempty"`
+
+	// Validations are CEL expressions evaluated against every resource produced by
+	// the synthesizer. Synthesis fails when any expression does not evaluate to true.
+	// +optional
+	Validations []Validation `json:"validations,omitempty"`
+
+	// MaxInFlight caps the number of concurrent syntheses using this synthesizer,
+	// in addition to the global concurrency limit. Compositions beyond the cap
+	// stay queued without blocking other synthesizers' compositions.
+	// +optional
+	MaxInFlight *int `json:"maxInFlight,omitempty"`
+
+	// MaxResources caps how many resources a single synthesis may emit across
+	// all of its resource slices. Exceeding it fails the synthesis before any
+	// slices are written. Zero means unlimited.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxResources int `json:"maxResources,omitempty"`
+
+	// MaxManifestBytes caps the total serialized size of the manifests a single
+	// synthesis may emit across all of its resource slices. Exceeding it fails
+	// the synthesis before any slices are written. Zero means unlimited.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxManifestBytes int `json:"maxManifestBytes,omitempty"`
+
+	// ProtocolVersion declares the version of the KRM ResourceList contract the
+	// synthesizer image implements. Synthesis fails with an incompatibility error
+	// when the version isn't supported by this version of Eno. Empty is treated
+	// as the latest supported version.
+	// +optional
+	ProtocolVersion string `json:"protocolVersion,omitempty"`
+
+	// ValidateSchema checks every output resource against the cluster's OpenAPI
+	// schema before any of them are applied, failing synthesis on violations.
+	// Opt-in since resources whose CRDs haven't been installed yet can't be validated.
+	// +optional
+	ValidateSchema bool `json:"validateSchema,omitempty"`
+
+	// RetryPolicy controls how syntheses that fail are retried.
+	// When unset, retries back off proportionally to the duration of the failed attempt.
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+}
+
+// RetryPolicy configures exponential backoff for failed syntheses.
+type RetryPolicy struct {
+	// MaxAttempts caps the number of synthesis attempts. Zero means unlimited.
+	// +optional
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+
+	// BackoffBase is the delay before the first retry. Each subsequent retry doubles it.
+	// +optional
+	BackoffBase *metav1.Duration `json:"backoffBase,omitempty"`
+
+	// BackoffMax caps the delay between retries.
+	// +optional
+	BackoffMax *metav1.Duration `json:"backoffMax,omitempty"`
+}
+
+// Validate checks the spec against the rules Eno's controllers assume, so
+// admission webhooks can reject invalid synthesizers instead of discovering
+// the problem at synthesis time. The returned errors are rooted at spec.
+func (s *SynthesizerSpec) Validate() field.ErrorList {
+	errs := field.ErrorList{}
+	root := field.NewPath("spec")
+
+	if s.Image == "" {
+		errs = append(errs, field.Required(root.Child("image"), "synthesizer pods cannot be created without an image"))
+	}
+	if len(s.Command) == 0 {
+		errs = append(errs, field.Required(root.Child("command"), "defaulted to [synthesize] by the CRD schema but cannot be empty"))
+	}
+	if s.ExecTimeout != nil && s.ExecTimeout.Duration <= 0 {
+		errs = append(errs, field.Invalid(root.Child("execTimeout"), s.ExecTimeout.Duration.String(), "must be a positive duration"))
+	}
+	if s.PodTimeout != nil && s.PodTimeout.Duration <= 0 {
+		errs = append(errs, field.Invalid(root.Child("podTimeout"), s.PodTimeout.Duration.String(), "must be a positive duration"))
+	}
+	if s.ExecTimeout != nil && s.PodTimeout != nil && s.ExecTimeout.Duration > s.PodTimeout.Duration {
+		errs = append(errs, field.Invalid(root.Child("podTimeout"), s.PodTimeout.Duration.String(), "must be greater than execTimeout"))
+	}
+
+	if p := s.RetryPolicy; p != nil {
+		path := root.Child("retryPolicy")
+		if p.MaxAttempts < 0 {
+			errs = append(errs, field.Invalid(path.Child("maxAttempts"), p.MaxAttempts, "must not be negative"))
+		}
+		if p.BackoffBase != nil && p.BackoffBase.Duration <= 0 {
+			errs = append(errs, field.Invalid(path.Child("backoffBase"), p.BackoffBase.Duration.String(), "must be a positive duration"))
+		}
+		if p.BackoffMax != nil && p.BackoffMax.Duration <= 0 {
+			errs = append(errs, field.Invalid(path.Child("backoffMax"), p.BackoffMax.Duration.String(), "must be a positive duration"))
+		}
+		if p.BackoffBase != nil && p.BackoffMax != nil && p.BackoffMax.Duration < p.BackoffBase.Duration {
+			errs = append(errs, field.Invalid(path.Child("backoffMax"), p.BackoffMax.Duration.String(), "must not be less than backoffBase"))
+		}
+	}
+
+	seen := map[string]struct{}{}
+	for i, ref := range s.Refs {
+		if _, ok := seen[ref.Key]; ok {
+			errs = append(errs, field.Duplicate(root.Child("refs").Index(i).Child("key"), ref.Key))
+		}
+		seen[ref.Key] = struct{}{}
+	}
+
+	return errs
+}
+
+// Validation is a policy check applied to synthesizer output.
+type Validation struct {
+	// A CEL expression that must evaluate to true for every output resource.
+	// The resource is bound to the identifier `self`.
+	Expression string `json:"expression,omitempty"`
+
+	// Message is surfaced in the synthesis results when the expression fails.
+	// Defaults to the expression itself.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type PodOverrides struct {
-	Labels      map[string]string           `json:"labels,omitempty"`
-	Annotations map[string]string           `json:"annotations,omitempty"`
-	Resources   corev1.ResourceRequirements `json:"resources,omitempty"`
-	Affinity    *corev1.Affinity            `json:"affinity,omitempty"`
+	Labels       map[string]string           `json:"labels,omitempty"`
+	Annotations  map[string]string           `json:"annotations,omitempty"`
+	Resources    corev1.ResourceRequirements `json:"resources,omitempty"`
+	Affinity     *corev1.Affinity            `json:"affinity,omitempty"`
+	NodeSelector map[string]string           `json:"nodeSelector,omitempty"`
+	Tolerations  []corev1.Toleration         `json:"tolerations,omitempty"`
 }
 
 type SynthesizerStatus struct {
@@ -70,4 +193,11 @@ type SynthesizerStatus struct {
 
 type SynthesizerRef struct {
 	Name string `json:"name,omitempty"`
+
+	// Overrides the referenced synthesizer's image for this composition,
+	// e.g. to pin one symphony variation to a specific digest while the
+	// others float with the synthesizer.
+	//
+	// +optional
+	ImageOverride string `json:"imageOverride,omitempty"`
 }
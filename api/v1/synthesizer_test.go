@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSynthesizerSpecValidate(t *testing.T) {
+	tests := []struct {
+		Name           string
+		Spec           SynthesizerSpec
+		ExpectedErrors []string
+	}{
+		{
+			Name: "valid",
+			Spec: SynthesizerSpec{
+				Image:       "example.com/some-image:latest",
+				Command:     []string{"synthesize"},
+				ExecTimeout: &metav1.Duration{Duration: time.Second * 10},
+				PodTimeout:  &metav1.Duration{Duration: time.Minute * 2},
+				Refs:        []Ref{{Key: "foo"}, {Key: "bar"}},
+			},
+		},
+		{
+			Name: "missing image and command",
+			Spec: SynthesizerSpec{},
+			ExpectedErrors: []string{
+				"spec.image: Required value",
+				"spec.command: Required value",
+			},
+		},
+		{
+			Name: "non-positive timeouts",
+			Spec: SynthesizerSpec{
+				Image:       "example.com/some-image:latest",
+				Command:     []string{"synthesize"},
+				ExecTimeout: &metav1.Duration{Duration: -time.Second},
+				PodTimeout:  &metav1.Duration{},
+			},
+			ExpectedErrors: []string{
+				`spec.execTimeout: Invalid value: "-1s"`,
+				`spec.podTimeout: Invalid value: "0s"`,
+			},
+		},
+		{
+			Name: "exec timeout longer than pod timeout",
+			Spec: SynthesizerSpec{
+				Image:       "example.com/some-image:latest",
+				Command:     []string{"synthesize"},
+				ExecTimeout: &metav1.Duration{Duration: time.Minute * 3},
+				PodTimeout:  &metav1.Duration{Duration: time.Minute * 2},
+			},
+			ExpectedErrors: []string{
+				`spec.podTimeout: Invalid value: "2m0s": must be greater than execTimeout`,
+			},
+		},
+		{
+			Name: "duplicate ref keys",
+			Spec: SynthesizerSpec{
+				Image:   "example.com/some-image:latest",
+				Command: []string{"synthesize"},
+				Refs:    []Ref{{Key: "foo"}, {Key: "bar"}, {Key: "foo"}},
+			},
+			ExpectedErrors: []string{
+				`spec.refs[2].key: Duplicate value: "foo"`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			errs := tc.Spec.Validate()
+			assert.Len(t, errs, len(tc.ExpectedErrors))
+			for i, expected := range tc.ExpectedErrors {
+				assert.Contains(t, errs[i].Error(), expected)
+			}
+		})
+	}
+}
@@ -13,7 +13,12 @@ import (
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Binding) DeepCopyInto(out *Binding) {
 	*out = *in
-	out.Resource = in.Resource
+	in.Resource.DeepCopyInto(&out.Resource)
+	if in.Projection != nil {
+		in, out := &in.Projection, &out.Projection
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Binding.
@@ -92,13 +97,38 @@ func (in *CompositionSpec) DeepCopyInto(out *CompositionSpec) {
 	if in.Bindings != nil {
 		in, out := &in.Bindings, &out.Bindings
 		*out = make([]Binding, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.SynthesisEnv != nil {
 		in, out := &in.SynthesisEnv, &out.SynthesisEnv
 		*out = make([]EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Refs != nil {
+		in, out := &in.Refs, &out.Refs
+		*out = make([]Ref, len(*in))
+		copy(*out, *in)
+	}
+	if in.PromotedResultTags != nil {
+		in, out := &in.PromotedResultTags, &out.PromotedResultTags
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]ObjectRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.HistoryLimit != nil {
+		in, out := &in.HistoryLimit, &out.HistoryLimit
+		*out = new(int)
+		**out = **in
+	}
+	in.PodOverrides.DeepCopyInto(&out.PodOverrides)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositionSpec.
@@ -145,6 +175,31 @@ func (in *CompositionStatus) DeepCopyInto(out *CompositionStatus) {
 		in, out := &in.PendingResynthesis, &out.PendingResynthesis
 		*out = (*in).DeepCopy()
 	}
+	if in.PromotedTags != nil {
+		in, out := &in.PromotedTags, &out.PromotedTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FirstReconciled != nil {
+		in, out := &in.FirstReconciled, &out.FirstReconciled
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SynthesisHistory != nil {
+		in, out := &in.SynthesisHistory, &out.SynthesisHistory
+		*out = make([]RetainedSynthesis, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositionStatus.
@@ -160,6 +215,11 @@ func (in *CompositionStatus) DeepCopy() *CompositionStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvVar) DeepCopyInto(out *EnvVar) {
 	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(EnvVarSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvVar.
@@ -172,6 +232,31 @@ func (in *EnvVar) DeepCopy() *EnvVar {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvVarSource) DeepCopyInto(out *EnvVarSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(KeyRef)
+		**out = **in
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(KeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvVarSource.
+func (in *EnvVarSource) DeepCopy() *EnvVarSource {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvVarSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Input) DeepCopyInto(out *Input) {
 	*out = *in
@@ -229,6 +314,21 @@ func (in *InputRevisions) DeepCopy() *InputRevisions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyRef) DeepCopyInto(out *KeyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyRef.
+func (in *KeyRef) DeepCopy() *KeyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Manifest) DeepCopyInto(out *Manifest) {
 	*out = *in
@@ -244,6 +344,21 @@ func (in *Manifest) DeepCopy() *Manifest {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectRef) DeepCopyInto(out *ObjectRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectRef.
+func (in *ObjectRef) DeepCopy() *ObjectRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodOverrides) DeepCopyInto(out *PodOverrides) {
 	*out = *in
@@ -267,6 +382,20 @@ func (in *PodOverrides) DeepCopyInto(out *PodOverrides) {
 		*out = new(corev1.Affinity)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodOverrides.
@@ -298,6 +427,11 @@ func (in *Ref) DeepCopy() *Ref {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceBinding) DeepCopyInto(out *ResourceBinding) {
 	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceBinding.
@@ -448,6 +582,10 @@ func (in *ResourceState) DeepCopyInto(out *ResourceState) {
 		in, out := &in.Ready, &out.Ready
 		*out = (*in).DeepCopy()
 	}
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceState.
@@ -482,6 +620,81 @@ func (in *Result) DeepCopy() *Result {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetainedSynthesis) DeepCopyInto(out *RetainedSynthesis) {
+	*out = *in
+	if in.Synthesized != nil {
+		in, out := &in.Synthesized, &out.Synthesized
+		*out = (*in).DeepCopy()
+	}
+	if in.ResourceSlices != nil {
+		in, out := &in.ResourceSlices, &out.ResourceSlices
+		*out = make([]*ResourceSliceRef, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ResourceSliceRef)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetainedSynthesis.
+func (in *RetainedSynthesis) DeepCopy() *RetainedSynthesis {
+	if in == nil {
+		return nil
+	}
+	out := new(RetainedSynthesis)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.BackoffBase != nil {
+		in, out := &in.BackoffBase, &out.BackoffBase
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.BackoffMax != nil {
+		in, out := &in.BackoffMax, &out.BackoffMax
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
+	*out = *in
+	if in.FailureWindow != nil {
+		in, out := &in.FailureWindow, &out.FailureWindow
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategy.
+func (in *RolloutStrategy) DeepCopy() *RolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SimplifiedStatus) DeepCopyInto(out *SimplifiedStatus) {
 	*out = *in
@@ -569,12 +782,21 @@ func (in *SymphonySpec) DeepCopyInto(out *SymphonySpec) {
 	if in.Bindings != nil {
 		in, out := &in.Bindings, &out.Bindings
 		*out = make([]Binding, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.SynthesisEnv != nil {
 		in, out := &in.SynthesisEnv, &out.SynthesisEnv
 		*out = make([]EnvVar, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStrategy)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -603,6 +825,15 @@ func (in *SymphonyStatus) DeepCopyInto(out *SymphonyStatus) {
 		in, out := &in.Ready, &out.Ready
 		*out = (*in).DeepCopy()
 	}
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = (*in).DeepCopy()
+	}
+	if in.NotReadySynthesizers != nil {
+		in, out := &in.NotReadySynthesizers, &out.NotReadySynthesizers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Synthesizers != nil {
 		in, out := &in.Synthesizers, &out.Synthesizers
 		*out = make([]SynthesizerRef, len(*in))
@@ -643,10 +874,18 @@ func (in *Synthesis) DeepCopyInto(out *Synthesis) {
 		in, out := &in.Ready, &out.Ready
 		*out = (*in).DeepCopy()
 	}
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = (*in).DeepCopy()
+	}
 	if in.Canceled != nil {
 		in, out := &in.Canceled, &out.Canceled
 		*out = (*in).DeepCopy()
 	}
+	if in.LastAttempt != nil {
+		in, out := &in.LastAttempt, &out.LastAttempt
+		*out = (*in).DeepCopy()
+	}
 	if in.ResourceSlices != nil {
 		in, out := &in.ResourceSlices, &out.ResourceSlices
 		*out = make([]*ResourceSliceRef, len(*in))
@@ -672,6 +911,11 @@ func (in *Synthesis) DeepCopyInto(out *Synthesis) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastError != nil {
+		in, out := &in.LastError, &out.LastError
+		*out = new(SynthesisError)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Synthesis.
@@ -684,6 +928,21 @@ func (in *Synthesis) DeepCopy() *Synthesis {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SynthesisError) DeepCopyInto(out *SynthesisError) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SynthesisError.
+func (in *SynthesisError) DeepCopy() *SynthesisError {
+	if in == nil {
+		return nil
+	}
+	out := new(SynthesisError)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Synthesizer) DeepCopyInto(out *Synthesizer) {
 	*out = *in
@@ -782,6 +1041,21 @@ func (in *SynthesizerSpec) DeepCopyInto(out *SynthesizerSpec) {
 		copy(*out, *in)
 	}
 	in.PodOverrides.DeepCopyInto(&out.PodOverrides)
+	if in.Validations != nil {
+		in, out := &in.Validations, &out.Validations
+		*out = make([]Validation, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxInFlight != nil {
+		in, out := &in.MaxInFlight, &out.MaxInFlight
+		*out = new(int)
+		**out = **in
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SynthesizerSpec.
@@ -809,6 +1083,21 @@ func (in *SynthesizerStatus) DeepCopy() *SynthesizerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Validation) DeepCopyInto(out *Validation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Validation.
+func (in *Validation) DeepCopy() *Validation {
+	if in == nil {
+		return nil
+	}
+	out := new(Validation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Variation) DeepCopyInto(out *Variation) {
 	*out = *in
@@ -830,13 +1119,27 @@ func (in *Variation) DeepCopyInto(out *Variation) {
 	if in.Bindings != nil {
 		in, out := &in.Bindings, &out.Bindings
 		*out = make([]Binding, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.SynthesisEnv != nil {
 		in, out := &in.SynthesisEnv, &out.SynthesisEnv
 		*out = make([]EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Variation.
@@ -24,6 +24,7 @@ import (
 	"github.com/Azure/eno/internal/controllers/symphony"
 	"github.com/Azure/eno/internal/controllers/synthesis"
 	"github.com/Azure/eno/internal/controllers/watch"
+	"github.com/Azure/eno/internal/discovery"
 	"github.com/Azure/eno/internal/execution"
 	"github.com/Azure/eno/internal/manager"
 )
@@ -47,15 +48,22 @@ func main() {
 func runController() error {
 	ctx := ctrl.SetupSignalHandler()
 	var (
-		debugLogging             bool
-		watchdogThres            time.Duration
-		rolloutCooldown          time.Duration
-		selfHealingGracePeriod   time.Duration
-		taintToleration          string
-		nodeAffinity             string
-		concurrencyLimit         int
-		containerCreationTimeout time.Duration
-		synconf                  = &synthesis.Config{}
+		debugLogging                bool
+		watchdogThres               time.Duration
+		rolloutCooldown             time.Duration
+		selfHealingGracePeriod      time.Duration
+		taintToleration             string
+		nodeAffinity                string
+		compositionPodLabels        string
+		concurrencyLimit            int
+		nsConcurrencyLimit          int
+		sliceCompactionMaxBytes     int
+		containerCreationTimeout    time.Duration
+		orphanPodSweepInterval      time.Duration
+		stuckSynthesisSweepInterval time.Duration
+		stuckSynthesisGracePeriod   time.Duration
+		podRetention                time.Duration
+		synconf                     = &synthesis.Config{}
 
 		mgrOpts = &manager.Options{
 			Rest: ctrl.GetConfigOrDie(),
@@ -65,18 +73,28 @@ func runController() error {
 	flag.StringVar(&synconf.ExecutorImage, "executor-image", os.Getenv("EXECUTOR_IMAGE"), "Reference to the image that will be used to execute synthesizers. Defaults to EXECUTOR_IMAGE.")
 	flag.StringVar(&synconf.PodServiceAccount, "synthesizer-pod-service-account", "", "Service account name to be assigned to synthesizer Pods.")
 	flag.DurationVar(&containerCreationTimeout, "container-creation-ttl", time.Second*3, "Timeout when waiting for kubelet to ack scheduled pods. Protects tail latency from kubelet network partitions")
+	flag.DurationVar(&orphanPodSweepInterval, "orphan-pod-sweep-interval", time.Minute, "How often to sweep for synthesizer pods whose composition no longer exists")
+	flag.DurationVar(&stuckSynthesisSweepInterval, "stuck-synthesis-sweep-interval", time.Minute, "How often to sweep for in-flight syntheses whose pod never materialized")
+	flag.DurationVar(&stuckSynthesisGracePeriod, "stuck-synthesis-grace-period", time.Minute*2, "How long an in-flight synthesis can go without a pod before it's re-enqueued for pod creation")
+	flag.DurationVar(&podRetention, "pod-retention", 0, "How long successful synthesizer pods are retained after completion e.g. for external log collectors. Zero deletes them immediately")
 	flag.BoolVar(&debugLogging, "debug", true, "Enable debug logging")
 	flag.DurationVar(&watchdogThres, "watchdog-threshold", time.Minute*3, "How long before the watchdog considers a mid-transition resource to be stuck")
 	flag.DurationVar(&rolloutCooldown, "rollout-cooldown", time.Minute, "How long before an update to a related resource (synthesizer, bindings, etc.) will trigger a second composition's re-synthesis")
 	flag.StringVar(&taintToleration, "taint-toleration", "", "Node NoSchedule taint to be tolerated by synthesizer pods e.g. taintKey=taintValue to match on value, just taintKey to match on presence of the taint")
 	flag.StringVar(&nodeAffinity, "node-affinity", "", "Synthesizer pods will be created with this required node affinity expression e.g. labelKey=labelValue to match on value, just labelKey to match on presence of the label")
+	flag.StringVar(&compositionPodLabels, "composition-pod-labels", "", "Comma-separated list of label keys to be copied from compositions onto their synthesizer pods e.g. for cost attribution")
 	flag.IntVar(&concurrencyLimit, "concurrency-limit", 10, "Upper bound on active syntheses. This effectively limits the number of running synthesizer pods spawned by Eno.")
+	flag.IntVar(&nsConcurrencyLimit, "namespace-concurrency-limit", 0, "Upper bound on active syntheses per composition namespace. Protects namespace quotas from large fan-outs e.g. symphonies with many variations. Zero disables the limit.")
 	flag.DurationVar(&selfHealingGracePeriod, "self-healing-grace-period", time.Minute*5, "How long before the self-healing controllers are allowed to start the resynthesis process.")
+	flag.IntVar(&sliceCompactionMaxBytes, "slice-compaction-max-bytes", 0, "Byte budget per resource slice when compacting a completed synthesis's small slices into fewer larger ones. Zero disables compaction.")
 	mgrOpts.Bind(flag.CommandLine)
 	flag.Parse()
 
 	synconf.NodeAffinityKey, synconf.NodeAffinityValue = parseKeyValue(nodeAffinity)
 	synconf.TaintTolerationKey, synconf.TaintTolerationValue = parseKeyValue(taintToleration)
+	if compositionPodLabels != "" {
+		synconf.CompositionLabelKeys = strings.Split(compositionPodLabels, ",")
+	}
 
 	if synconf.ExecutorImage == "" {
 		return fmt.Errorf("a value is required in --executor-image or EXECUTOR_IMAGE")
@@ -107,11 +125,31 @@ func runController() error {
 		return fmt.Errorf("constructing pod lifecycle controller: %w", err)
 	}
 
-	err = synthesis.NewPodGC(mgr, containerCreationTimeout)
+	err = synthesis.NewPodGC(mgr, containerCreationTimeout, podRetention)
 	if err != nil {
 		return fmt.Errorf("constructing pod garbage collector: %w", err)
 	}
 
+	err = synthesis.NewOrphanPodSweeper(mgr, orphanPodSweepInterval)
+	if err != nil {
+		return fmt.Errorf("constructing orphaned pod sweeper: %w", err)
+	}
+
+	err = synthesis.NewStuckSynthesisWatchdog(mgr, synconf, stuckSynthesisSweepInterval, stuckSynthesisGracePeriod)
+	if err != nil {
+		return fmt.Errorf("constructing stuck synthesis watchdog: %w", err)
+	}
+
+	err = synthesis.NewInputsConditionController(mgr)
+	if err != nil {
+		return fmt.Errorf("constructing inputs condition controller: %w", err)
+	}
+
+	err = synthesis.NewLatencyMetricsController(mgr)
+	if err != nil {
+		return fmt.Errorf("constructing latency metrics controller: %w", err)
+	}
+
 	err = resourceslice.NewController(mgr)
 	if err != nil {
 		return fmt.Errorf("constructing resource slice controller: %w", err)
@@ -122,12 +160,29 @@ func runController() error {
 		return fmt.Errorf("constructing resource slice cleanup controller: %w", err)
 	}
 
+	err = resourceslice.NewConditionController(mgr)
+	if err != nil {
+		return fmt.Errorf("constructing resource slice condition controller: %w", err)
+	}
+
+	if sliceCompactionMaxBytes > 0 {
+		err = resourceslice.NewCompactionController(mgr, sliceCompactionMaxBytes)
+		if err != nil {
+			return fmt.Errorf("constructing resource slice compaction controller: %w", err)
+		}
+	}
+
 	err = watch.NewController(mgr)
 	if err != nil {
 		return fmt.Errorf("constructing watch controller: %w", err)
 	}
 
-	err = scheduling.NewController(mgr, concurrencyLimit, rolloutCooldown, watchdogThres)
+	err = scheduling.NewDependenciesConditionController(mgr)
+	if err != nil {
+		return fmt.Errorf("constructing dependencies condition controller: %w", err)
+	}
+
+	err = scheduling.NewController(mgr, concurrencyLimit, nsConcurrencyLimit, rolloutCooldown, watchdogThres)
 	if err != nil {
 		return fmt.Errorf("constructing synthesis scheduling controller: %w", err)
 	}
@@ -209,10 +264,17 @@ func runExecutor() {
 		os.Exit(1)
 	}
 
+	disc, err := discovery.NewCache(rc, 2)
+	if err != nil {
+		logger.Error(err, "building discovery cache")
+		os.Exit(1)
+	}
+
 	e := &execution.Executor{
-		Reader:  client,
-		Writer:  client,
-		Handler: execution.NewExecHandler(),
+		Reader:       client,
+		Writer:       client,
+		Handler:      execution.NewExecHandler(),
+		SchemaGetter: disc,
 	}
 	err = e.Synthesize(ctx, execution.LoadEnv())
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/go-logr/zapr"
@@ -19,6 +20,7 @@ import (
 	"github.com/Azure/eno/internal/flowcontrol"
 	"github.com/Azure/eno/internal/k8s"
 	"github.com/Azure/eno/internal/manager"
+	"github.com/Azure/eno/internal/resource"
 )
 
 func main() {
@@ -38,6 +40,9 @@ func run() error {
 		compositionNamespace         string
 		namespaceCreationGracePeriod time.Duration
 		namespaceCleanup             bool
+		conflictPolicies             string
+		protectedNamespaces          string
+		retainedMetadataKeys         string
 
 		mgrOpts = &manager.Options{
 			Rest: ctrl.GetConfigOrDie(),
@@ -57,6 +62,12 @@ func run() error {
 	flag.StringVar(&compositionNamespace, "composition-namespace", metav1.NamespaceAll, "Optional namespace to limit compositions that will be reconciled")
 	flag.DurationVar(&namespaceCreationGracePeriod, "ns-creation-grace-period", time.Second, "A namespace is assumed to be missing if it doesn't exist once one of its resources has existed for this long")
 	flag.BoolVar(&namespaceCleanup, "namespace-cleanup", true, "Clean up orphaned resources caused by namespace force-deletions")
+	flag.StringVar(&conflictPolicies, "conflict-policies", "", "Optional per-type update conflict policies as comma-separated group/version/Kind=policy pairs e.g. apps/v1/Deployment=skip. Policies: force, skip, fail (default)")
+	flag.BoolVar(&recOpts.WatchDrift, "watch-drift", false, "Watch the kinds Eno manages so out-of-band modifications are reconciled immediately instead of waiting for the next reconcile interval")
+	flag.BoolVar(&recOpts.ServerSideApply, "server-side-apply", false, "Apply resources with server-side apply under the \"eno\" field manager instead of client-side three-way merges, letting the apiserver resolve conflicts with other controllers")
+	flag.StringVar(&protectedNamespaces, "protected-namespaces", "kube-system,kube-node-lease,kube-public", "Comma-separated namespaces that resources will not be written into unless the composition sets the eno.azure.io/allow-protected-namespaces annotation")
+	flag.StringVar(&retainedMetadataKeys, "retained-metadata-keys", "", "Comma-separated eno.azure.io annotation/label keys that are left on applied objects instead of being pruned e.g. for external debugging tools")
+	flag.StringVar(&recOpts.CacheDebugAddr, "cache-debug-addr", "", "Optional address of a listener that serves read-only dumps of the reconstitution cache for debugging. Disabled when empty")
 	mgrOpts.Bind(flag.CommandLine)
 	flag.Parse()
 
@@ -111,6 +122,16 @@ func run() error {
 	recOpts.Manager = mgr
 	recOpts.WriteBuffer = writeBuffer
 	recOpts.Downstream = remoteConfig
+	recOpts.ConflictPolicies, err = reconciliation.ParseConflictPolicies(conflictPolicies)
+	if err != nil {
+		return fmt.Errorf("invalid conflict policies: %w", err)
+	}
+	if protectedNamespaces != "" {
+		recOpts.ProtectedNamespaces = strings.Split(protectedNamespaces, ",")
+	}
+	if retainedMetadataKeys != "" {
+		resource.RetainMetadataKeys(strings.Split(retainedMetadataKeys, ",")...)
+	}
 
 	err = reconciliation.New(mgr, recOpts)
 	if err != nil {
@@ -3,6 +3,7 @@ package composition
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	krmv1 "github.com/Azure/eno/pkg/krm/functions/api/v1"
@@ -197,8 +198,14 @@ func buildSimplifiedStatus(synth *apiv1.Synthesizer, comp *apiv1.Composition) *a
 		return status
 	}
 
-	if !comp.InputsExist(synth) {
+	if unsatisfied := comp.UnsatisfiedRefs(synth); len(unsatisfied) > 0 {
+		status.Status = "MissingBindings"
+		status.Error = fmt.Sprintf("refs are not bound by the composition: %s", strings.Join(unsatisfied, ", "))
+		return status
+	}
+	if unresolved := comp.UnresolvedRefs(synth); len(unresolved) > 0 {
 		status.Status = "MissingInputs"
+		status.Error = fmt.Sprintf("input resources have not been resolved for refs: %s", strings.Join(unresolved, ", "))
 		return status
 	}
 	if comp.InputsOutOfLockstep(synth) {
@@ -0,0 +1,73 @@
+package reconciliation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestApplyUpdateLargeObjects(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.SchemeBuilder.AddToScheme(scheme))
+
+	var updates, patches int
+	downstream := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				updates++
+				return cli.Update(ctx, obj, opts...)
+			},
+			Patch: func(ctx context.Context, cli client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				patches++
+				return cli.Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+	c := &Controller{upstreamClient: downstream}
+
+	newCM := func(name, value string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": name, "namespace": "default"},
+			"data":       map[string]any{"key": value},
+		}}
+	}
+
+	// Small objects take the plain update path
+	small := newCM("small", "initial")
+	require.NoError(t, downstream.Create(ctx, small.DeepCopy()))
+	current := small.DeepCopy()
+	require.NoError(t, downstream.Get(ctx, client.ObjectKeyFromObject(small), current))
+	updated := current.DeepCopy()
+	require.NoError(t, unstructured.SetNestedField(updated.Object, "updated", "data", "key"))
+	require.NoError(t, c.applyUpdate(ctx, c.upstreamClient, current, updated))
+	assert.Equal(t, 1, updates)
+	assert.Zero(t, patches)
+
+	// Large objects are applied as merge patches
+	large := newCM("large", strings.Repeat("x", largeObjectThresholdBytes))
+	require.NoError(t, downstream.Create(ctx, large.DeepCopy()))
+	current = large.DeepCopy()
+	require.NoError(t, downstream.Get(ctx, client.ObjectKeyFromObject(large), current))
+	updated = current.DeepCopy()
+	require.NoError(t, unstructured.SetNestedField(updated.Object, "sentinel", "data", "extra"))
+	require.NoError(t, c.applyUpdate(ctx, c.upstreamClient, current, updated))
+	assert.Equal(t, 1, updates)
+	assert.Equal(t, 1, patches)
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, downstream.Get(ctx, client.ObjectKeyFromObject(large), cm))
+	assert.Equal(t, "sentinel", cm.Data["extra"])
+}
@@ -0,0 +1,64 @@
+package reconciliation
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ConflictPolicy controls how update conflicts are handled for a particular resource type.
+type ConflictPolicy string
+
+const (
+	// ConflictPolicyFail surfaces the conflict error and lets the workqueue retry the full reconciliation.
+	ConflictPolicyFail ConflictPolicy = "fail"
+
+	// ConflictPolicyForce immediately retries the update against the latest resource version,
+	// overwriting whatever was written concurrently.
+	ConflictPolicyForce ConflictPolicy = "force"
+
+	// ConflictPolicySkip drops the update, deferring to whichever client wrote concurrently.
+	ConflictPolicySkip ConflictPolicy = "skip"
+)
+
+// ParseConflictPolicies parses a comma-separated set of group/version/Kind=policy pairs
+// e.g. "apps/v1/Deployment=skip,eno.azure.io/v1/Composition=force". The group is omitted
+// for core types e.g. "v1/ConfigMap=skip". Types without a policy default to fail.
+func ParseConflictPolicies(str string) (map[schema.GroupVersionKind]ConflictPolicy, error) {
+	if str == "" {
+		return nil, nil
+	}
+
+	policies := map[schema.GroupVersionKind]ConflictPolicy{}
+	for _, pair := range strings.Split(str, ",") {
+		gvkStr, policyStr, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid conflict policy %q: expected group/version/Kind=policy", pair)
+		}
+
+		policy := ConflictPolicy(policyStr)
+		switch policy {
+		case ConflictPolicyFail, ConflictPolicyForce, ConflictPolicySkip:
+		default:
+			return nil, fmt.Errorf("invalid conflict policy %q: unknown policy %q", pair, policyStr)
+		}
+
+		i := strings.LastIndex(gvkStr, "/")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid conflict policy %q: expected group/version/Kind=policy", pair)
+		}
+		gv, err := schema.ParseGroupVersion(gvkStr[:i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid conflict policy %q: %w", pair, err)
+		}
+		kind := gvkStr[i+1:]
+		if gv.Version == "" || kind == "" {
+			return nil, fmt.Errorf("invalid conflict policy %q: expected group/version/Kind=policy", pair)
+		}
+
+		policies[gv.WithKind(kind)] = policy
+	}
+
+	return policies, nil
+}
@@ -0,0 +1,95 @@
+package reconciliation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/Azure/eno/internal/resource"
+)
+
+func TestParseConflictPolicies(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Input    string
+		Expected map[schema.GroupVersionKind]ConflictPolicy
+		Error    bool
+	}{
+		{
+			Name:     "empty",
+			Input:    "",
+			Expected: nil,
+		},
+		{
+			Name:  "single",
+			Input: "apps/v1/Deployment=skip",
+			Expected: map[schema.GroupVersionKind]ConflictPolicy{
+				{Group: "apps", Version: "v1", Kind: "Deployment"}: ConflictPolicySkip,
+			},
+		},
+		{
+			Name:  "core group",
+			Input: "v1/ConfigMap=force",
+			Expected: map[schema.GroupVersionKind]ConflictPolicy{
+				{Version: "v1", Kind: "ConfigMap"}: ConflictPolicyForce,
+			},
+		},
+		{
+			Name:  "multiple",
+			Input: "apps/v1/Deployment=skip,eno.azure.io/v1/Composition=force,v1/Service=fail",
+			Expected: map[schema.GroupVersionKind]ConflictPolicy{
+				{Group: "apps", Version: "v1", Kind: "Deployment"}:          ConflictPolicySkip,
+				{Group: "eno.azure.io", Version: "v1", Kind: "Composition"}: ConflictPolicyForce,
+				{Version: "v1", Kind: "Service"}:                            ConflictPolicyFail,
+			},
+		},
+		{
+			Name:  "missing policy",
+			Input: "apps/v1/Deployment",
+			Error: true,
+		},
+		{
+			Name:  "unknown policy",
+			Input: "apps/v1/Deployment=overwrite",
+			Error: true,
+		},
+		{
+			Name:  "missing kind",
+			Input: "Deployment=skip",
+			Error: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			actual, err := ParseConflictPolicies(tt.Input)
+			if tt.Error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.Expected, actual)
+		})
+	}
+}
+
+func TestResolveConflictPolicy(t *testing.T) {
+	cmGVK := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	c := &Controller{conflictPolicies: map[schema.GroupVersionKind]ConflictPolicy{
+		cmGVK: ConflictPolicyFail,
+	}}
+
+	// The force-conflicts annotation overrides the type-level policy
+	forced := &resource.Resource{GVK: cmGVK, ForceConflicts: true}
+	assert.Equal(t, ConflictPolicyForce, c.resolveConflictPolicy(forced))
+
+	// Siblings of the same type still fail on conflict
+	sibling := &resource.Resource{GVK: cmGVK}
+	assert.Equal(t, ConflictPolicyFail, c.resolveConflictPolicy(sibling))
+
+	// Types without any policy default to the zero value (fail)
+	other := &resource.Resource{GVK: schema.GroupVersionKind{Version: "v1", Kind: "Secret"}}
+	assert.Equal(t, ConflictPolicy(""), c.resolveConflictPolicy(other))
+}
@@ -1,19 +1,25 @@
 package reconciliation
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -25,6 +31,7 @@ import (
 	"github.com/Azure/eno/internal/discovery"
 	"github.com/Azure/eno/internal/flowcontrol"
 	"github.com/Azure/eno/internal/manager"
+	"github.com/Azure/eno/internal/readiness"
 	"github.com/Azure/eno/internal/resource"
 	"github.com/go-logr/logr"
 )
@@ -41,6 +48,41 @@ type Options struct {
 	Timeout               time.Duration
 	ReadinessPollInterval time.Duration
 	MinReconcileInterval  time.Duration
+
+	// ConflictPolicies optionally overrides how update conflicts are handled per resource type.
+	// Types without a policy default to ConflictPolicyFail.
+	ConflictPolicies map[schema.GroupVersionKind]ConflictPolicy
+
+	// ProtectedNamespaces are never written to unless the composition explicitly
+	// opts out of the protection. Deletion of previously-created resources is
+	// still allowed so cleanup isn't blocked.
+	ProtectedNamespaces []string
+
+	// NormalizeVersions rewrites resources synthesized at api versions the cluster
+	// no longer serves to the highest-priority served version before applying them.
+	NormalizeVersions bool
+
+	// CompositionQPS caps the rate of apiserver writes per composition.
+	// Zero means unlimited.
+	CompositionQPS float64
+
+	// WatchDrift watches the kinds Eno manages so out-of-band modifications are
+	// reconciled immediately instead of waiting for the next reconcile interval.
+	WatchDrift bool
+
+	// ServerSideApply applies resources with server-side apply under a stable
+	// field manager instead of client-side three-way merges, letting the
+	// apiserver resolve conflicts with other controllers that own fields.
+	ServerSideApply bool
+
+	// CacheDebugAddr optionally serves a read-only HTTP dump of the
+	// reconstitution cache. Empty disables the listener.
+	CacheDebugAddr string
+
+	// TargetClusters optionally registers spoke cluster clients by name.
+	// Resources annotated with eno.azure.io/target-cluster are reconciled into
+	// the matching cluster; resources without the annotation apply locally.
+	TargetClusters map[string]client.Client
 }
 
 type Controller struct {
@@ -52,6 +94,15 @@ type Controller struct {
 	upstreamClient        client.Client
 	discovery             *discovery.Cache
 	minReconcileInterval  time.Duration
+	conflictPolicies      map[schema.GroupVersionKind]ConflictPolicy
+	protectedNamespaces   map[string]struct{}
+	normalizeVersions     bool
+	serverSideApply       bool
+	writeRateLimit        *flowcontrol.CompositionRateLimiter
+	targetClients         *targetClientCache
+
+	mut              sync.Mutex
+	clampedIntervals map[resource.Ref]struct{} // resources already warned about a sub-floor reconcile interval
 }
 
 func New(mgr ctrl.Manager, opts Options) error {
@@ -66,6 +117,9 @@ func New(mgr ctrl.Manager, opts Options) error {
 	if err != nil {
 		return err
 	}
+	if opts.CacheDebugAddr != "" {
+		serveCacheDebug(opts.CacheDebugAddr, cache)
+	}
 
 	disc, err := discovery.NewCache(opts.Downstream, opts.DiscoveryRPS)
 	if err != nil {
@@ -81,9 +135,44 @@ func New(mgr ctrl.Manager, opts Options) error {
 		upstreamClient:        upstreamClient,
 		discovery:             disc,
 		minReconcileInterval:  opts.MinReconcileInterval,
+		conflictPolicies:      opts.ConflictPolicies,
+		protectedNamespaces:   map[string]struct{}{},
+		normalizeVersions:     opts.NormalizeVersions,
+		serverSideApply:       opts.ServerSideApply,
+		targetClients:         newTargetClientCache(opts.Manager.GetAPIReader(), upstreamClient, opts.TargetClusters),
+	}
+	for _, ns := range opts.ProtectedNamespaces {
+		c.protectedNamespaces[ns] = struct{}{}
+	}
+	if opts.CompositionQPS > 0 {
+		c.writeRateLimit = flowcontrol.NewCompositionRateLimiter(opts.CompositionQPS)
 	}
 
-	return builder.TypedControllerManagedBy[resource.Request](mgr).
+	err = ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1.Composition{}).
+		WithLogConstructor(manager.NewLogConstructor(mgr, "pruneController")).
+		Complete(&pruneController{
+			client:         opts.Manager.GetClient(),
+			upstreamClient: upstreamClient,
+			cache:          cache,
+		})
+	if err != nil {
+		return err
+	}
+
+	err = ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1.Composition{}).
+		WithLogConstructor(manager.NewLogConstructor(mgr, "crossPatchController")).
+		Complete(&crossPatchController{
+			client:         opts.Manager.GetClient(),
+			upstreamClient: upstreamClient,
+			cache:          cache,
+		})
+	if err != nil {
+		return err
+	}
+
+	b := builder.TypedControllerManagedBy[resource.Request](mgr).
 		Named("reconciliationController").
 		WithLogConstructor(manager.NewTypedLogConstructor[*resource.Request](mgr, "reconciliationController")).
 		WithOptions(controller.TypedOptions[resource.Request]{
@@ -94,8 +183,17 @@ func New(mgr ctrl.Manager, opts Options) error {
 			// the additional shared/global/non-item-scoped limiter.
 			RateLimiter: workqueue.NewTypedItemExponentialFailureRateLimiter[resource.Request](5*time.Millisecond, 1000*time.Second),
 		}).
-		WatchesRawSource(src).
-		Complete(c)
+		WatchesRawSource(src)
+
+	if opts.WatchDrift {
+		ds, err := newDriftSource(opts.Downstream, cache)
+		if err != nil {
+			return err
+		}
+		b = b.WatchesRawSource(ds)
+	}
+
+	return b.Complete(c)
 }
 
 func (c *Controller) Reconcile(ctx context.Context, req resource.Request) (ctrl.Result, error) {
@@ -112,6 +210,9 @@ func (c *Controller) Reconcile(ctx context.Context, req resource.Request) (ctrl.
 	if comp.Status.CurrentSynthesis == nil {
 		return ctrl.Result{}, nil // nothing to do
 	}
+	if comp.NotEligibleForResynthesis() {
+		return ctrl.Result{}, nil // one-shot composition has already converged
+	}
 	logger = logger.WithValues("synthesizerName", comp.Spec.Synthesizer.Name,
 		"synthesizerGeneration", comp.Status.CurrentSynthesis.ObservedSynthesizerGeneration,
 		"synthesisID", comp.Status.GetCurrentSynthesisUUID())
@@ -130,8 +231,36 @@ func (c *Controller) Reconcile(ctx context.Context, req resource.Request) (ctrl.
 		prev, _, _ = c.resourceClient.Get(ctx, syn.UUID, req.Resource)
 	}
 
+	// Sealed resources are frozen once they've been reconciled - no drift correction
+	// or periodic reconciliation until the composition is resynthesized, which
+	// replaces the latched state.
+	if resource.SealAfterApply && !resource.Deleted(comp) {
+		if state := resource.State(); state != nil && state.Reconciled {
+			logger.V(1).Info("skipping reconciliation of sealed resource")
+			return ctrl.Result{}, nil
+		}
+	}
+
+	// Optionally rewrite the resource to an api version the cluster still serves
+	var normalizedVersion string
+	if c.normalizeVersions && !resource.IsPatch() {
+		served, ok := c.discovery.PreferredVersion(ctx, resource.GVK)
+		if ok && served != resource.GVK.Version {
+			normalizedVersion = served
+			logger = logger.WithValues("normalizedVersion", served)
+			ctx = logr.NewContext(ctx, logger)
+		}
+	}
+
+	// Resolve the client for the resource's target cluster
+	upstream, err := c.targetClients.Get(ctx, comp, resource)
+	if err != nil {
+		logger.Error(err, "failed to resolve target cluster client")
+		return ctrl.Result{}, err
+	}
+
 	// Fetch the current resource
-	current, err := c.getCurrent(ctx, resource)
+	current, err := c.getCurrent(ctx, upstream, resource, normalizedVersion)
 	if client.IgnoreNotFound(err) != nil && !isErrMissingNS(err) {
 		logger.Error(err, "failed to get current state")
 		return ctrl.Result{}, err
@@ -144,15 +273,39 @@ func (c *Controller) Reconcile(ctx context.Context, req resource.Request) (ctrl.
 	var ready *metav1.Time
 	status := resource.State()
 	if status == nil || status.Ready == nil {
-		readiness, ok := resource.ReadinessChecks.EvalOptionally(ctx, current)
+		evalCtx := ctx
+		if len(resource.ReadinessChecks) > 0 {
+			evalCtx = readiness.NewCompositionContext(ctx, c.compositionState(comp, resource))
+		}
+		readiness, ok := resource.ReadinessChecks.EvalOptionally(evalCtx, current)
 		if ok {
 			ready = &readiness.ReadyTime
 		}
+		if !resource.ObserveReadiness(ok, time.Now()) {
+			// Hold the previously recorded state until the new outcome has been
+			// stable for the resource's readiness dwell period
+			ready = nil
+			if status != nil {
+				ready = status.Ready
+			}
+		}
 	} else {
 		ready = status.Ready
 	}
 
-	modified, err := c.reconcileResource(ctx, comp, prev, resource, current)
+	// Resources that miss their readiness check timeout are reported as failed
+	// so status aggregation can reach a terminal state instead of waiting forever
+	var failed *metav1.Time
+	if ready == nil {
+		if timeout := resource.ReadinessChecks.Timeout(); timeout > 0 {
+			if syn := comp.Status.CurrentSynthesis; syn != nil && syn.Synthesized != nil && time.Since(syn.Synthesized.Time) > timeout {
+				now := metav1.Now()
+				failed = &now
+			}
+		}
+	}
+
+	modified, err := c.reconcileResource(ctx, upstream, comp, prev, resource, current, normalizedVersion)
 	if err != nil {
 		logger.Error(err, "failed to reconcile resource")
 		return ctrl.Result{}, err
@@ -161,15 +314,47 @@ func (c *Controller) Reconcile(ctx context.Context, req resource.Request) (ctrl.
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	// Delete-intent resources aren't reconciled until the object is confirmed absent -
+	// a lingering object (e.g. held by finalizers) hasn't actually been deleted yet.
+	if resource.Deleted(comp) && !comp.ShouldOrphanResources() && !resource.CreateOnly && !resource.Orphan && current != nil {
+		logger.V(1).Info("resource still exists after deletion - waiting for it to be finalized")
+		c.writeBuffer.PatchStatusAsync(ctx, &resource.ManifestRef, patchPendingDeletionState())
+		return ctrl.Result{RequeueAfter: wait.Jitter(c.readinessPollInterval, 0.1)}, nil
+	}
+
 	deleted := current == nil ||
 		current.GetDeletionTimestamp() != nil ||
-		(resource.Deleted(comp) && comp.ShouldOrphanResources()) // orphaning should be reflected on the status.
-	c.writeBuffer.PatchStatusAsync(ctx, &resource.ManifestRef, patchResourceState(deleted, ready))
+		(resource.Deleted(comp) && (comp.ShouldOrphanResources() || resource.CreateOnly || resource.Orphan)) // orphaning should be reflected on the status.
+	c.writeBuffer.PatchStatusAsync(ctx, &resource.ManifestRef, patchResourceState(deleted, ready, failed, normalizedVersion))
 
 	return c.requeue(logger, comp, resource, ready)
 }
 
-func (c *Controller) reconcileResource(ctx context.Context, comp *apiv1.Composition, prev, resource *resource.Resource, current *unstructured.Unstructured) (bool, error) {
+// compositionState summarizes the latest known states of the composition's other
+// resources for use in CEL readiness expressions.
+func (c *Controller) compositionState(comp *apiv1.Composition, self *resource.Resource) []readiness.CompositionResource {
+	var out []readiness.CompositionResource
+	for _, res := range c.resourceClient.ListForSynthesis(comp.Status.GetCurrentSynthesisUUID()) {
+		if res.Ref == self.Ref {
+			continue
+		}
+		cr := readiness.CompositionResource{
+			Group:     res.Ref.Group,
+			Kind:      res.Ref.Kind,
+			Name:      res.Ref.Name,
+			Namespace: res.Ref.Namespace,
+		}
+		if state := res.State(); state != nil {
+			cr.Ready = state.Ready != nil
+			cr.Reconciled = state.Reconciled
+			cr.Deleted = state.Deleted
+		}
+		out = append(out, cr)
+	}
+	return out
+}
+
+func (c *Controller) reconcileResource(ctx context.Context, upstream client.Client, comp *apiv1.Composition, prev, resource *resource.Resource, current *unstructured.Unstructured, normalizedVersion string) (bool, error) {
 	logger := logr.FromContextOrDiscard(ctx)
 	start := time.Now()
 	defer func() {
@@ -177,12 +362,16 @@ func (c *Controller) reconcileResource(ctx context.Context, comp *apiv1.Composit
 	}()
 
 	if resource.Deleted(comp) {
-		if current == nil || current.GetDeletionTimestamp() != nil || comp.ShouldOrphanResources() {
+		// Create-only and orphaned resources are never deleted by Eno - they outlive their composition.
+		if current == nil || current.GetDeletionTimestamp() != nil || comp.ShouldOrphanResources() || resource.CreateOnly || resource.Orphan {
 			return false, nil // already deleted - nothing to do
 		}
 
+		if err := c.limitWrites(ctx, comp); err != nil {
+			return false, err
+		}
 		reconciliationActions.WithLabelValues("delete").Inc()
-		err := c.upstreamClient.Delete(ctx, current)
+		err := upstream.Delete(ctx, current)
 		if err != nil {
 			return true, client.IgnoreNotFound(fmt.Errorf("deleting resource: %w", err))
 		}
@@ -190,15 +379,32 @@ func (c *Controller) reconcileResource(ctx context.Context, comp *apiv1.Composit
 		return true, nil
 	}
 
-	if resource.Patch != nil && current == nil {
+	if resource.IsPatch() && current == nil {
 		logger.V(1).Info("resource doesn't exist - skipping patch")
 		return false, nil
 	}
 
+	if _, protected := c.protectedNamespaces[resource.Ref.Namespace]; protected && !comp.AllowsProtectedNamespaces() {
+		return false, fmt.Errorf("refusing to write resource into protected namespace %q", resource.Ref.Namespace)
+	}
+
 	// Create the resource when it doesn't exist
 	if current == nil {
+		if err := c.limitWrites(ctx, comp); err != nil {
+			return false, err
+		}
 		reconciliationActions.WithLabelValues("create").Inc()
-		err := c.upstreamClient.Create(ctx, resource.Unstructured())
+		obj := resource.Unstructured()
+		if normalizedVersion != "" {
+			obj = obj.DeepCopy()
+			obj.SetAPIVersion(effectiveAPIVersion(resource, normalizedVersion))
+		}
+		if resource.DryRunFirst {
+			if err := upstream.Create(ctx, obj.DeepCopy(), client.DryRunAll); err != nil {
+				return false, fmt.Errorf("dry-run create failed: %w", err)
+			}
+		}
+		err := upstream.Create(ctx, obj)
 		if err != nil {
 			return false, fmt.Errorf("creating resource: %w", err)
 		}
@@ -206,22 +412,32 @@ func (c *Controller) reconcileResource(ctx context.Context, comp *apiv1.Composit
 		return true, nil
 	}
 
-	if resource.DisableUpdates {
+	if resource.DisableUpdates || resource.CreateOnly {
 		return false, nil
 	}
 
 	// Apply Eno patches
-	if resource.Patch != nil {
+	if resource.IsPatch() {
 		if !resource.NeedsToBePatched(current) {
 			return false, nil
 		}
-		patch, err := json.Marshal(&resource.Patch)
-		if err != nil {
-			return false, fmt.Errorf("encoding json patch: %w", err)
+		patchType := types.JSONPatchType
+		patch := []byte(resource.MergePatch)
+		if patch != nil {
+			patchType = types.MergePatchType
+		} else {
+			js, err := json.Marshal(&resource.Patch)
+			if err != nil {
+				return false, fmt.Errorf("encoding json patch: %w", err)
+			}
+			patch = js
 		}
 
+		if err := c.limitWrites(ctx, comp); err != nil {
+			return false, err
+		}
 		reconciliationActions.WithLabelValues("patch").Inc()
-		err = c.upstreamClient.Patch(ctx, current, client.RawPatch(types.JSONPatchType, patch))
+		err := upstream.Patch(ctx, current, client.RawPatch(patchType, patch))
 		if err != nil {
 			return false, fmt.Errorf("applying patch: %w", err)
 		}
@@ -230,6 +446,21 @@ func (c *Controller) reconcileResource(ctx context.Context, comp *apiv1.Composit
 		return true, nil
 	}
 
+	// Resources whose manifests weren't changed by a resynthesis don't need to be
+	// re-applied - the previous synthesis already converged them. Drift is still
+	// corrected by later visits once the new synthesis's status has been recorded.
+	if skipUnchangedApply(prev, resource) {
+		logger.V(1).Info("skipping apply because the manifest hasn't changed since the previous synthesis")
+		return false, nil
+	}
+
+	// Server-side apply delegates the merge to the apiserver under a stable
+	// field manager, so Eno doesn't fight controllers that own fields via
+	// their own managers.
+	if c.serverSideApply {
+		return c.serverSideApplyResource(ctx, upstream, comp, resource, current, normalizedVersion)
+	}
+
 	// Compute a merge patch
 	updated, typed, err := resource.Merge(ctx, prev, current, c.discovery)
 	if err != nil {
@@ -239,13 +470,58 @@ func (c *Controller) reconcileResource(ctx context.Context, comp *apiv1.Composit
 		logger.V(1).Info("skipping empty update")
 		return false, nil
 	}
+	if normalizedVersion != "" {
+		updated.SetAPIVersion(effectiveAPIVersion(resource, normalizedVersion))
+	}
 	if insecureLogPatch {
 		js, _ := updated.MarshalJSON()
 		logger.V(1).Info("INSECURE logging patch", "update", string(js))
 	}
 
+	// Replace-on-conflict resources are deleted and recreated by the next reconciliation
+	// instead of merged, since merging would be rejected on their immutable fields.
+	if resource.Replace {
+		if err := c.limitWrites(ctx, comp); err != nil {
+			return false, err
+		}
+		reconciliationActions.WithLabelValues("replace").Inc()
+		err := upstream.Delete(ctx, current)
+		if err != nil {
+			return true, client.IgnoreNotFound(fmt.Errorf("deleting resource for replacement: %w", err))
+		}
+		logger.V(0).Info("deleted resource so it can be recreated with its updated manifest")
+		return true, nil
+	}
+
+	if err := c.limitWrites(ctx, comp); err != nil {
+		return false, err
+	}
+
+	// Optionally gate the real apply behind a successful dry-run
+	if resource.DryRunFirst {
+		if err := upstream.Update(ctx, updated.DeepCopy(), client.DryRunAll); err != nil {
+			return false, fmt.Errorf("dry-run update failed: %w", err)
+		}
+	}
+
 	reconciliationActions.WithLabelValues("patch").Inc()
-	err = c.upstreamClient.Update(ctx, updated)
+	err = c.applyUpdate(ctx, upstream, current, updated)
+	if errors.IsConflict(err) {
+		switch c.resolveConflictPolicy(resource) {
+		case ConflictPolicySkip:
+			logger.V(0).Info("dropping update because another client wrote the resource concurrently")
+			return false, nil
+		case ConflictPolicyForce:
+			err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				current, err := c.getCurrent(ctx, upstream, resource, normalizedVersion)
+				if err != nil {
+					return err
+				}
+				updated.SetResourceVersion(current.GetResourceVersion())
+				return upstream.Update(ctx, updated)
+			})
+		}
+	}
 	if err != nil {
 		return false, fmt.Errorf("applying update: %w", err)
 	}
@@ -259,13 +535,108 @@ func (c *Controller) reconcileResource(ctx context.Context, comp *apiv1.Composit
 	return true, nil
 }
 
-func (c *Controller) getCurrent(ctx context.Context, resource *resource.Resource) (*unstructured.Unstructured, error) {
+// fieldManager is the server-side apply field manager used when ServerSideApply
+// is enabled. It must remain stable - changing it would orphan previously
+// managed fields.
+const fieldManager = "eno"
+
+// serverSideApplyResource applies the resource's full desired state with
+// server-side apply. Conflicts with other field managers are surfaced in the
+// resource's state and retried with backoff unless the resource's conflict
+// policy says otherwise.
+func (c *Controller) serverSideApplyResource(ctx context.Context, upstream client.Client, comp *apiv1.Composition, res *resource.Resource, current *unstructured.Unstructured, normalizedVersion string) (bool, error) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	obj := res.Unstructured().DeepCopy()
+	if normalizedVersion != "" {
+		obj.SetAPIVersion(effectiveAPIVersion(res, normalizedVersion))
+	}
+
+	if err := c.limitWrites(ctx, comp); err != nil {
+		return false, err
+	}
+	opts := []client.PatchOption{client.FieldOwner(fieldManager)}
+	if c.resolveConflictPolicy(res) == ConflictPolicyForce {
+		opts = append(opts, client.ForceOwnership)
+	}
+	reconciliationActions.WithLabelValues("apply").Inc()
+	err := upstream.Patch(ctx, obj, client.Apply, opts...)
+	if errors.IsConflict(err) {
+		if c.resolveConflictPolicy(res) == ConflictPolicySkip {
+			logger.V(0).Info("dropping apply because another field manager owns conflicting fields")
+			return false, nil
+		}
+		c.writeBuffer.PatchStatusAsync(ctx, &res.ManifestRef, patchFieldConflict(err.Error()))
+		return false, fmt.Errorf("applying resource: %w", err)
+	}
+	if err != nil {
+		return false, fmt.Errorf("applying resource: %w", err)
+	}
+
+	if current != nil && obj.GetResourceVersion() == current.GetResourceVersion() {
+		logger.V(1).Info("applied resource but it did not change", "resourceVersion", obj.GetResourceVersion())
+		return false, nil
+	}
+	logger.V(0).Info("applied resource", "resourceVersion", obj.GetResourceVersion())
+	return true, nil
+}
+
+// skipUnchangedApply returns true when a resource is being visited for the first
+// time after a resynthesis that didn't change its manifest, as proven by the
+// previous synthesis's matching manifest hash and reconciled state.
+func skipUnchangedApply(prev, res *resource.Resource) bool {
+	if prev == nil || !bytes.Equal(prev.ManifestHash, res.ManifestHash) {
+		return false
+	}
+	if state := res.State(); state != nil && state.Reconciled {
+		return false // periodic revisit - let drift correction happen normally
+	}
+	prevState := prev.State()
+	return prevState != nil && prevState.Reconciled
+}
+
+// resolveConflictPolicy returns the effective conflict policy for a resource.
+// The per-resource force-conflicts annotation takes precedence over the
+// type-level policy configured on the controller.
+func (c *Controller) resolveConflictPolicy(res *resource.Resource) ConflictPolicy {
+	if res.ForceConflicts {
+		return ConflictPolicyForce
+	}
+	return c.conflictPolicies[res.GVK]
+}
+
+// largeObjectThresholdBytes is the serialized size above which updates are applied
+// as merge patches instead of full updates. Patches only carry the changed fields,
+// saving bandwidth and avoiding conflicts on fields Eno doesn't manage.
+const largeObjectThresholdBytes = 256 * 1024
+
+func (c *Controller) applyUpdate(ctx context.Context, upstream client.Client, current, updated *unstructured.Unstructured) error {
+	updatedJS, err := updated.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("encoding updated state: %w", err)
+	}
+	if len(updatedJS) < largeObjectThresholdBytes {
+		return upstream.Update(ctx, updated)
+	}
+
+	currentJS, err := current.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("encoding current state: %w", err)
+	}
+	patch, err := jsonpatch.CreateMergePatch(currentJS, updatedJS)
+	if err != nil {
+		return fmt.Errorf("creating merge patch: %w", err)
+	}
+	return upstream.Patch(ctx, updated, client.RawPatch(types.MergePatchType, patch))
+}
+
+func (c *Controller) getCurrent(ctx context.Context, upstream client.Client, resource *resource.Resource, normalizedVersion string) (*unstructured.Unstructured, error) {
 	current := &unstructured.Unstructured{}
 	current.SetName(resource.Ref.Name)
 	current.SetNamespace(resource.Ref.Namespace)
 	current.SetKind(resource.GVK.Kind)
-	current.SetAPIVersion(resource.GVK.GroupVersion().String())
-	err := c.upstreamClient.Get(ctx, client.ObjectKeyFromObject(current), current)
+	current.SetAPIVersion(effectiveAPIVersion(resource, normalizedVersion))
+	err := upstream.Get(ctx, client.ObjectKeyFromObject(current), current)
 	if err != nil {
 		return nil, err
 	}
@@ -283,25 +654,95 @@ func (c *Controller) requeue(logger logr.Logger, comp *apiv1.Composition, resour
 
 	interval := resource.ReconcileInterval.Duration
 	if interval < c.minReconcileInterval {
-		logger.V(1).Info("reconcile interval is too small - using default", "latency", interval, "default", c.minReconcileInterval)
+		c.warnIntervalClamped(logger, resource, interval)
 		interval = c.minReconcileInterval
 	}
-	return ctrl.Result{RequeueAfter: wait.Jitter(interval, 0.1)}, nil
+	jitter := 0.1
+	if resource.ReconcileJitter > 0 {
+		jitter = resource.ReconcileJitter
+	}
+	return ctrl.Result{RequeueAfter: wait.Jitter(interval, jitter)}, nil
+}
+
+// warnIntervalClamped logs the first time each resource's reconcile interval is
+// clamped to the configured floor. Requeues happen constantly, so logging every
+// occurrence would flood the logs without adding information.
+func (c *Controller) warnIntervalClamped(logger logr.Logger, res *resource.Resource, interval time.Duration) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if c.clampedIntervals == nil {
+		c.clampedIntervals = map[resource.Ref]struct{}{}
+	}
+	if _, ok := c.clampedIntervals[res.Ref]; ok {
+		return
+	}
+	c.clampedIntervals[res.Ref] = struct{}{}
+	logger.V(0).Info("reconcile interval is below the configured floor and will be clamped", "interval", interval.String(), "floor", c.minReconcileInterval.String())
+}
+
+// limitWrites blocks until the composition's write rate limit (if any) permits
+// another apiserver write.
+func (c *Controller) limitWrites(ctx context.Context, comp *apiv1.Composition) error {
+	if c.writeRateLimit == nil {
+		return nil
+	}
+	return c.writeRateLimit.Wait(ctx, client.ObjectKeyFromObject(comp))
 }
 
-func patchResourceState(deleted bool, ready *metav1.Time) flowcontrol.StatusPatchFn {
+func patchPendingDeletionState() flowcontrol.StatusPatchFn {
 	return func(rs *apiv1.ResourceState) *apiv1.ResourceState {
-		if rs != nil && rs.Deleted == deleted && rs.Reconciled && ptr.Deref(rs.Ready, metav1.Time{}) == ptr.Deref(ready, metav1.Time{}) {
+		if rs != nil && !rs.Reconciled && !rs.Deleted {
+			return nil
+		}
+		return &apiv1.ResourceState{}
+	}
+}
+
+// patchFieldConflict records a server-side apply conflict on the resource's
+// state while preserving the rest of it. The conflict is cleared by the next
+// successful state patch.
+func patchFieldConflict(message string) flowcontrol.StatusPatchFn {
+	return func(rs *apiv1.ResourceState) *apiv1.ResourceState {
+		if rs != nil && rs.FieldConflict == message {
+			return nil
+		}
+		next := apiv1.ResourceState{}
+		if rs != nil {
+			next = *rs
+		}
+		next.FieldConflict = message
+		return &next
+	}
+}
+
+func patchResourceState(deleted bool, ready, failed *metav1.Time, normalizedVersion string) flowcontrol.StatusPatchFn {
+	return func(rs *apiv1.ResourceState) *apiv1.ResourceState {
+		if rs != nil && rs.Failed != nil && failed != nil {
+			failed = rs.Failed // preserve the original failure time
+		}
+		if rs != nil && rs.Deleted == deleted && rs.Reconciled && ptr.Deref(rs.Ready, metav1.Time{}) == ptr.Deref(ready, metav1.Time{}) && ptr.Deref(rs.Failed, metav1.Time{}) == ptr.Deref(failed, metav1.Time{}) && rs.NormalizedVersion == normalizedVersion {
 			return nil
 		}
 		return &apiv1.ResourceState{
-			Deleted:    deleted,
-			Ready:      ready,
-			Reconciled: true,
+			Deleted:           deleted,
+			Ready:             ready,
+			Failed:            failed,
+			Reconciled:        true,
+			NormalizedVersion: normalizedVersion,
 		}
 	}
 }
 
+// effectiveAPIVersion returns the apiVersion a resource should be applied at,
+// accounting for any version normalization.
+func effectiveAPIVersion(res *resource.Resource, normalizedVersion string) string {
+	gv := res.GVK.GroupVersion()
+	if normalizedVersion != "" {
+		gv.Version = normalizedVersion
+	}
+	return gv.String()
+}
+
 // isErrMissingNS returns true when given the client-go error returned by mutating requests that do not include a namespace.
 // Sadly, this error isn't exposed anywhere - it's just a plain string, so we have to do string matching here.
 //
@@ -76,3 +76,29 @@ func TestRequeue(t *testing.T) {
 		})
 	}
 }
+
+func TestIntervalClampWarnsOnce(t *testing.T) {
+	c := &Controller{minReconcileInterval: 10 * time.Second}
+	comp := &apiv1.Composition{}
+	res := &resource.Resource{
+		Ref:               resource.Ref{Kind: "ConfigMap", Namespace: "default", Name: "foo"},
+		ReconcileInterval: &metav1.Duration{Duration: time.Millisecond},
+	}
+
+	// The first clamp records the resource, subsequent ones don't log again
+	for i := 0; i < 3; i++ {
+		result, err := c.requeue(logr.Discard(), comp, res, &metav1.Time{})
+		assert.NoError(t, err)
+		assert.InDelta(t, 10*time.Second, result.RequeueAfter, float64(2*time.Second))
+	}
+	assert.Len(t, c.clampedIntervals, 1)
+
+	// Other resources are warned about independently
+	other := &resource.Resource{
+		Ref:               resource.Ref{Kind: "ConfigMap", Namespace: "default", Name: "bar"},
+		ReconcileInterval: &metav1.Duration{Duration: time.Millisecond},
+	}
+	_, err := c.requeue(logr.Discard(), comp, other, &metav1.Time{})
+	assert.NoError(t, err)
+	assert.Len(t, c.clampedIntervals, 2)
+}
@@ -0,0 +1,84 @@
+package reconciliation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/resource"
+	"github.com/go-logr/logr"
+)
+
+// crossPatchController applies patches scoped by label selector.
+//
+// The standard patch mechanism targets a single named resource owned by the same composition.
+// Synthesizers can instead scope a patch to a label selector, in which case this controller
+// applies it to every matching resource of the patch's kind - including resources produced by
+// sibling compositions. Patches are constrained to the patch resource's own namespace and to
+// additive operations, so one composition cannot remove or break out of another's resources.
+type crossPatchController struct {
+	client         client.Client
+	upstreamClient client.Client
+	cache          *resource.Cache
+}
+
+func (c *crossPatchController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	comp := &apiv1.Composition{}
+	err := c.client.Get(ctx, req.NamespacedName, comp)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if comp.Status.CurrentSynthesis == nil || comp.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+	logger = logger.WithValues("compositionName", comp.Name, "compositionNamespace", comp.Namespace, "synthesisID", comp.Status.CurrentSynthesis.UUID)
+
+	var sawSelectorPatch bool
+	for _, res := range c.cache.ListForSynthesis(comp.Status.CurrentSynthesis.UUID) {
+		// Don't bother listing candidate targets for no-op patches
+		if res.Patch == nil || res.PatchSelector == nil || res.PatchIsNoOp() {
+			continue
+		}
+		sawSelectorPatch = true
+
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(res.GVK.GroupVersion().WithKind(res.GVK.Kind + "List"))
+		err := c.upstreamClient.List(ctx, list, client.InNamespace(res.Ref.Namespace), client.MatchingLabelsSelector{Selector: res.PatchSelector})
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("listing patch targets: %w", err)
+		}
+
+		patch, err := json.Marshal(&res.Patch)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("encoding json patch: %w", err)
+		}
+
+		for _, item := range list.Items {
+			item := item
+			if item.GetDeletionTimestamp() != nil || !res.NeedsToBePatched(&item) {
+				continue
+			}
+
+			err = c.upstreamClient.Patch(ctx, &item, client.RawPatch(types.JSONPatchType, patch))
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("applying patch: %w", err)
+			}
+			logger.V(0).Info("applied cross-composition patch", "resourceKind", item.GetKind(), "resourceName", item.GetName(), "resourceNamespace", item.GetNamespace())
+		}
+	}
+
+	if sawSelectorPatch {
+		// Matching resources can appear after this reconciliation - poll for them
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+	return ctrl.Result{}, nil
+}
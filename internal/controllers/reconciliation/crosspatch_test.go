@@ -0,0 +1,74 @@
+package reconciliation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/resource"
+)
+
+func TestCrossCompositionPatch(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1.SchemeBuilder.AddToScheme(scheme))
+	require.NoError(t, corev1.SchemeBuilder.AddToScheme(scheme))
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{UUID: "test-uuid", Reconciled: ptr.To(metav1.Now())}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(comp).WithStatusSubresource(comp).Build()
+
+	// The configmap belongs to a sibling composition
+	cm := &corev1.ConfigMap{}
+	cm.Name = "sibling-cm"
+	cm.Namespace = "default"
+	cm.Labels = map[string]string{"team": "foo"}
+	other := &corev1.ConfigMap{}
+	other.Name = "unrelated-cm"
+	other.Namespace = "default"
+	downstream := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm, other).Build()
+
+	slice := apiv1.ResourceSlice{}
+	slice.Name = "test-slice"
+	slice.Namespace = "default"
+	slice.Spec.Resources = []apiv1.Manifest{{
+		Manifest: `{
+			"apiVersion": "eno.azure.io/v1",
+			"kind": "Patch",
+			"metadata": {"name": "annotate-siblings", "namespace": "default"},
+			"patch": {
+				"apiVersion": "v1",
+				"kind": "ConfigMap",
+				"labelSelector": {"team": "foo"},
+				"ops": [{"op": "add", "path": "/metadata/annotations", "value": {"example.com/owned-by": "foo"}}]
+			}
+		}`,
+	}}
+	cache := &resource.Cache{}
+	cache.SetQueue(workqueue.NewTypedRateLimitingQueue[resource.Request](workqueue.DefaultTypedControllerRateLimiter[resource.Request]()))
+	cache.Fill(ctx, types.NamespacedName{Name: comp.Name, Namespace: comp.Namespace}, "test-uuid", []apiv1.ResourceSlice{slice})
+
+	c := &crossPatchController{client: cli, upstreamClient: downstream, cache: cache}
+	res, err := c.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: comp.Name, Namespace: comp.Namespace}})
+	require.NoError(t, err)
+	assert.NotZero(t, res.RequeueAfter)
+
+	// Only the matching configmap was annotated
+	require.NoError(t, downstream.Get(ctx, types.NamespacedName{Name: "sibling-cm", Namespace: "default"}, cm))
+	assert.Equal(t, "foo", cm.Annotations["example.com/owned-by"])
+	require.NoError(t, downstream.Get(ctx, types.NamespacedName{Name: "unrelated-cm", Namespace: "default"}, other))
+	assert.Empty(t, other.Annotations)
+}
@@ -0,0 +1,36 @@
+package reconciliation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/Azure/eno/internal/resource"
+)
+
+// serveCacheDebug exposes a read-only dump of the reconstitution cache over HTTP.
+// Useful for diagnosing stale informer loops where the cache never converges on
+// the expected slice contents. Off by default - enabled by a flag on the reconciler.
+func serveCacheDebug(addr string, cache *resource.Cache) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cache", func(w http.ResponseWriter, r *http.Request) {
+		nsn := types.NamespacedName{
+			Namespace: r.URL.Query().Get("namespace"),
+			Name:      r.URL.Query().Get("name"),
+		}
+		if nsn.Name == "" {
+			http.Error(w, "the name query parameter is required", http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(cache.Dump(nsn))
+	})
+
+	go func() {
+		err := http.ListenAndServe(addr, mux)
+		panic(fmt.Sprintf("unable to serve cache debug listener: %s", err))
+	}()
+}
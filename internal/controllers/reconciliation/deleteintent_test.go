@@ -0,0 +1,92 @@
+package reconciliation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/flowcontrol"
+	"github.com/Azure/eno/internal/resource"
+)
+
+// TestDeleteIntentReconciledState proves that resources reconciled toward absence
+// aren't considered reconciled until the object is actually gone.
+func TestDeleteIntentReconciledState(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1.SchemeBuilder.AddToScheme(scheme))
+	require.NoError(t, corev1.SchemeBuilder.AddToScheme(scheme))
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{UUID: "test-uuid"}
+
+	slice := &apiv1.ResourceSlice{}
+	slice.Name = "test-slice"
+	slice.Namespace = "default"
+	slice.Spec.Resources = []apiv1.Manifest{{
+		Deleted:  true,
+		Manifest: `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "foo", "namespace": "default"}}`,
+	}}
+	upstream := fake.NewClientBuilder().WithScheme(scheme).WithObjects(comp, slice).WithStatusSubresource(comp, slice).Build()
+
+	// The configmap is being finalized downstream
+	cm := &corev1.ConfigMap{}
+	cm.Name = "foo"
+	cm.Namespace = "default"
+	cm.Finalizers = []string{"example.com/finalizer"}
+	downstream := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm).Build()
+	require.NoError(t, downstream.Delete(ctx, cm.DeepCopy()))
+
+	cache := &resource.Cache{}
+	cache.SetQueue(workqueue.NewTypedRateLimitingQueue[resource.Request](workqueue.DefaultTypedControllerRateLimiter[resource.Request]()))
+	cache.Fill(ctx, types.NamespacedName{Name: comp.Name, Namespace: comp.Namespace}, "test-uuid", []apiv1.ResourceSlice{*slice})
+
+	writeBuffer := flowcontrol.NewResourceSliceWriteBuffer(upstream)
+	go writeBuffer.Start(ctx)
+
+	c := &Controller{
+		client:                upstream,
+		upstreamClient:        downstream,
+		resourceClient:        cache,
+		writeBuffer:           writeBuffer,
+		readinessPollInterval: time.Second,
+		targetClients:         newTargetClientCache(upstream, downstream, nil),
+	}
+	req := resource.Request{
+		Resource:    resource.Ref{Name: "foo", Namespace: "default", Kind: "ConfigMap"},
+		Composition: types.NamespacedName{Name: comp.Name, Namespace: comp.Namespace},
+	}
+
+	// The object lingers behind its finalizer - not reconciled yet
+	res, err := c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.NotZero(t, res.RequeueAfter)
+	require.Eventually(t, func() bool {
+		require.NoError(t, upstream.Get(ctx, types.NamespacedName{Name: slice.Name, Namespace: slice.Namespace}, slice))
+		return len(slice.Status.Resources) == 1 && !slice.Status.Resources[0].Reconciled
+	}, time.Second*10, time.Millisecond*10)
+
+	// Releasing the finalizer allows deletion to complete
+	require.NoError(t, downstream.Get(ctx, types.NamespacedName{Name: "foo", Namespace: "default"}, cm))
+	cm.Finalizers = nil
+	require.NoError(t, downstream.Update(ctx, cm))
+
+	_, err = c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		require.NoError(t, upstream.Get(ctx, types.NamespacedName{Name: slice.Name, Namespace: slice.Namespace}, slice))
+		return len(slice.Status.Resources) == 1 && slice.Status.Resources[0].Reconciled && slice.Status.Resources[0].Deleted
+	}, time.Second*10, time.Millisecond*10)
+}
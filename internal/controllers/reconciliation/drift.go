@@ -0,0 +1,148 @@
+package reconciliation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/Azure/eno/internal/resource"
+	"github.com/go-logr/logr"
+)
+
+// driftSource watches the kinds Eno manages on the target cluster, scoped to
+// objects carrying the synthesis-uuid label, and enqueues the owning resource
+// when one is modified out-of-band. This corrects drift promptly instead of
+// waiting for the resource's next reconcile interval.
+//
+// Only resources labeled by Eno (i.e. compositions that opt into identity
+// labels) are covered, since the labels are what map an event back to its
+// composition.
+type driftSource struct {
+	cache        *resource.Cache
+	mapper       meta.RESTMapper
+	factory      metadatainformer.SharedInformerFactory
+	syncInterval time.Duration
+
+	mut     sync.Mutex
+	queue   workqueue.TypedRateLimitingInterface[resource.Request]
+	watched map[schema.GroupVersionKind]struct{}
+}
+
+func newDriftSource(downstream *rest.Config, cache *resource.Cache) (source.TypedSource[resource.Request], error) {
+	httpClient, err := rest.HTTPClientFor(downstream)
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := apiutil.NewDynamicRESTMapper(downstream, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	metaClient, err := metadata.NewForConfig(downstream)
+	if err != nil {
+		return nil, err
+	}
+	return newDriftSourceWithClient(metaClient, mapper, cache), nil
+}
+
+func newDriftSourceWithClient(metaClient metadata.Interface, mapper meta.RESTMapper, cache *resource.Cache) *driftSource {
+	factory := metadatainformer.NewFilteredSharedInformerFactory(metaClient, 0, metav1.NamespaceAll, func(opts *metav1.ListOptions) {
+		opts.LabelSelector = resource.SynthesisUUIDLabelKey
+	})
+	return &driftSource{
+		cache:        cache,
+		mapper:       mapper,
+		factory:      factory,
+		syncInterval: time.Second * 30,
+		watched:      map[schema.GroupVersionKind]struct{}{},
+	}
+}
+
+func (d *driftSource) Start(ctx context.Context, queue workqueue.TypedRateLimitingInterface[resource.Request]) error {
+	d.mut.Lock()
+	d.queue = queue
+	d.mut.Unlock()
+
+	go wait.UntilWithContext(ctx, d.sync, d.syncInterval)
+	return nil
+}
+
+// sync starts informers for any managed kinds that aren't already being watched.
+func (d *driftSource) sync(ctx context.Context) {
+	logger := logr.FromContextOrDiscard(ctx)
+	d.mut.Lock()
+	defer d.mut.Unlock()
+
+	for _, gvk := range d.cache.ManagedGVKs() {
+		if _, ok := d.watched[gvk]; ok {
+			continue
+		}
+		if err := d.watchUnlocked(gvk); err != nil {
+			logger.Error(err, "failed to watch managed kind for drift", "group", gvk.Group, "version", gvk.Version, "kind", gvk.Kind)
+			continue
+		}
+		d.watched[gvk] = struct{}{}
+		logger.V(1).Info("watching managed kind for drift", "group", gvk.Group, "version", gvk.Version, "kind", gvk.Kind)
+	}
+	d.factory.Start(ctx.Done())
+}
+
+func (d *driftSource) watchUnlocked(gvk schema.GroupVersionKind) error {
+	mapping, err := d.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return fmt.Errorf("mapping kind to resource: %w", err)
+	}
+
+	// Creations are either Eno's own writes or unmanaged objects, so only
+	// updates and deletions can constitute drift
+	_, err = d.factory.ForResource(mapping.Resource).Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(_, obj any) { d.enqueue(gvk, obj) },
+		DeleteFunc: func(obj any) { d.enqueue(gvk, obj) },
+	})
+	return err
+}
+
+func (d *driftSource) enqueue(gvk schema.GroupVersionKind, obj any) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	object, ok := obj.(*metav1.PartialObjectMetadata)
+	if !ok {
+		return
+	}
+
+	labels := object.GetLabels()
+	comp := types.NamespacedName{
+		Name:      labels[resource.CompositionNameLabelKey],
+		Namespace: labels[resource.CompositionNamespaceLabelKey],
+	}
+	if comp.Name == "" {
+		return
+	}
+
+	d.mut.Lock()
+	queue := d.queue
+	d.mut.Unlock()
+	queue.Add(resource.Request{
+		Resource: resource.Ref{
+			Group:     gvk.Group,
+			Kind:      gvk.Kind,
+			Name:      object.Name,
+			Namespace: object.Namespace,
+		},
+		Composition: comp,
+	})
+}
@@ -0,0 +1,79 @@
+package reconciliation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// TestDriftWatch proves that externally modifying a managed resource promptly
+// enqueues the owning resource for reconciliation.
+func TestDriftWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Populate the resource cache with a managed ConfigMap
+	rc := &resource.Cache{}
+	rc.SetQueue(workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[resource.Request]()))
+	comp := types.NamespacedName{Name: "test-comp", Namespace: "default"}
+	slice := apiv1.ResourceSlice{
+		Spec: apiv1.ResourceSliceSpec{
+			Resources: []apiv1.Manifest{{Manifest: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"managed-cm","namespace":"default"}}`}},
+		},
+	}
+	rc.Fill(ctx, comp, "test-uuid", []apiv1.ResourceSlice{slice})
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+
+	scheme := metadatafake.NewTestScheme()
+	require.NoError(t, metav1.AddMetaToScheme(scheme))
+	metaClient := metadatafake.NewSimpleMetadataClient(scheme)
+
+	ds := newDriftSourceWithClient(metaClient, mapper, rc)
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[resource.Request]())
+	require.NoError(t, ds.Start(ctx, queue))
+
+	// Create the object as Eno would, then modify it out-of-band
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	cm := &metav1.PartialObjectMetadata{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "managed-cm",
+			Namespace: "default",
+			Labels: map[string]string{
+				resource.CompositionNameLabelKey:      comp.Name,
+				resource.CompositionNamespaceLabelKey: comp.Namespace,
+				resource.SynthesisUUIDLabelKey:        "test-uuid",
+			},
+		},
+	}
+	cli := metaClient.Resource(gvr).Namespace("default").(metadatafake.MetadataClient)
+	require.Eventually(t, func() bool {
+		_, err := cli.CreateFake(cm, metav1.CreateOptions{})
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cm.Annotations = map[string]string{"drifted": "true"}
+	_, err := cli.UpdateFake(cm, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// The owning resource is enqueued without waiting for a reconcile interval
+	require.Eventually(t, func() bool { return queue.Len() > 0 }, 5*time.Second, 10*time.Millisecond)
+	req, _ := queue.Get()
+	assert.Equal(t, resource.Request{
+		Resource:    resource.Ref{Kind: "ConfigMap", Name: "managed-cm", Namespace: "default"},
+		Composition: comp,
+	}, req)
+}
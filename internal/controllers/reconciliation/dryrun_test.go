@@ -0,0 +1,72 @@
+package reconciliation
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestDryRunFirst(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.SchemeBuilder.AddToScheme(scheme))
+
+	var denyDryRun bool
+	downstream := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				o := &client.CreateOptions{}
+				for _, opt := range opts {
+					opt.ApplyToCreate(o)
+				}
+				if slices.Contains(o.DryRun, metav1.DryRunAll) {
+					if denyDryRun {
+						return errors.NewBadRequest("rejected by admission")
+					}
+					return nil // don't persist dry-run writes
+				}
+				return cli.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	c := &Controller{upstreamClient: downstream}
+
+	newRes := func(name string) *resource.Resource {
+		slice := &apiv1.ResourceSlice{
+			Spec: apiv1.ResourceSliceSpec{
+				Resources: []apiv1.Manifest{{Manifest: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"` + name + `","namespace":"default","annotations":{"eno.azure.io/dry-run-first":"true"}}}`}},
+			},
+		}
+		res, err := resource.NewResource(ctx, slice, 0)
+		require.NoError(t, err)
+		require.True(t, res.DryRunFirst)
+		return res
+	}
+
+	// Failed dry-runs block the real apply and surface the error
+	denyDryRun = true
+	_, err := c.reconcileResource(ctx, c.upstreamClient, &apiv1.Composition{}, nil, newRes("denied"), nil, "")
+	require.ErrorContains(t, err, "dry-run create failed")
+	cm := &corev1.ConfigMap{}
+	assert.True(t, errors.IsNotFound(downstream.Get(ctx, client.ObjectKey{Name: "denied", Namespace: "default"}, cm)))
+
+	// Successful dry-runs proceed to the real apply
+	denyDryRun = false
+	modified, err := c.reconcileResource(ctx, c.upstreamClient, &apiv1.Composition{}, nil, newRes("allowed"), nil, "")
+	require.NoError(t, err)
+	assert.True(t, modified)
+	require.NoError(t, downstream.Get(ctx, client.ObjectKey{Name: "allowed", Namespace: "default"}, cm))
+}
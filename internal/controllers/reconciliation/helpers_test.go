@@ -25,8 +25,8 @@ import (
 
 func registerControllers(t *testing.T, mgr *testutil.Manager) {
 	require.NoError(t, synthesis.NewPodLifecycleController(mgr.Manager, defaultConf))
-	require.NoError(t, synthesis.NewPodGC(mgr.Manager, time.Second))
-	require.NoError(t, scheduling.NewController(mgr.Manager, 10, time.Millisecond, time.Second))
+	require.NoError(t, synthesis.NewPodGC(mgr.Manager, time.Second, 0))
+	require.NoError(t, scheduling.NewController(mgr.Manager, 10, 0, time.Millisecond, time.Second))
 	require.NoError(t, liveness.NewNamespaceController(mgr.Manager, 3, time.Second))
 	require.NoError(t, watch.NewController(mgr.Manager))
 	require.NoError(t, resourceslice.NewController(mgr.Manager))
@@ -0,0 +1,46 @@
+package reconciliation
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNormalizedVersionApply(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, appsv1.SchemeBuilder.AddToScheme(scheme))
+	downstream := fake.NewClientBuilder().WithScheme(scheme).Build()
+	c := &Controller{upstreamClient: downstream}
+
+	// This version of Deployment hasn't been served in a long time
+	slice := &apiv1.ResourceSlice{
+		Spec: apiv1.ResourceSliceSpec{
+			Resources: []apiv1.Manifest{{Manifest: `{"apiVersion":"apps/v1beta1","kind":"Deployment","metadata":{"name":"foo","namespace":"default"}}`}},
+		},
+	}
+	res, err := resource.NewResource(ctx, slice, 0)
+	require.NoError(t, err)
+
+	modified, err := c.reconcileResource(ctx, c.upstreamClient, &apiv1.Composition{}, nil, res, nil, "v1")
+	require.NoError(t, err)
+	assert.True(t, modified)
+
+	// The resource was created at the served version
+	dep := &appsv1.Deployment{}
+	require.NoError(t, downstream.Get(ctx, client.ObjectKey{Name: "foo", Namespace: "default"}, dep))
+
+	// The normalization is recorded on the resource's state
+	state := patchResourceState(false, nil, nil, "v1")(nil)
+	require.NotNil(t, state)
+	assert.Equal(t, "v1", state.NormalizedVersion)
+	assert.Nil(t, patchResourceState(false, nil, nil, "v1")(state))
+}
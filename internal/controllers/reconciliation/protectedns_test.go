@@ -0,0 +1,60 @@
+package reconciliation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/resource"
+)
+
+func TestProtectedNamespaces(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.SchemeBuilder.AddToScheme(scheme))
+	downstream := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	c := &Controller{
+		upstreamClient:      downstream,
+		protectedNamespaces: map[string]struct{}{"kube-system": {}},
+	}
+
+	newRes := func(ns string) *resource.Resource {
+		slice := &apiv1.ResourceSlice{}
+		slice.Spec.Resources = []apiv1.Manifest{{
+			Manifest: `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "foo", "namespace": "` + ns + `"}}`,
+		}}
+		res, err := resource.NewResource(ctx, slice, 0)
+		require.NoError(t, err)
+		return res
+	}
+
+	// Writes into the protected namespace are rejected
+	comp := &apiv1.Composition{}
+	_, err := c.reconcileResource(ctx, c.upstreamClient, comp, nil, newRes("kube-system"), nil, "")
+	require.ErrorContains(t, err, "protected namespace")
+
+	cm := &corev1.ConfigMap{}
+	assert.True(t, errors.IsNotFound(downstream.Get(ctx, types.NamespacedName{Name: "foo", Namespace: "kube-system"}, cm)))
+
+	// Unprotected namespaces are unaffected
+	modified, err := c.reconcileResource(ctx, c.upstreamClient, comp, nil, newRes("default"), nil, "")
+	require.NoError(t, err)
+	assert.True(t, modified)
+	require.NoError(t, downstream.Get(ctx, types.NamespacedName{Name: "foo", Namespace: "default"}, cm))
+
+	// Compositions can explicitly opt out of the protection
+	comp.Annotations = map[string]string{"eno.azure.io/allow-protected-namespaces": "true"}
+	modified, err = c.reconcileResource(ctx, c.upstreamClient, comp, nil, newRes("kube-system"), nil, "")
+	require.NoError(t, err)
+	assert.True(t, modified)
+	require.NoError(t, downstream.Get(ctx, types.NamespacedName{Name: "foo", Namespace: "kube-system"}, cm))
+}
@@ -0,0 +1,91 @@
+package reconciliation
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/resource"
+	"github.com/go-logr/logr"
+)
+
+// pruneController deletes resources left behind by previous syntheses.
+//
+// Eno already diffs consecutive syntheses to delete removed resources, but that relies on the
+// previous synthesis being available - resources can slip through across cache gaps. For
+// compositions that opt in (see Composition.ShouldPruneStaleResources), every synthesized
+// resource carries identity labels and this controller deletes any labeled resource that is
+// no longer part of the current synthesis. Only kinds present in the current synthesis are
+// considered.
+type pruneController struct {
+	client         client.Client
+	upstreamClient client.Client
+	cache          *resource.Cache
+}
+
+func (p *pruneController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	comp := &apiv1.Composition{}
+	err := p.client.Get(ctx, req.NamespacedName, comp)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	syn := comp.Status.CurrentSynthesis
+	if !comp.ShouldPruneStaleResources() || syn == nil || syn.Reconciled == nil {
+		// Wait until the current synthesis has been fully reconciled - otherwise a current
+		// resource that hasn't been updated yet could still carry a stale synthesis label.
+		return ctrl.Result{}, nil
+	}
+	logger = logger.WithValues("compositionName", comp.Name, "compositionNamespace", comp.Namespace, "synthesisID", syn.UUID)
+
+	resources := p.cache.ListForSynthesis(syn.UUID)
+	if len(resources) == 0 {
+		return ctrl.Result{}, nil // cache hasn't been filled yet
+	}
+
+	gvks := map[schema.GroupVersionKind]struct{}{}
+	expected := map[resource.Ref]struct{}{}
+	for _, res := range resources {
+		gvks[res.GVK] = struct{}{}
+		expected[res.Ref] = struct{}{}
+	}
+
+	for gvk := range gvks {
+		list := &metav1.PartialObjectMetadataList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		err := p.upstreamClient.List(ctx, list, client.MatchingLabels{
+			resource.CompositionNameLabelKey:      comp.Name,
+			resource.CompositionNamespaceLabelKey: comp.Namespace,
+		})
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("listing labeled resources: %w", err)
+		}
+
+		for _, item := range list.Items {
+			item := item
+			if item.DeletionTimestamp != nil || item.Labels[resource.SynthesisUUIDLabelKey] == syn.UUID {
+				continue
+			}
+			ref := resource.Ref{Name: item.Name, Namespace: item.Namespace, Group: gvk.Group, Kind: gvk.Kind}
+			if _, ok := expected[ref]; ok {
+				continue // still part of the current synthesis
+			}
+
+			item.SetGroupVersionKind(gvk)
+			err := p.upstreamClient.Delete(ctx, &item, &client.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &item.UID}})
+			if err != nil && !errors.IsNotFound(err) {
+				return ctrl.Result{}, fmt.Errorf("deleting stale resource: %w", err)
+			}
+			logger.V(0).Info("pruned stale resource", "resourceKind", gvk.Kind, "resourceName", item.Name, "resourceNamespace", item.Namespace)
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,87 @@
+package reconciliation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/resource"
+)
+
+func TestPruneStaleResources(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1.SchemeBuilder.AddToScheme(scheme))
+	require.NoError(t, corev1.SchemeBuilder.AddToScheme(scheme))
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	comp.Annotations = map[string]string{"eno.azure.io/prune-stale-resources": "true"}
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{UUID: "uuid-2", Reconciled: ptr.To(metav1.Now())}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(comp).WithStatusSubresource(comp).Build()
+
+	newCM := func(name, uuid string) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{}
+		cm.Name = name
+		cm.Namespace = "default"
+		cm.Labels = map[string]string{
+			resource.CompositionNameLabelKey:      comp.Name,
+			resource.CompositionNamespaceLabelKey: comp.Namespace,
+			resource.SynthesisUUIDLabelKey:        uuid,
+		}
+		return cm
+	}
+	downstream := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(newCM("test-current", "uuid-2"), newCM("test-stale", "uuid-1")).
+		Build()
+
+	// Only the current configmap is part of the current synthesis
+	slice := apiv1.ResourceSlice{}
+	slice.Name = "test-slice"
+	slice.Namespace = "default"
+	slice.Spec.Resources = []apiv1.Manifest{{
+		Manifest: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"test-current","namespace":"default"}}`,
+	}}
+	cache := &resource.Cache{}
+	cache.SetQueue(workqueue.NewTypedRateLimitingQueue[resource.Request](workqueue.DefaultTypedControllerRateLimiter[resource.Request]()))
+	cache.Fill(ctx, types.NamespacedName{Name: comp.Name, Namespace: comp.Namespace}, "uuid-2", []apiv1.ResourceSlice{slice})
+
+	p := &pruneController{client: cli, upstreamClient: downstream, cache: cache}
+	_, err := p.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: comp.Name, Namespace: comp.Namespace}})
+	require.NoError(t, err)
+
+	// The stale configmap is pruned while the current one remains
+	cm := &corev1.ConfigMap{}
+	assert.Error(t, downstream.Get(ctx, types.NamespacedName{Name: "test-stale", Namespace: "default"}, cm))
+	assert.NoError(t, downstream.Get(ctx, types.NamespacedName{Name: "test-current", Namespace: "default"}, cm))
+}
+
+func TestPruneStaleResourcesNotOptedIn(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1.SchemeBuilder.AddToScheme(scheme))
+	require.NoError(t, corev1.SchemeBuilder.AddToScheme(scheme))
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{UUID: "uuid-2", Reconciled: ptr.To(metav1.Now())}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(comp).WithStatusSubresource(comp).Build()
+
+	p := &pruneController{client: cli, upstreamClient: cli, cache: &resource.Cache{}}
+	_, err := p.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(comp)})
+	require.NoError(t, err)
+}
@@ -0,0 +1,63 @@
+package reconciliation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/flowcontrol"
+	"github.com/Azure/eno/internal/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestCompositionWriteRateLimit(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.SchemeBuilder.AddToScheme(scheme))
+
+	var timestamps []time.Time
+	downstream := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				timestamps = append(timestamps, time.Now())
+				return cli.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	c := &Controller{
+		upstreamClient: downstream,
+		writeRateLimit: flowcontrol.NewCompositionRateLimiter(20),
+	}
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	for i := 0; i < 5; i++ {
+		slice := &apiv1.ResourceSlice{
+			Spec: apiv1.ResourceSliceSpec{
+				Resources: []apiv1.Manifest{{Manifest: fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm-%d","namespace":"default"}}`, i)}},
+			},
+		}
+		res, err := resource.NewResource(ctx, slice, 0)
+		require.NoError(t, err)
+
+		modified, err := c.reconcileResource(ctx, c.upstreamClient, comp, nil, res, nil, "")
+		require.NoError(t, err)
+		assert.True(t, modified)
+	}
+
+	// 5 creates at 20 qps with a burst of 1 should be spaced ~50ms apart
+	// instead of bursting - allow generous slack to avoid flakes
+	require.Len(t, timestamps, 5)
+	elapsed := timestamps[len(timestamps)-1].Sub(timestamps[0])
+	assert.GreaterOrEqual(t, elapsed, 150*time.Millisecond)
+}
@@ -0,0 +1,27 @@
+package reconciliation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPatchResourceStateFailed(t *testing.T) {
+	now := metav1.Now()
+	state := patchResourceState(false, nil, &now, "")(nil)
+	require.NotNil(t, state)
+	require.NotNil(t, state.Failed)
+
+	// Later evaluations preserve the original failure time
+	later := metav1.NewTime(now.Add(time.Minute))
+	assert.Nil(t, patchResourceState(false, nil, &later, "")(state))
+
+	// Becoming ready clears the failure
+	state = patchResourceState(false, &now, nil, "")(state)
+	require.NotNil(t, state)
+	assert.Nil(t, state.Failed)
+	assert.NotNil(t, state.Ready)
+}
@@ -0,0 +1,104 @@
+package reconciliation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// TestResynthesisSkipsUnchangedResources proves that resources whose manifests
+// weren't changed by a resynthesis aren't re-applied - only the changed ones are.
+func TestResynthesisSkipsUnchangedResources(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.SchemeBuilder.AddToScheme(scheme))
+
+	var writes int
+	downstream := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				writes++
+				return cli.Create(ctx, obj, opts...)
+			},
+			Update: func(ctx context.Context, cli client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				writes++
+				return cli.Update(ctx, obj, opts...)
+			},
+			Patch: func(ctx context.Context, cli client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				writes++
+				return cli.Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+	c := &Controller{upstreamClient: downstream}
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	comp.Status.PreviousSynthesis = &apiv1.Synthesis{UUID: "uuid-1"}
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{UUID: "uuid-2"}
+
+	manifest := func(name, data string) apiv1.Manifest {
+		return apiv1.Manifest{Manifest: fmt.Sprintf(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"%s","namespace":"default"},"data":{"key":"%s"}}`, name, data)}
+	}
+
+	// The resynthesis only changed cm-2's manifest
+	prevSlice := apiv1.ResourceSlice{}
+	prevSlice.Name = "slice-1"
+	prevSlice.Namespace = "default"
+	prevSlice.Spec.Resources = []apiv1.Manifest{manifest("cm-0", "a"), manifest("cm-1", "a"), manifest("cm-2", "a")}
+	prevSlice.Status.Resources = []apiv1.ResourceState{{Reconciled: true}, {Reconciled: true}, {Reconciled: true}}
+
+	currentSlice := apiv1.ResourceSlice{}
+	currentSlice.Name = "slice-2"
+	currentSlice.Namespace = "default"
+	currentSlice.Spec.Resources = []apiv1.Manifest{manifest("cm-0", "a"), manifest("cm-1", "a"), manifest("cm-2", "b")}
+
+	cache := &resource.Cache{}
+	cache.SetQueue(workqueue.NewTypedRateLimitingQueue[resource.Request](workqueue.DefaultTypedControllerRateLimiter[resource.Request]()))
+	compNSN := types.NamespacedName{Name: comp.Name, Namespace: comp.Namespace}
+	cache.Fill(ctx, compNSN, "uuid-1", []apiv1.ResourceSlice{prevSlice})
+	cache.Fill(ctx, compNSN, "uuid-2", []apiv1.ResourceSlice{currentSlice})
+	cache.Visit(ctx, comp, "uuid-1", []apiv1.ResourceSlice{prevSlice})
+	cache.Visit(ctx, comp, "uuid-2", []apiv1.ResourceSlice{currentSlice})
+
+	for i := 0; i < 3; i++ {
+		ref := resource.Ref{Kind: "ConfigMap", Name: fmt.Sprintf("cm-%d", i), Namespace: "default"}
+		res, _, found := cache.Get(ctx, "uuid-2", ref)
+		require.True(t, found)
+		prev, _, found := cache.Get(ctx, "uuid-1", ref)
+		require.True(t, found)
+
+		// The unchanged live objects have drifted, so an apply would write to them
+		current := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": ref.Name, "namespace": "default", "resourceVersion": "1"},
+			"data":       map[string]any{"key": "drifted"},
+		}}
+		if i == 2 {
+			current = nil // the changed resource doesn't exist yet
+		}
+
+		modified, err := c.reconcileResource(ctx, c.upstreamClient, comp, prev, res, current, "")
+		require.NoError(t, err)
+		assert.Equal(t, i == 2, modified, "resource %d", i)
+	}
+
+	// Only the changed manifest was re-applied
+	assert.Equal(t, 1, writes)
+}
@@ -0,0 +1,110 @@
+package reconciliation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/flowcontrol"
+	"github.com/Azure/eno/internal/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// TestServerSideApply covers the opt-in server-side apply mode: updates go
+// through the apiserver's apply machinery under the eno field manager, and
+// conflicts with other field managers are surfaced in the resource's state.
+func TestServerSideApply(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1.SchemeBuilder.AddToScheme(scheme))
+	require.NoError(t, corev1.SchemeBuilder.AddToScheme(scheme))
+
+	slice := &apiv1.ResourceSlice{}
+	slice.Name = "test-slice"
+	slice.Namespace = "default"
+	slice.Spec.Resources = []apiv1.Manifest{{
+		Manifest: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"foo","namespace":"default"},"data":{"key":"value"}}`,
+	}}
+	upstream := fake.NewClientBuilder().WithScheme(scheme).WithObjects(slice).WithStatusSubresource(slice).Build()
+
+	// The fake client doesn't support apply patches, so emulate the apiserver's
+	// behavior while capturing the patch type and field manager
+	var conflict bool
+	var appliedType types.PatchType
+	var appliedOwner string
+	var appliedForce *bool
+	downstream := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, cli client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				appliedType = patch.Type()
+				o := &client.PatchOptions{}
+				for _, opt := range opts {
+					opt.ApplyToPatch(o)
+				}
+				appliedOwner = o.FieldManager
+				appliedForce = o.Force
+				if conflict {
+					return errors.NewConflict(corev1.Resource("configmaps"), obj.GetName(), fmt.Errorf("conflict with field manager %q", "hpa"))
+				}
+				return nil
+			},
+		}).
+		Build()
+
+	writeBuffer := flowcontrol.NewResourceSliceWriteBuffer(upstream)
+	go writeBuffer.Start(ctx)
+
+	c := &Controller{upstreamClient: downstream, writeBuffer: writeBuffer, serverSideApply: true}
+
+	res, err := resource.NewResource(ctx, slice, 0)
+	require.NoError(t, err)
+	current := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": "foo", "namespace": "default", "resourceVersion": "1"},
+		"data":       map[string]any{"key": "old"},
+	}}
+
+	// Conflicts block reconciliation and are recorded on the resource's state
+	conflict = true
+	_, err = c.reconcileResource(ctx, c.upstreamClient, &apiv1.Composition{}, nil, res, current, "")
+	require.ErrorContains(t, err, "applying resource")
+	require.Eventually(t, func() bool {
+		require.NoError(t, upstream.Get(ctx, client.ObjectKeyFromObject(slice), slice))
+		return len(slice.Status.Resources) == 1 && slice.Status.Resources[0].FieldConflict != ""
+	}, time.Second*10, time.Millisecond*10)
+
+	// Successful applies use the apply patch type with the eno field manager
+	conflict = false
+	modified, err := c.reconcileResource(ctx, c.upstreamClient, &apiv1.Composition{}, nil, res, current, "")
+	require.NoError(t, err)
+	assert.True(t, modified)
+	assert.Equal(t, types.ApplyPatchType, appliedType)
+	assert.Equal(t, fieldManager, appliedOwner)
+	assert.Nil(t, appliedForce)
+
+	// Resources that opt into forced conflicts take ownership of the fields
+	forced := slice.DeepCopy()
+	forced.Spec.Resources = []apiv1.Manifest{{
+		Manifest: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"foo","namespace":"default","annotations":{"eno.azure.io/force-conflicts":"true"}},"data":{"key":"value"}}`,
+	}}
+	forcedRes, err := resource.NewResource(ctx, forced, 0)
+	require.NoError(t, err)
+	_, err = c.reconcileResource(ctx, c.upstreamClient, &apiv1.Composition{}, nil, forcedRes, current, "")
+	require.NoError(t, err)
+	require.NotNil(t, appliedForce)
+	assert.True(t, *appliedForce)
+}
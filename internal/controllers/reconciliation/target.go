@@ -0,0 +1,84 @@
+package reconciliation
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/resource"
+)
+
+// targetClientCache resolves the client used to reconcile each resource.
+// Resources that name a registered spoke cluster or a kubeconfig secret are
+// reconciled into that cluster, everything else uses the default target
+// cluster. Secret-derived clients are cached per secret and rebuilt when the
+// secret changes.
+type targetClientCache struct {
+	reader        client.Reader
+	defaultClient client.Client
+	named         map[string]client.Client
+
+	mut     sync.Mutex
+	clients map[types.NamespacedName]*targetClient
+}
+
+type targetClient struct {
+	client          client.Client
+	resourceVersion string
+}
+
+func newTargetClientCache(reader client.Reader, defaultClient client.Client, named map[string]client.Client) *targetClientCache {
+	return &targetClientCache{
+		reader:        reader,
+		defaultClient: defaultClient,
+		named:         named,
+		clients:       map[types.NamespacedName]*targetClient{},
+	}
+}
+
+func (t *targetClientCache) Get(ctx context.Context, comp *apiv1.Composition, res *resource.Resource) (client.Client, error) {
+	if res.TargetCluster != "" {
+		cli, ok := t.named[res.TargetCluster]
+		if !ok {
+			return nil, fmt.Errorf("no client is registered for target cluster %q", res.TargetCluster)
+		}
+		return cli, nil
+	}
+
+	if res.TargetKubeconfigSecret == "" {
+		return t.defaultClient, nil
+	}
+
+	key := types.NamespacedName{Namespace: comp.Namespace, Name: res.TargetKubeconfigSecret}
+	secret := &corev1.Secret{}
+	if err := t.reader.Get(ctx, key, secret); err != nil {
+		return nil, fmt.Errorf("getting target kubeconfig secret: %w", err)
+	}
+
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	if cached, ok := t.clients[key]; ok && cached.resourceVersion == secret.ResourceVersion {
+		return cached.client, nil
+	}
+
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(secret.Data["kubeconfig"])
+	if err != nil {
+		return nil, fmt.Errorf("parsing target kubeconfig: %w", err)
+	}
+	cli, err := client.New(cfg, client.Options{
+		Scheme: runtime.NewScheme(), // empty scheme since we shouldn't rely on compile-time types
+	})
+	if err != nil {
+		return nil, fmt.Errorf("building target cluster client: %w", err)
+	}
+
+	t.clients[key] = &targetClient{client: cli, resourceVersion: secret.ResourceVersion}
+	return cli, nil
+}
@@ -0,0 +1,121 @@
+package reconciliation
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// TestTargetClientResolution proves that resources in a single composition can be
+// reconciled into different target clusters based on their annotations.
+func TestTargetClientResolution(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.SchemeBuilder.AddToScheme(scheme))
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+
+	secret := &corev1.Secret{}
+	secret.Name = "cluster-b"
+	secret.Namespace = comp.Namespace
+	secret.ResourceVersion = "1"
+
+	defaultCluster := fake.NewClientBuilder().WithScheme(scheme).Build()
+	targetCluster := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+
+	cache := newTargetClientCache(reader, defaultCluster, nil)
+	cache.clients[types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}] = &targetClient{
+		client:          targetCluster,
+		resourceVersion: secret.ResourceVersion,
+	}
+	c := &Controller{upstreamClient: defaultCluster, targetClients: cache}
+
+	slice := &apiv1.ResourceSlice{
+		Spec: apiv1.ResourceSliceSpec{
+			Resources: []apiv1.Manifest{
+				{Manifest: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"local-cm","namespace":"default"}}`},
+				{Manifest: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"remote-cm","namespace":"team-b","annotations":{"eno.azure.io/target-kubeconfig-secret":"cluster-b"}}}`},
+			},
+		},
+	}
+
+	// Reconcile both resources - each should land in its own cluster
+	for i := range slice.Spec.Resources {
+		res, err := resource.NewResource(ctx, slice, i)
+		require.NoError(t, err)
+
+		cli, err := cache.Get(ctx, comp, res)
+		require.NoError(t, err)
+
+		modified, err := c.reconcileResource(ctx, cli, comp, nil, res, nil, "")
+		require.NoError(t, err)
+		assert.True(t, modified)
+	}
+
+	cm := &corev1.ConfigMap{}
+	require.NoError(t, defaultCluster.Get(ctx, types.NamespacedName{Namespace: "default", Name: "local-cm"}, cm))
+	assert.True(t, apierrors.IsNotFound(defaultCluster.Get(ctx, types.NamespacedName{Namespace: "team-b", Name: "remote-cm"}, cm)))
+
+	require.NoError(t, targetCluster.Get(ctx, types.NamespacedName{Namespace: "team-b", Name: "remote-cm"}, cm))
+	assert.True(t, apierrors.IsNotFound(targetCluster.Get(ctx, types.NamespacedName{Namespace: "default", Name: "local-cm"}, cm)))
+
+	// Missing secrets fail resolution instead of silently falling back to the default cluster
+	missing := &resource.Resource{TargetKubeconfigSecret: "does-not-exist"}
+	_, err := cache.Get(ctx, comp, missing)
+	require.ErrorContains(t, err, "getting target kubeconfig secret")
+}
+
+// TestTargetClusterRegistry proves that resources can name a cluster from the
+// controller's registry of spoke clients instead of a kubeconfig secret.
+func TestTargetClusterRegistry(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.SchemeBuilder.AddToScheme(scheme))
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+
+	defaultCluster := fake.NewClientBuilder().WithScheme(scheme).Build()
+	spokeCluster := fake.NewClientBuilder().WithScheme(scheme).Build()
+	cache := newTargetClientCache(defaultCluster, defaultCluster, map[string]client.Client{"spoke-a": spokeCluster})
+
+	slice := &apiv1.ResourceSlice{
+		Spec: apiv1.ResourceSliceSpec{
+			Resources: []apiv1.Manifest{
+				{Manifest: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"local-cm","namespace":"default"}}`},
+				{Manifest: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"spoke-cm","namespace":"default","annotations":{"eno.azure.io/target-cluster":"spoke-a"}}}`},
+			},
+		},
+	}
+
+	local, err := resource.NewResource(ctx, slice, 0)
+	require.NoError(t, err)
+	cli, err := cache.Get(ctx, comp, local)
+	require.NoError(t, err)
+	assert.Same(t, client.Client(defaultCluster), cli)
+
+	spoke, err := resource.NewResource(ctx, slice, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "spoke-a", spoke.TargetCluster)
+	cli, err = cache.Get(ctx, comp, spoke)
+	require.NoError(t, err)
+	assert.Same(t, client.Client(spokeCluster), cli)
+
+	// Unregistered clusters fail resolution instead of silently falling back to the default cluster
+	_, err = cache.Get(ctx, comp, &resource.Resource{TargetCluster: "unregistered"})
+	require.ErrorContains(t, err, `no client is registered for target cluster "unregistered"`)
+}
@@ -0,0 +1,157 @@
+package resourceslice
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/manager"
+	"github.com/go-logr/logr"
+)
+
+// compactionController rewrites a completed synthesis's many small resource slices
+// into fewer larger ones, up to the configured byte budget per slice. Synthesizers
+// that emit lots of tiny resources otherwise accumulate slices that bloat etcd and
+// slow down listing.
+//
+// Compaction never runs while a synthesis is in flight, preserves manifest ordering
+// so per-resource indices remain stable, and carries the reconciliation state of
+// every resource over to the new slices. The old slices are released by the cleanup
+// controller once the composition no longer references them.
+type compactionController struct {
+	client       client.Client
+	maxJsonBytes int
+}
+
+func NewCompactionController(mgr ctrl.Manager, maxJsonBytes int) error {
+	c := &compactionController{
+		client:       mgr.GetClient(),
+		maxJsonBytes: maxJsonBytes,
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1.Composition{}).
+		Owns(&apiv1.ResourceSlice{}).
+		WithLogConstructor(manager.NewLogConstructor(mgr, "sliceCompactionController")).
+		Complete(c)
+}
+
+func (c *compactionController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	comp := &apiv1.Composition{}
+	err := c.client.Get(ctx, req.NamespacedName, comp)
+	if err != nil {
+		logger.Error(err, "failed to get composition")
+		return manager.RequeueTransientErr(client.IgnoreNotFound(err))
+	}
+
+	syn := comp.Status.CurrentSynthesis
+	if comp.DeletionTimestamp != nil || comp.Status.InFlightSynthesis != nil || syn == nil || syn.Synthesized == nil || len(syn.ResourceSlices) < 2 {
+		return ctrl.Result{}, nil
+	}
+	logger = logger.WithValues("compositionName", comp.Name, "compositionNamespace", comp.Namespace, "synthesisID", syn.UUID)
+
+	// Load the slices in reference order - manifest ordering must survive compaction
+	slices := make([]*apiv1.ResourceSlice, 0, len(syn.ResourceSlices))
+	for _, ref := range syn.ResourceSlices {
+		slice := &apiv1.ResourceSlice{}
+		slice.Name = ref.Name
+		slice.Namespace = comp.Namespace
+		err := c.client.Get(ctx, client.ObjectKeyFromObject(slice), slice)
+		if err != nil {
+			// Missing slices are handled by the slice controller, not us
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		if slice.Spec.SynthesisUUID != syn.UUID {
+			return ctrl.Result{}, nil // stale informer - try again on the next event
+		}
+		if len(slice.Status.Resources) != len(slice.Spec.Resources) {
+			// Wait until every resource's state has been recorded so it can be
+			// carried over to the compacted slices
+			return ctrl.Result{}, nil
+		}
+		slices = append(slices, slice)
+	}
+
+	compacted := c.compact(comp, slices)
+	if len(compacted) >= len(slices) {
+		return ctrl.Result{}, nil // compaction wouldn't reduce the slice count
+	}
+
+	refs := make([]*apiv1.ResourceSliceRef, len(compacted))
+	for i, slice := range compacted {
+		status := slice.Status
+		if err := c.client.Create(ctx, slice); err != nil {
+			return ctrl.Result{}, fmt.Errorf("creating compacted resource slice %d: %w", i, err)
+		}
+		slice.Status = status
+		if err := c.client.Status().Update(ctx, slice); err != nil {
+			return ctrl.Result{}, fmt.Errorf("updating status of compacted resource slice %d: %w", i, err)
+		}
+		refs[i] = &apiv1.ResourceSliceRef{Name: slice.Name}
+	}
+
+	// Swap the references atomically. If the synthesis has moved on in the meantime
+	// the new slices are simply left unreferenced for the cleanup controller to collect.
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		current := &apiv1.Composition{}
+		if err := c.client.Get(ctx, req.NamespacedName, current); err != nil {
+			return err
+		}
+		syn := current.Status.CurrentSynthesis
+		if current.Status.InFlightSynthesis != nil || syn == nil || syn.UUID != comp.Status.CurrentSynthesis.UUID {
+			return nil
+		}
+		syn.ResourceSlices = refs
+		return c.client.Status().Update(ctx, current)
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("updating composition status: %w", err)
+	}
+
+	logger.V(0).Info("compacted resource slices", "before", len(slices), "after", len(compacted))
+	return ctrl.Result{}, nil
+}
+
+// compact repartitions the slices' manifests across as few slices as the byte
+// budget allows, preserving order and per-resource state.
+func (c *compactionController) compact(comp *apiv1.Composition, slices []*apiv1.ResourceSlice) []*apiv1.ResourceSlice {
+	var (
+		compacted          []*apiv1.ResourceSlice
+		sliceBytes         int
+		slice              *apiv1.ResourceSlice
+		blockOwnerDeletion = true
+	)
+	for _, old := range slices {
+		for i, manifest := range old.Spec.Resources {
+			if slice == nil || sliceBytes >= c.maxJsonBytes {
+				sliceBytes = 0
+				slice = &apiv1.ResourceSlice{}
+				slice.GenerateName = comp.Name + "-"
+				slice.Namespace = comp.Namespace
+				slice.Finalizers = []string{"eno.azure.io/cleanup"}
+				slice.OwnerReferences = []metav1.OwnerReference{{
+					APIVersion:         apiv1.SchemeGroupVersion.Identifier(),
+					Kind:               "Composition",
+					Name:               comp.Name,
+					UID:                comp.UID,
+					BlockOwnerDeletion: &blockOwnerDeletion,
+					Controller:         &blockOwnerDeletion,
+				}}
+				slice.Spec.SynthesisUUID = old.Spec.SynthesisUUID
+				slice.Spec.Attempt = old.Spec.Attempt
+				slice.Spec.CompositionGeneration = old.Spec.CompositionGeneration
+				compacted = append(compacted, slice)
+			}
+			sliceBytes += len(manifest.Manifest)
+			slice.Spec.Resources = append(slice.Spec.Resources, manifest)
+			slice.Status.Resources = append(slice.Status.Resources, old.Status.Resources[i])
+		}
+	}
+	return compacted
+}
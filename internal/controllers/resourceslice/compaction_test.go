@@ -0,0 +1,138 @@
+package resourceslice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/eno/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+)
+
+func TestCompaction(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	cli := testutil.NewClient(t)
+
+	now := metav1.Now()
+	comp := &apiv1.Composition{}
+	comp.Name = "test"
+	comp.Namespace = "default"
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{UUID: "test-uuid", Synthesized: &now}
+
+	// Three tiny slices, each holding a single reconciled resource
+	for i := 0; i < 3; i++ {
+		slice := &apiv1.ResourceSlice{}
+		slice.Name = fmt.Sprintf("test-slice-%d", i)
+		slice.Namespace = comp.Namespace
+		slice.Spec.SynthesisUUID = "test-uuid"
+		slice.Spec.Resources = []apiv1.Manifest{{Manifest: fmt.Sprintf(`{"index":%d}`, i)}}
+		slice.Status.Resources = []apiv1.ResourceState{{Reconciled: true, Ready: &now}}
+		require.NoError(t, cli.Create(ctx, slice))
+		require.NoError(t, cli.Status().Update(ctx, slice))
+		comp.Status.CurrentSynthesis.ResourceSlices = append(comp.Status.CurrentSynthesis.ResourceSlices, &apiv1.ResourceSliceRef{Name: slice.Name})
+	}
+	require.NoError(t, cli.Create(ctx, comp))
+	require.NoError(t, cli.Status().Update(ctx, comp))
+
+	c := &compactionController{client: cli, maxJsonBytes: 100000}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: comp.Namespace, Name: comp.Name}}
+	_, err := c.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	// The composition now references a single slice holding every manifest in order,
+	// with the reconciliation state carried over
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	require.Len(t, comp.Status.CurrentSynthesis.ResourceSlices, 1)
+
+	slice := &apiv1.ResourceSlice{}
+	slice.Name = comp.Status.CurrentSynthesis.ResourceSlices[0].Name
+	slice.Namespace = comp.Namespace
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(slice), slice))
+	require.Len(t, slice.Spec.Resources, 3)
+	require.Len(t, slice.Status.Resources, 3)
+	assert.Equal(t, "test-uuid", slice.Spec.SynthesisUUID)
+	for i, manifest := range slice.Spec.Resources {
+		assert.Equal(t, fmt.Sprintf(`{"index":%d}`, i), manifest.Manifest)
+		assert.True(t, slice.Status.Resources[i].Reconciled)
+	}
+
+	// Reconciling again is a no-op
+	_, err = c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	assert.Len(t, comp.Status.CurrentSynthesis.ResourceSlices, 1)
+}
+
+func TestCompactionInFlightSynthesis(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	cli := testutil.NewClient(t)
+
+	now := metav1.Now()
+	comp := &apiv1.Composition{}
+	comp.Name = "test"
+	comp.Namespace = "default"
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{UUID: "test-uuid", Synthesized: &now}
+	comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "test-uuid-2"}
+
+	for i := 0; i < 2; i++ {
+		slice := &apiv1.ResourceSlice{}
+		slice.Name = fmt.Sprintf("test-slice-%d", i)
+		slice.Namespace = comp.Namespace
+		slice.Spec.SynthesisUUID = "test-uuid"
+		slice.Spec.Resources = []apiv1.Manifest{{Manifest: "{}"}}
+		slice.Status.Resources = []apiv1.ResourceState{{Reconciled: true}}
+		require.NoError(t, cli.Create(ctx, slice))
+		require.NoError(t, cli.Status().Update(ctx, slice))
+		comp.Status.CurrentSynthesis.ResourceSlices = append(comp.Status.CurrentSynthesis.ResourceSlices, &apiv1.ResourceSliceRef{Name: slice.Name})
+	}
+	require.NoError(t, cli.Create(ctx, comp))
+	require.NoError(t, cli.Status().Update(ctx, comp))
+
+	// Compaction refuses to run while a synthesis is in flight
+	c := &compactionController{client: cli, maxJsonBytes: 100000}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: comp.Namespace, Name: comp.Name}}
+	_, err := c.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	assert.Len(t, comp.Status.CurrentSynthesis.ResourceSlices, 2)
+}
+
+func TestCompactionIncompleteStatus(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	cli := testutil.NewClient(t)
+
+	now := metav1.Now()
+	comp := &apiv1.Composition{}
+	comp.Name = "test"
+	comp.Namespace = "default"
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{UUID: "test-uuid", Synthesized: &now}
+
+	for i := 0; i < 2; i++ {
+		slice := &apiv1.ResourceSlice{}
+		slice.Name = fmt.Sprintf("test-slice-%d", i)
+		slice.Namespace = comp.Namespace
+		slice.Spec.SynthesisUUID = "test-uuid"
+		slice.Spec.Resources = []apiv1.Manifest{{Manifest: "{}"}}
+		// No status - the reconciler hasn't seen these resources yet
+		require.NoError(t, cli.Create(ctx, slice))
+		comp.Status.CurrentSynthesis.ResourceSlices = append(comp.Status.CurrentSynthesis.ResourceSlices, &apiv1.ResourceSliceRef{Name: slice.Name})
+	}
+	require.NoError(t, cli.Create(ctx, comp))
+	require.NoError(t, cli.Status().Update(ctx, comp))
+
+	// Compaction waits until every resource's state has been recorded
+	c := &compactionController{client: cli, maxJsonBytes: 100000}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: comp.Namespace, Name: comp.Name}}
+	_, err := c.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	assert.Len(t, comp.Status.CurrentSynthesis.ResourceSlices, 2)
+}
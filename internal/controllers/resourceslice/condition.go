@@ -0,0 +1,94 @@
+package resourceslice
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/manager"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// conditionController maintains the ResourceSlicesReconciled condition on compositions
+// so operators can tell whether a slow slice cleanup is making progress or stuck.
+// The condition is true only when every slice of the current synthesis exists and no
+// unreferenced slices from older syntheses remain.
+type conditionController struct {
+	client client.Client
+}
+
+func NewConditionController(mgr ctrl.Manager) error {
+	c := &conditionController{client: mgr.GetClient()}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1.Composition{}).
+		Owns(&apiv1.ResourceSlice{}).
+		WithLogConstructor(manager.NewLogConstructor(mgr, "sliceConditionController")).
+		Complete(c)
+}
+
+func (c *conditionController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	comp := &apiv1.Composition{}
+	err := c.client.Get(ctx, req.NamespacedName, comp)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if comp.DeletionTimestamp != nil || comp.Status.CurrentSynthesis == nil {
+		return ctrl.Result{}, nil
+	}
+
+	list := &apiv1.ResourceSliceList{}
+	err = c.client.List(ctx, list, client.InNamespace(comp.Namespace))
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing resource slices: %w", err)
+	}
+
+	var current, orphaned int
+	for i := range list.Items {
+		slice := &list.Items[i]
+		owner := metav1.GetControllerOf(slice)
+		if owner == nil || owner.UID != comp.UID {
+			continue
+		}
+		if slice.Spec.SynthesisUUID == comp.Status.CurrentSynthesis.UUID {
+			current++
+		}
+		if !sliceInUse(comp, slice) {
+			orphaned++
+		}
+	}
+
+	expected := len(comp.Status.CurrentSynthesis.ResourceSlices)
+	cond := metav1.Condition{
+		Type:               apiv1.ConditionResourceSlicesReconciled,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Reconciled",
+		Message:            fmt.Sprintf("All %d resource slices of the current synthesis exist and no stale slices remain", expected),
+		ObservedGeneration: comp.Generation,
+	}
+	if current != expected {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "MissingSlices"
+		cond.Message = fmt.Sprintf("%d of %d resource slices of the current synthesis exist", current, expected)
+	} else if orphaned > 0 {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "CleanupPending"
+		cond.Message = fmt.Sprintf("%d orphaned resource slices are pending deletion", orphaned)
+	}
+
+	if !meta.SetStatusCondition(&comp.Status.Conditions, cond) {
+		return ctrl.Result{}, nil
+	}
+	err = c.client.Status().Update(ctx, comp)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("updating composition status: %w", err)
+	}
+
+	logger.V(1).Info("updated composition resource slice condition", "conditionStatus", cond.Status, "conditionReason", cond.Reason)
+	return ctrl.Result{}, nil
+}
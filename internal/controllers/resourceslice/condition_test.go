@@ -0,0 +1,84 @@
+package resourceslice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/testutil"
+)
+
+func TestSliceCondition(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	cli := testutil.NewClient(t)
+	c := conditionController{client: cli}
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-1"
+	comp.Namespace = "default"
+	require.NoError(t, cli.Create(ctx, comp))
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(comp)}
+
+	newSlice := func(name, uuid string) *apiv1.ResourceSlice {
+		slice := &apiv1.ResourceSlice{}
+		slice.Name = name
+		slice.Namespace = comp.Namespace
+		slice.Spec.SynthesisUUID = uuid
+		require.NoError(t, controllerutil.SetControllerReference(comp, slice, cli.Scheme()))
+		require.NoError(t, cli.Create(ctx, slice))
+		return slice
+	}
+	getCondition := func() *metav1.Condition {
+		require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+		return meta.FindStatusCondition(comp.Status.Conditions, apiv1.ConditionResourceSlicesReconciled)
+	}
+
+	// No current synthesis - no condition
+	_, err := c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Nil(t, getCondition())
+
+	// The current synthesis references a slice that doesn't exist yet
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{
+		UUID:           "current-uuid",
+		ResourceSlices: []*apiv1.ResourceSliceRef{{Name: "slice-1"}},
+	}
+	require.NoError(t, cli.Status().Update(ctx, comp))
+
+	_, err = c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	cond := getCondition()
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, "MissingSlices", cond.Reason)
+
+	// All current slices exist but an orphaned slice remains
+	newSlice("slice-1", "current-uuid")
+	newSlice("stale", "old-uuid")
+
+	_, err = c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	cond = getCondition()
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, "CleanupPending", cond.Reason)
+	assert.Equal(t, "1 orphaned resource slices are pending deletion", cond.Message)
+
+	// Cleanup finished - the condition becomes true
+	stale := &apiv1.ResourceSlice{}
+	stale.Name = "stale"
+	stale.Namespace = comp.Namespace
+	require.NoError(t, cli.Delete(ctx, stale))
+
+	_, err = c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	cond = getCondition()
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+	assert.Equal(t, "Reconciled", cond.Reason)
+}
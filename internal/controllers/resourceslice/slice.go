@@ -37,7 +37,7 @@ func (s *sliceController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	err := s.client.Get(ctx, req.NamespacedName, comp)
 	if err != nil {
 		logger.Error(err, "failed to get composition")
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		return manager.RequeueTransientErr(client.IgnoreNotFound(err))
 	}
 	logger = logger.WithValues("compositionGeneration", comp.Generation, "compositionName", comp.Name, "compositionNamespace", comp.Namespace, "synthesisID", comp.Status.GetCurrentSynthesisUUID())
 	ctx = logr.NewContext(ctx, logger)
@@ -62,7 +62,7 @@ func (s *sliceController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		}
 		if err != nil {
 			logger.Error(err, "failed to get resource slice")
-			return ctrl.Result{}, err
+			return manager.RequeueTransientErr(err)
 		}
 
 		// Handle a case where the reconciliation controller hasn't updated the slice's status yet
@@ -73,17 +73,25 @@ func (s *sliceController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		}
 
 		// Collect the state of every resource
-		for _, state := range slice.Status.Resources {
+		for i, state := range slice.Status.Resources {
 			state := state
+			if i < len(slice.Spec.Resources) && slice.Spec.Resources[i].Optional {
+				continue // optional resources are best-effort and never block composition status
+			}
 			if resourceNotReconciled(comp, &state) {
 				snapshot.Reconciled = false
 			}
-			if state.Ready == nil {
+			// Readiness-optional resources must reconcile but don't gate readiness
+			readinessOptional := i < len(slice.Spec.Resources) && slice.Spec.Resources[i].ReadinessOptional
+			if state.Ready == nil && !readinessOptional {
 				snapshot.Ready = false
 			}
 			if state.Ready != nil && (snapshot.ReadyTime == nil || state.Ready.After(snapshot.ReadyTime.Time)) {
 				snapshot.ReadyTime = state.Ready
 			}
+			if state.Failed != nil && !readinessOptional && (snapshot.FailedTime == nil || snapshot.FailedTime.After(state.Failed.Time)) {
+				snapshot.FailedTime = state.Failed
+			}
 		}
 	}
 
@@ -145,7 +153,7 @@ func (s *sliceController) handleMissingSlice(ctx context.Context, comp *apiv1.Co
 func processCompositionTransition(ctx context.Context, comp *apiv1.Composition, snapshot statusSnapshot) (modified bool) {
 	logger := logr.FromContextOrDiscard(ctx)
 
-	if comp.Status.CurrentSynthesis == nil || ((comp.Status.CurrentSynthesis.Reconciled != nil) == snapshot.Reconciled && (comp.Status.CurrentSynthesis.Ready != nil) == snapshot.Ready) {
+	if comp.Status.CurrentSynthesis == nil || ((comp.Status.CurrentSynthesis.Reconciled != nil) == snapshot.Reconciled && (comp.Status.CurrentSynthesis.Ready != nil) == snapshot.Ready && (comp.Status.CurrentSynthesis.Failed != nil) == (snapshot.FailedTime != nil)) {
 		return false // either no change or no synthesis yet
 	}
 
@@ -157,6 +165,13 @@ func processCompositionTransition(ctx context.Context, comp *apiv1.Composition,
 	now := metav1.Now()
 	comp.Status.CurrentSynthesis.Reconciled = snapshot.GetReconciled(comp, &now, logger)
 	comp.Status.CurrentSynthesis.Ready = snapshot.GetReady(comp, logger)
+	comp.Status.CurrentSynthesis.Failed = snapshot.FailedTime
+	if snapshot.FailedTime != nil {
+		logger.V(0).Info("composition failed a readiness check timeout", "failedTime", snapshot.FailedTime)
+	}
+	if comp.Status.FirstReconciled == nil && comp.Status.CurrentSynthesis.Reconciled != nil {
+		comp.Status.FirstReconciled = comp.Status.CurrentSynthesis.Reconciled
+	}
 	return true
 }
 
@@ -173,6 +188,7 @@ type statusSnapshot struct {
 	Reconciled bool
 	Ready      bool
 	ReadyTime  *metav1.Time
+	FailedTime *metav1.Time // min across resources i.e. the first failure
 }
 
 func (s *statusSnapshot) GetReconciled(comp *apiv1.Composition, now *metav1.Time, logger logr.Logger) *metav1.Time {
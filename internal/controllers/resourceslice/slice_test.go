@@ -9,11 +9,14 @@ import (
 	"github.com/Azure/eno/internal/testutil/statespace"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	apiv1 "github.com/Azure/eno/api/v1"
 )
@@ -375,6 +378,111 @@ func TestOrphanedOnPurpose(t *testing.T) {
 	assert.NotNil(t, comp.Status.CurrentSynthesis.Reconciled)
 }
 
+func TestOptionalResourceAggregation(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	cli := testutil.NewClient(t)
+
+	now := metav1.Now()
+	failure := metav1.Now()
+
+	// One required resource that's fully reconciled, one failing optional resource
+	slice := &apiv1.ResourceSlice{}
+	slice.Name = "test-slice-1"
+	slice.Namespace = "default"
+	slice.Spec.Resources = []apiv1.Manifest{{Manifest: "{}"}, {Manifest: "{}", Optional: true}}
+	slice.Status.Resources = []apiv1.ResourceState{
+		{Ready: &now, Reconciled: true},
+		{Failed: &failure, Reconciled: false},
+	}
+	require.NoError(t, cli.Create(ctx, slice))
+	require.NoError(t, cli.Status().Update(ctx, slice))
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test"
+	comp.Namespace = "default"
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{
+		Synthesized:    &now,
+		ResourceSlices: []*apiv1.ResourceSliceRef{{Name: slice.Name}},
+	}
+	require.NoError(t, cli.Create(ctx, comp))
+	require.NoError(t, cli.Status().Update(ctx, comp))
+
+	a := &sliceController{client: cli}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: comp.Namespace, Name: comp.Name}}
+	_, err := a.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	// The failing optional resource doesn't block the composition
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	assert.NotNil(t, comp.Status.CurrentSynthesis.Reconciled)
+	assert.NotNil(t, comp.Status.CurrentSynthesis.Ready)
+	assert.Nil(t, comp.Status.CurrentSynthesis.Failed)
+
+	// The same failure on a required resource does
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(slice), slice))
+	slice.Spec.Resources[1].Optional = false
+	require.NoError(t, cli.Update(ctx, slice))
+
+	_, err = a.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	assert.Nil(t, comp.Status.CurrentSynthesis.Reconciled)
+	assert.Nil(t, comp.Status.CurrentSynthesis.Ready)
+	assert.NotNil(t, comp.Status.CurrentSynthesis.Failed)
+}
+
+func TestReadinessOptionalResourceAggregation(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	cli := testutil.NewClient(t)
+
+	now := metav1.Now()
+
+	// One required resource that's fully reconciled, one readiness-optional resource
+	// that has been reconciled but isn't ready
+	slice := &apiv1.ResourceSlice{}
+	slice.Name = "test-slice-1"
+	slice.Namespace = "default"
+	slice.Spec.Resources = []apiv1.Manifest{{Manifest: "{}"}, {Manifest: "{}", ReadinessOptional: true}}
+	slice.Status.Resources = []apiv1.ResourceState{
+		{Ready: &now, Reconciled: true},
+		{Reconciled: true},
+	}
+	require.NoError(t, cli.Create(ctx, slice))
+	require.NoError(t, cli.Status().Update(ctx, slice))
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test"
+	comp.Namespace = "default"
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{
+		Synthesized:    &now,
+		ResourceSlices: []*apiv1.ResourceSliceRef{{Name: slice.Name}},
+	}
+	require.NoError(t, cli.Create(ctx, comp))
+	require.NoError(t, cli.Status().Update(ctx, comp))
+
+	a := &sliceController{client: cli}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: comp.Namespace, Name: comp.Name}}
+	_, err := a.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	// The unready readiness-optional resource doesn't block the composition's readiness
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	assert.NotNil(t, comp.Status.CurrentSynthesis.Reconciled)
+	assert.NotNil(t, comp.Status.CurrentSynthesis.Ready)
+
+	// Unlike fully optional resources, readiness-optional resources still gate reconciliation
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(slice), slice))
+	slice.Status.Resources[1].Reconciled = false
+	require.NoError(t, cli.Status().Update(ctx, slice))
+
+	_, err = a.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	assert.Nil(t, comp.Status.CurrentSynthesis.Reconciled)
+}
+
 func TestFuzzProcessCompositionTransition(t *testing.T) {
 	statespace.Test(func(test *compositionTransitionTest) bool {
 		return processCompositionTransition(context.Background(), test.Composition.DeepCopy(), test.Snapshot)
@@ -442,3 +550,93 @@ type compositionTransitionTest struct {
 	Composition *apiv1.Composition
 	Snapshot    statusSnapshot
 }
+
+func TestTransientErrorRequeue(t *testing.T) {
+	ctx := testutil.NewContext(t)
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{
+		UUID:           "test-uuid",
+		ResourceSlices: []*apiv1.ResourceSliceRef{{Name: "test-slice-1"}},
+	}
+
+	var failGets bool
+	cli := testutil.NewClientWithInterceptors(t, &interceptor.Funcs{
+		Get: func(ctx context.Context, cli client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if _, ok := obj.(*apiv1.ResourceSlice); ok && failGets {
+				return errors.NewServerTimeout(schema.GroupResource{Group: "eno.azure.io", Resource: "resourceslices"}, "get", 1)
+			}
+			return cli.Get(ctx, key, obj, opts...)
+		},
+	}, comp)
+	c := &sliceController{client: cli}
+
+	// Transient apiserver errors result in a bounded requeue, not an immediate retry
+	failGets = true
+	res, err := c.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: comp.Name, Namespace: comp.Namespace}})
+	require.NoError(t, err)
+	assert.Greater(t, res.RequeueAfter, time.Duration(0))
+}
+
+func TestFirstReconciledTimestamp(t *testing.T) {
+	ctx := testutil.NewContext(t)
+
+	comp := &apiv1.Composition{}
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{UUID: "syn-1"}
+
+	// Set on the first full reconciliation
+	require.True(t, processCompositionTransition(ctx, comp, statusSnapshot{Reconciled: true}))
+	require.NotNil(t, comp.Status.FirstReconciled)
+	first := comp.Status.FirstReconciled
+
+	// A later synthesis briefly goes unreconciled
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{UUID: "syn-2"}
+	assert.False(t, processCompositionTransition(ctx, comp, statusSnapshot{}))
+	assert.Equal(t, first, comp.Status.FirstReconciled)
+
+	// Reconciling again doesn't move the timestamp
+	require.True(t, processCompositionTransition(ctx, comp, statusSnapshot{Reconciled: true}))
+	assert.Equal(t, first, comp.Status.FirstReconciled)
+}
+
+func TestFailedTimeAggregation(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	cli := testutil.NewClient(t)
+
+	now := metav1.Now()
+	laterFailure := metav1.NewTime(now.Add(time.Hour))
+
+	slice := &apiv1.ResourceSlice{}
+	slice.Name = "test-slice-1"
+	slice.Namespace = "default"
+	slice.Spec.Resources = []apiv1.Manifest{{Manifest: "{}"}, {Manifest: "{}"}}
+	slice.Status.Resources = []apiv1.ResourceState{
+		{Failed: &laterFailure, Reconciled: true},
+		{Failed: &now, Reconciled: true},
+	}
+	require.NoError(t, cli.Create(ctx, slice))
+	require.NoError(t, cli.Status().Update(ctx, slice))
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test"
+	comp.Namespace = "default"
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{
+		Synthesized:    &now,
+		ResourceSlices: []*apiv1.ResourceSliceRef{{Name: slice.Name}},
+	}
+	require.NoError(t, cli.Create(ctx, comp))
+	require.NoError(t, cli.Status().Update(ctx, comp))
+
+	a := &sliceController{client: cli}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: comp.Namespace, Name: comp.Name}}
+	_, err := a.Reconcile(ctx, req)
+	require.NoError(t, err)
+
+	// The earliest failure time is surfaced on the composition
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	require.NotNil(t, comp.Status.CurrentSynthesis.Failed)
+	assert.Equal(t, now.Round(time.Minute), comp.Status.CurrentSynthesis.Failed.Round(time.Minute))
+	assert.Nil(t, comp.Status.CurrentSynthesis.Ready)
+}
@@ -54,6 +54,11 @@ func (c *cleanupController) newCompEventHandler() handler.TypedEventHandler[*api
 				q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: ref.Name, Namespace: c.Namespace}})
 			}
 		}
+		for _, syn := range c.Status.SynthesisHistory {
+			for _, ref := range syn.ResourceSlices {
+				q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Name: ref.Name, Namespace: c.Namespace}})
+			}
+		}
 	}
 	return &handler.TypedFuncs[*apiv1.Composition, reconcile.Request]{
 		CreateFunc: func(ctx context.Context, e event.TypedCreateEvent[*apiv1.Composition], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
@@ -134,9 +139,14 @@ func (c *cleanupController) shouldDelete(ctx context.Context, reader client.Read
 		return false, fmt.Errorf("getting composition: %w", err)
 	}
 
-	// Don't delete slices that are part of an active synthesis
+	return !sliceInUse(comp, slice), nil
+}
+
+// sliceInUse returns true when any retained synthesis still references the slice.
+func sliceInUse(comp *apiv1.Composition, slice *apiv1.ResourceSlice) bool {
+	// Don't consider slices that are part of an active synthesis to be unused
 	if comp.Status.InFlightSynthesis != nil && comp.Status.InFlightSynthesis.UUID == slice.Spec.SynthesisUUID {
-		return false, nil
+		return true
 	}
 
 	// Check resource slice references
@@ -148,11 +158,21 @@ func (c *cleanupController) shouldDelete(ctx context.Context, reader client.Read
 			return ref.Name == slice.Name
 		})
 		if idx != -1 {
-			return false, nil
+			return true
+		}
+	}
+
+	// Syntheses retained per spec.historyLimit keep their slices too
+	for _, syn := range comp.Status.SynthesisHistory {
+		idx := slices.IndexFunc(syn.ResourceSlices, func(ref *apiv1.ResourceSliceRef) bool {
+			return ref.Name == slice.Name
+		})
+		if idx != -1 {
+			return true
 		}
 	}
 
-	return true, nil
+	return false
 }
 
 // removeFinalizer removes the finalizer from the resource slice if the slice is not needed for deletion of the composition.
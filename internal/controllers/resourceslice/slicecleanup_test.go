@@ -267,3 +267,42 @@ func TestSliceCleanupFinalizersCompReconciled(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, errors.IsNotFound(cli.Get(ctx, client.ObjectKeyFromObject(slice), slice)))
 }
+
+func TestSliceCleanupSynthesisHistory(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	cli := testutil.NewClient(t)
+	c := cleanupController{client: cli, noCacheReader: cli}
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-1"
+	comp.Namespace = "default"
+	require.NoError(t, cli.Create(ctx, comp))
+
+	slice := &apiv1.ResourceSlice{}
+	slice.Name = "test-1"
+	slice.Namespace = comp.Namespace
+	slice.Spec.SynthesisUUID = "test-uuid"
+	require.NoError(t, controllerutil.SetControllerReference(comp, slice, cli.Scheme()))
+	require.NoError(t, cli.Create(ctx, slice))
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(slice)}
+
+	// The slice is only referenced by a retained past synthesis - it shouldn't be deleted
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{ResourceSlices: []*apiv1.ResourceSliceRef{{Name: "different-slice"}}}
+	comp.Status.SynthesisHistory = []apiv1.RetainedSynthesis{{
+		UUID:           slice.Spec.SynthesisUUID,
+		ResourceSlices: []*apiv1.ResourceSliceRef{{Name: slice.Name}},
+	}}
+	require.NoError(t, cli.Status().Update(ctx, comp))
+
+	_, err := c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(slice), slice))
+
+	// The synthesis ages out of the history
+	comp.Status.SynthesisHistory = nil
+	require.NoError(t, cli.Status().Update(ctx, comp))
+
+	_, err = c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.True(t, errors.IsNotFound(cli.Get(ctx, client.ObjectKeyFromObject(slice), slice)))
+}
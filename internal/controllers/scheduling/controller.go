@@ -42,22 +42,26 @@ const (
 // Compositions will not receive the new synthesizer in the same order for every generation, but
 // the same generation will always roll out in the same order.
 type controller struct {
-	client            client.Client
-	concurrencyLimit  int
-	cooldownPeriod    time.Duration
-	cacheGracePeriod  time.Duration
-	watchdogThreshold time.Duration
+	client             client.Client
+	concurrencyLimit   int
+	nsConcurrencyLimit int
+	cooldownPeriod     time.Duration
+	cacheGracePeriod   time.Duration
+	watchdogThreshold  time.Duration
 
 	lastApplied *op
 }
 
-func NewController(mgr ctrl.Manager, concurrencyLimit int, cooldown, watchdogThreshold time.Duration) error {
+// NewController creates the scheduling controller. nsConcurrencyLimit bounds concurrent syntheses
+// per composition namespace, protecting namespace-scoped quotas from large fan-outs. Zero disables it.
+func NewController(mgr ctrl.Manager, concurrencyLimit, nsConcurrencyLimit int, cooldown, watchdogThreshold time.Duration) error {
 	c := &controller{
-		client:            mgr.GetClient(),
-		concurrencyLimit:  concurrencyLimit,
-		cooldownPeriod:    cooldown,
-		cacheGracePeriod:  time.Second,
-		watchdogThreshold: watchdogThreshold,
+		client:             mgr.GetClient(),
+		concurrencyLimit:   concurrencyLimit,
+		nsConcurrencyLimit: nsConcurrencyLimit,
+		cooldownPeriod:     cooldown,
+		cacheGracePeriod:   time.Second,
+		watchdogThreshold:  watchdogThreshold,
 	}
 	return ctrl.NewControllerManagedBy(mgr).
 		Named("schedulingController").
@@ -103,24 +107,42 @@ func (c *controller) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 		return ctrl.Result{}, err
 	}
 	nextSlot := c.getNextCooldownSlot(comps)
+	compsByName := indexCompositions(comps.Items)
 
 	var inFlight int
-	var op *op
+	inFlightByNamespace := map[string]int{}
+	inFlightBySynthesizer := map[string]int{}
 	stuckReconciling.Reset()
 	for _, comp := range comps.Items {
 		comp := comp
 		if comp.Synthesizing() {
 			inFlight++
+			inFlightByNamespace[comp.Namespace]++
+			inFlightBySynthesizer[comp.Spec.Synthesizer.Name]++
 		}
 
 		if missedReconciliation(&comp, c.watchdogThreshold) {
 			stuckReconciling.WithLabelValues(comp.Spec.Synthesizer.Name).Inc()
 		}
+	}
+
+	var op *op
+	for _, comp := range comps.Items {
+		comp := comp
+		if c.nsConcurrencyLimit > 0 && inFlightByNamespace[comp.Namespace] >= c.nsConcurrencyLimit {
+			continue // namespace is already at its synthesis cap
+		}
 
 		synth, ok := synthsByName[comp.Spec.Synthesizer.Name]
 		if !ok {
 			continue
 		}
+		if max := synth.Spec.MaxInFlight; max != nil && inFlightBySynthesizer[synth.Name] >= *max {
+			continue // synthesizer is already at its synthesis cap
+		}
+		if blocked, _, _ := dependencyState(&comp, compsByName); blocked {
+			continue // waiting for the composition's dependencies to be reconciled
+		}
 
 		next := newOp(&synth, &comp, nextSlot)
 		if next != nil && (op == nil || next.Less(op)) {
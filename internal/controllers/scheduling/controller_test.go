@@ -26,7 +26,7 @@ import (
 func TestBasics(t *testing.T) {
 	ctx := testutil.NewContext(t)
 	mgr := testutil.NewManager(t)
-	require.NoError(t, NewController(mgr.Manager, 100, 2*time.Second, 0))
+	require.NoError(t, NewController(mgr.Manager, 100, 0, 2*time.Second, 0))
 	mgr.Start(t)
 	cli := mgr.GetClient()
 
@@ -96,7 +96,7 @@ func TestBasics(t *testing.T) {
 func TestSynthRolloutBasics(t *testing.T) {
 	ctx := testutil.NewContext(t)
 	mgr := testutil.NewManager(t)
-	require.NoError(t, NewController(mgr.Manager, 100, 2*time.Second, 0))
+	require.NoError(t, NewController(mgr.Manager, 100, 0, 2*time.Second, 0))
 	mgr.Start(t)
 	cli := mgr.GetClient()
 
@@ -195,7 +195,7 @@ func TestSynthRolloutBasics(t *testing.T) {
 func TestDeferredInput(t *testing.T) {
 	ctx := testutil.NewContext(t)
 	mgr := testutil.NewManager(t)
-	require.NoError(t, NewController(mgr.Manager, 100, 2*time.Second, 0))
+	require.NoError(t, NewController(mgr.Manager, 100, 0, 2*time.Second, 0))
 	mgr.Start(t)
 	cli := mgr.GetClient()
 
@@ -267,7 +267,7 @@ func TestDeferredInput(t *testing.T) {
 func TestForcedResynth(t *testing.T) {
 	ctx := testutil.NewContext(t)
 	mgr := testutil.NewManager(t)
-	require.NoError(t, NewController(mgr.Manager, 100, 2*time.Second, 0))
+	require.NoError(t, NewController(mgr.Manager, 100, 0, 2*time.Second, 0))
 	mgr.Start(t)
 	cli := mgr.GetClient()
 
@@ -309,7 +309,7 @@ func TestForcedResynth(t *testing.T) {
 func TestChaos(t *testing.T) {
 	t.Run("one leader", func(t *testing.T) {
 		mgr := testutil.NewManager(t)
-		require.NoError(t, NewController(mgr.Manager, 5, time.Second, 0))
+		require.NoError(t, NewController(mgr.Manager, 5, 0, time.Second, 0))
 		mgr.Start(t)
 
 		testChaos(t, mgr)
@@ -318,8 +318,8 @@ func TestChaos(t *testing.T) {
 	// Run the same test but with another controller competing for the same resources
 	t.Run("zombie leader", func(t *testing.T) {
 		mgr := testutil.NewManager(t)
-		require.NoError(t, NewController(mgr.Manager, 5, time.Second, 0))
-		require.NoError(t, NewController(mgr.Manager, 5, time.Second, 0))
+		require.NoError(t, NewController(mgr.Manager, 5, 0, time.Second, 0))
+		require.NoError(t, NewController(mgr.Manager, 5, 0, time.Second, 0))
 		mgr.Start(t)
 
 		testChaos(t, mgr)
@@ -614,7 +614,7 @@ func TestIndexSynthesizersEpoch(t *testing.T) {
 func TestRetries(t *testing.T) {
 	ctx := testutil.NewContext(t)
 	mgr := testutil.NewManager(t)
-	require.NoError(t, NewController(mgr.Manager, 100, 2*time.Second, 0))
+	require.NoError(t, NewController(mgr.Manager, 100, 0, 2*time.Second, 0))
 	mgr.Start(t)
 	cli := mgr.GetClient()
 
@@ -671,7 +671,7 @@ func TestRetries(t *testing.T) {
 func TestRetryContention(t *testing.T) {
 	ctx := testutil.NewContext(t)
 	mgr := testutil.NewManager(t)
-	require.NoError(t, NewController(mgr.Manager, 1, 2*time.Second, 0))
+	require.NoError(t, NewController(mgr.Manager, 1, 0, 2*time.Second, 0))
 	mgr.Start(t)
 	cli := mgr.GetClient()
 
@@ -715,3 +715,149 @@ func TestRetryContention(t *testing.T) {
 		return err == nil && secondComp.Status.InFlightSynthesis != nil
 	})
 }
+
+// TestNamespaceConcurrencyLimit proves that concurrent syntheses are capped per composition namespace.
+func TestNamespaceConcurrencyLimit(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	mgr := testutil.NewManager(t)
+	require.NoError(t, NewController(mgr.Manager, 100, 1, 2*time.Second, 0))
+	mgr.Start(t)
+	cli := mgr.GetClient()
+
+	synth := &apiv1.Synthesizer{}
+	synth.Name = "test-synth"
+	synth.Namespace = "default"
+	require.NoError(t, cli.Create(ctx, synth))
+
+	comps := []*apiv1.Composition{}
+	for i := range 3 {
+		comp := &apiv1.Composition{}
+		comp.Name = fmt.Sprintf("test-comp-%d", i)
+		comp.Namespace = "default"
+		comp.Finalizers = []string{"eno.azure.io/cleanup"}
+		comp.Spec.Synthesizer.Name = synth.Name
+		require.NoError(t, cli.Create(ctx, comp))
+		comps = append(comps, comp)
+	}
+
+	// Complete syntheses one at a time - the cap should never admit more than one concurrently
+	for range comps {
+		var active *apiv1.Composition
+		testutil.Eventually(t, func() bool {
+			count := 0
+			active = nil
+			for _, comp := range comps {
+				if err := cli.Get(ctx, client.ObjectKeyFromObject(comp), comp); err != nil {
+					return false
+				}
+				if comp.Synthesizing() {
+					count++
+					active = comp
+				}
+			}
+			assert.LessOrEqual(t, count, 1)
+			return count == 1
+		})
+
+		err := retry.RetryOnConflict(testutil.Backoff, func() error {
+			cli.Get(ctx, client.ObjectKeyFromObject(active), active)
+			active.Status.InFlightSynthesis.Synthesized = ptr.To(metav1.Now())
+			active.Status.CurrentSynthesis = active.Status.InFlightSynthesis
+			active.Status.InFlightSynthesis = nil
+			return cli.Status().Update(ctx, active)
+		})
+		require.NoError(t, err)
+	}
+
+	// Every composition is eventually synthesized
+	testutil.Eventually(t, func() bool {
+		for _, comp := range comps {
+			if err := cli.Get(ctx, client.ObjectKeyFromObject(comp), comp); err != nil || comp.Status.CurrentSynthesis == nil {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// TestSynthesizerMaxInFlight proves that a synthesizer's cap limits its own syntheses
+// without blocking other synthesizers' compositions.
+func TestSynthesizerMaxInFlight(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	mgr := testutil.NewManager(t)
+	require.NoError(t, NewController(mgr.Manager, 100, 0, 2*time.Second, 0))
+	mgr.Start(t)
+	cli := mgr.GetClient()
+
+	cappedSynth := &apiv1.Synthesizer{}
+	cappedSynth.Name = "capped-synth"
+	cappedSynth.Spec.MaxInFlight = ptr.To(1)
+	require.NoError(t, cli.Create(ctx, cappedSynth))
+
+	otherSynth := &apiv1.Synthesizer{}
+	otherSynth.Name = "other-synth"
+	require.NoError(t, cli.Create(ctx, otherSynth))
+
+	comps := []*apiv1.Composition{}
+	for i := range 2 {
+		comp := &apiv1.Composition{}
+		comp.Name = fmt.Sprintf("capped-comp-%d", i)
+		comp.Namespace = "default"
+		comp.Finalizers = []string{"eno.azure.io/cleanup"}
+		comp.Spec.Synthesizer.Name = cappedSynth.Name
+		require.NoError(t, cli.Create(ctx, comp))
+		comps = append(comps, comp)
+	}
+
+	otherComp := &apiv1.Composition{}
+	otherComp.Name = "other-comp"
+	otherComp.Namespace = "default"
+	otherComp.Finalizers = []string{"eno.azure.io/cleanup"}
+	otherComp.Spec.Synthesizer.Name = otherSynth.Name
+	require.NoError(t, cli.Create(ctx, otherComp))
+
+	// The other synthesizer's composition is dispatched even while the capped
+	// synthesizer is saturated
+	var active *apiv1.Composition
+	testutil.Eventually(t, func() bool {
+		count := 0
+		active = nil
+		for _, comp := range comps {
+			if err := cli.Get(ctx, client.ObjectKeyFromObject(comp), comp); err != nil {
+				return false
+			}
+			if comp.Synthesizing() {
+				count++
+				active = comp
+			}
+		}
+		assert.LessOrEqual(t, count, 1)
+
+		if err := cli.Get(ctx, client.ObjectKeyFromObject(otherComp), otherComp); err != nil {
+			return false
+		}
+		return count == 1 && otherComp.Status.InFlightSynthesis != nil
+	})
+
+	// Completing the active synthesis frees the slot for the other capped composition
+	err := retry.RetryOnConflict(testutil.Backoff, func() error {
+		cli.Get(ctx, client.ObjectKeyFromObject(active), active)
+		active.Status.InFlightSynthesis.Synthesized = ptr.To(metav1.Now())
+		active.Status.CurrentSynthesis = active.Status.InFlightSynthesis
+		active.Status.InFlightSynthesis = nil
+		return cli.Status().Update(ctx, active)
+	})
+	require.NoError(t, err)
+
+	testutil.Eventually(t, func() bool {
+		for _, comp := range comps {
+			if err := cli.Get(ctx, client.ObjectKeyFromObject(comp), comp); err != nil {
+				return false
+			}
+			if comp.Status.CurrentSynthesis == nil && comp.Status.InFlightSynthesis == nil {
+				return false
+			}
+		}
+		return true
+	})
+}
@@ -0,0 +1,144 @@
+package scheduling
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/manager"
+)
+
+// indexCompositions indexes a list of compositions by namespaced name.
+func indexCompositions(comps []apiv1.Composition) map[types.NamespacedName]*apiv1.Composition {
+	byName := make(map[types.NamespacedName]*apiv1.Composition, len(comps))
+	for i := range comps {
+		comp := &comps[i]
+		byName[types.NamespacedName{Name: comp.Name, Namespace: comp.Namespace}] = comp
+	}
+	return byName
+}
+
+func dependencyKey(comp *apiv1.Composition, ref apiv1.ObjectRef) types.NamespacedName {
+	ns := ref.Namespace
+	if ns == "" {
+		ns = comp.Namespace
+	}
+	return types.NamespacedName{Name: ref.Name, Namespace: ns}
+}
+
+// dependencyState reports whether synthesis of the composition should wait for
+// its spec.dependsOn references. waiting names the dependencies that haven't
+// been reconciled yet, including ones that don't exist. Cyclic dependency
+// graphs never block - deadlocking would be worse than synthesizing out of
+// order - and are reported through the cycle flag instead.
+func dependencyState(comp *apiv1.Composition, byName map[types.NamespacedName]*apiv1.Composition) (blocked, cycle bool, waiting []string) {
+	if len(comp.Spec.DependsOn) == 0 {
+		return false, false, nil
+	}
+	if hasDependencyCycle(comp, byName, map[types.NamespacedName]struct{}{}) {
+		return false, true, nil
+	}
+	for _, ref := range comp.Spec.DependsOn {
+		key := dependencyKey(comp, ref)
+		dep, ok := byName[key]
+		if ok && dep.Status.CurrentSynthesis != nil && dep.Status.CurrentSynthesis.Reconciled != nil {
+			continue
+		}
+		waiting = append(waiting, key.String())
+	}
+	return len(waiting) > 0, false, waiting
+}
+
+func hasDependencyCycle(comp *apiv1.Composition, byName map[types.NamespacedName]*apiv1.Composition, path map[types.NamespacedName]struct{}) bool {
+	key := types.NamespacedName{Name: comp.Name, Namespace: comp.Namespace}
+	if _, ok := path[key]; ok {
+		return true
+	}
+	path[key] = struct{}{}
+	defer delete(path, key)
+	for _, ref := range comp.Spec.DependsOn {
+		if dep, ok := byName[dependencyKey(comp, ref)]; ok && hasDependencyCycle(dep, byName, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// dependenciesConditionController maintains the DependenciesReady condition on
+// compositions that declare spec.dependsOn, so it's clear why a composition
+// hasn't been synthesized yet.
+type dependenciesConditionController struct {
+	client client.Client
+}
+
+func NewDependenciesConditionController(mgr ctrl.Manager) error {
+	c := &dependenciesConditionController{client: mgr.GetClient()}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1.Composition{}).
+		WithLogConstructor(manager.NewLogConstructor(mgr, "dependenciesConditionController")).
+		Complete(c)
+}
+
+func (c *dependenciesConditionController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	comp := &apiv1.Composition{}
+	err := c.client.Get(ctx, req.NamespacedName, comp)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if comp.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if len(comp.Spec.DependsOn) == 0 {
+		if !meta.RemoveStatusCondition(&comp.Status.Conditions, apiv1.ConditionDependenciesReady) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, c.client.Status().Update(ctx, comp)
+	}
+
+	comps := &apiv1.CompositionList{}
+	err = c.client.List(ctx, comps)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing compositions: %w", err)
+	}
+
+	blocked, cycle, waiting := dependencyState(comp, indexCompositions(comps.Items))
+	cond := metav1.Condition{
+		Type:               apiv1.ConditionDependenciesReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             "DependenciesReconciled",
+		Message:            "Every dependency has been reconciled",
+		ObservedGeneration: comp.Generation,
+	}
+	switch {
+	case cycle:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "DependencyCycle"
+		cond.Message = "The dependency graph contains a cycle - synthesis proceeds without waiting"
+	case blocked:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "WaitingForDependencies"
+		cond.Message = fmt.Sprintf("Waiting for dependencies to be reconciled: %s", strings.Join(waiting, ", "))
+	}
+
+	if !meta.SetStatusCondition(&comp.Status.Conditions, cond) {
+		return ctrl.Result{}, nil
+	}
+	err = c.client.Status().Update(ctx, comp)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("updating composition status: %w", err)
+	}
+
+	logger.V(1).Info("updated composition dependencies condition", "conditionStatus", cond.Status, "conditionReason", cond.Reason)
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,151 @@
+package scheduling
+
+import (
+	"testing"
+	"time"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newDependencyTestComposition(name string, deps ...apiv1.ObjectRef) *apiv1.Composition {
+	comp := &apiv1.Composition{}
+	comp.Name = name
+	comp.Namespace = "default"
+	comp.Finalizers = []string{"eno.azure.io/cleanup"}
+	comp.Spec.Synthesizer.Name = "test-synth"
+	comp.Spec.DependsOn = deps
+	return comp
+}
+
+func TestDependencyState(t *testing.T) {
+	now := metav1.Now()
+	reconciled := newDependencyTestComposition("reconciled")
+	reconciled.Status.CurrentSynthesis = &apiv1.Synthesis{UUID: "a", Reconciled: &now}
+	pending := newDependencyTestComposition("pending")
+	pending.Status.CurrentSynthesis = &apiv1.Synthesis{UUID: "b"}
+
+	tests := []struct {
+		Name    string
+		Comp    *apiv1.Composition
+		Blocked bool
+		Cycle   bool
+	}{
+		{
+			Name: "no dependencies",
+			Comp: newDependencyTestComposition("comp"),
+		},
+		{
+			Name: "reconciled dependency",
+			Comp: newDependencyTestComposition("comp", apiv1.ObjectRef{Name: "reconciled"}),
+		},
+		{
+			Name:    "unreconciled dependency",
+			Comp:    newDependencyTestComposition("comp", apiv1.ObjectRef{Name: "pending"}),
+			Blocked: true,
+		},
+		{
+			Name:    "missing dependency",
+			Comp:    newDependencyTestComposition("comp", apiv1.ObjectRef{Name: "missing"}),
+			Blocked: true,
+		},
+		{
+			Name:  "self cycle",
+			Comp:  newDependencyTestComposition("comp", apiv1.ObjectRef{Name: "comp"}),
+			Cycle: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			byName := indexCompositions([]apiv1.Composition{*reconciled, *pending, *tt.Comp})
+			blocked, cycle, _ := dependencyState(tt.Comp, byName)
+			assert.Equal(t, tt.Blocked, blocked)
+			assert.Equal(t, tt.Cycle, cycle)
+		})
+	}
+
+	// Transitive cycles are detected through intermediate compositions
+	a := newDependencyTestComposition("a", apiv1.ObjectRef{Name: "b"})
+	b := newDependencyTestComposition("b", apiv1.ObjectRef{Name: "a"})
+	byName := indexCompositions([]apiv1.Composition{*a, *b})
+	blocked, cycle, _ := dependencyState(a, byName)
+	assert.False(t, blocked)
+	assert.True(t, cycle)
+}
+
+func TestDependenciesCondition(t *testing.T) {
+	ctx := testutil.NewContext(t)
+
+	dep := newDependencyTestComposition("dep")
+	comp := newDependencyTestComposition("comp", apiv1.ObjectRef{Name: "dep"})
+	cli := testutil.NewClient(t, dep, comp)
+	c := &dependenciesConditionController{client: cli}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(comp)}
+
+	// The dependency hasn't been reconciled yet
+	_, err := c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, req.NamespacedName, comp))
+	cond := meta.FindStatusCondition(comp.Status.Conditions, apiv1.ConditionDependenciesReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, "WaitingForDependencies", cond.Reason)
+
+	// The dependency becomes reconciled
+	now := metav1.Now()
+	dep.Status.CurrentSynthesis = &apiv1.Synthesis{UUID: "a", Reconciled: &now}
+	require.NoError(t, cli.Status().Update(ctx, dep))
+	_, err = c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, req.NamespacedName, comp))
+	cond = meta.FindStatusCondition(comp.Status.Conditions, apiv1.ConditionDependenciesReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+
+	// Cycles are reported instead of deadlocking
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(dep), dep))
+	dep.Spec.DependsOn = []apiv1.ObjectRef{{Name: "comp"}}
+	require.NoError(t, cli.Update(ctx, dep))
+	_, err = c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, req.NamespacedName, comp))
+	cond = meta.FindStatusCondition(comp.Status.Conditions, apiv1.ConditionDependenciesReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, "DependencyCycle", cond.Reason)
+
+	// Dropping the dependencies removes the condition
+	require.NoError(t, cli.Get(ctx, req.NamespacedName, comp))
+	comp.Spec.DependsOn = nil
+	require.NoError(t, cli.Update(ctx, comp))
+	_, err = c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, req.NamespacedName, comp))
+	assert.Nil(t, meta.FindStatusCondition(comp.Status.Conditions, apiv1.ConditionDependenciesReady))
+}
+
+func TestDependenciesBlockDispatch(t *testing.T) {
+	ctx := testutil.NewContext(t)
+
+	synth := &apiv1.Synthesizer{}
+	synth.Name = "test-synth"
+
+	dep := newDependencyTestComposition("dep")
+	dep.Status.InputRevisions = []apiv1.InputRevisions{{Key: "foo", ResourceVersion: "1"}}
+	comp := newDependencyTestComposition("comp", apiv1.ObjectRef{Name: "dep"})
+	comp.Status.InputRevisions = []apiv1.InputRevisions{{Key: "foo", ResourceVersion: "1"}}
+	cli := testutil.NewClient(t, synth, dep, comp)
+	c := &controller{client: cli, concurrencyLimit: 2, cacheGracePeriod: time.Millisecond}
+
+	// Only the dependency is eligible for synthesis
+	_, err := c.Reconcile(ctx, ctrl.Request{})
+	require.NoError(t, err)
+	require.NotNil(t, c.lastApplied)
+	assert.Equal(t, "dep", c.lastApplied.Composition.Name)
+}
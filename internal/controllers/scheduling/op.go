@@ -39,9 +39,11 @@ func newOp(synth *apiv1.Synthesizer, comp *apiv1.Composition, nextCooldownSlot t
 		}
 
 		// Retry canceled syntheses with exponential backoff
-		backoff := time.Duration(float64(syn.Canceled.Sub(syn.Initialized.Time)) * float64(syn.Attempts) * 1.5)
+		if p := synth.Spec.RetryPolicy; p != nil && p.MaxAttempts > 0 && syn.Attempts >= p.MaxAttempts {
+			return nil // retries exhausted
+		}
 		o.Reason = retrySynthesisOp
-		o.NotBefore = syn.Canceled.Add(backoff)
+		o.NotBefore = syn.Canceled.Add(retryBackoff(synth, syn))
 		return o
 	}
 
@@ -58,6 +60,28 @@ func newOp(synth *apiv1.Synthesizer, comp *apiv1.Composition, nextCooldownSlot t
 	return o
 }
 
+// retryBackoff returns the delay before a canceled synthesis may be retried.
+// Synthesizers can configure an explicit exponential backoff; when they don't,
+// the delay scales with how long the failed attempt ran.
+func retryBackoff(synth *apiv1.Synthesizer, syn *apiv1.Synthesis) time.Duration {
+	p := synth.Spec.RetryPolicy
+	if p == nil || p.BackoffBase == nil {
+		return time.Duration(float64(syn.Canceled.Sub(syn.Initialized.Time)) * float64(syn.Attempts) * 1.5)
+	}
+
+	backoff := p.BackoffBase.Duration
+	for i := 1; i < syn.Attempts; i++ {
+		backoff *= 2
+		if p.BackoffMax != nil && backoff >= p.BackoffMax.Duration {
+			break
+		}
+	}
+	if p.BackoffMax != nil && backoff > p.BackoffMax.Duration {
+		backoff = p.BackoffMax.Duration
+	}
+	return backoff
+}
+
 func classifyOp(synth *apiv1.Synthesizer, comp *apiv1.Composition) (opReason, bool) {
 	switch {
 	case comp.DeletionTimestamp != nil || !comp.InputsExist(synth) || comp.InputsOutOfLockstep(synth) || !controllerutil.ContainsFinalizer(comp, "eno.azure.io/cleanup"):
@@ -69,6 +93,9 @@ func classifyOp(synth *apiv1.Synthesizer, comp *apiv1.Composition) (opReason, bo
 	case comp.ShouldForceResynthesis():
 		return forcedResynthesisOp, true
 
+	case comp.NotEligibleForResynthesis():
+		return 0, false
+
 	case compositionHasBeenModified(comp):
 		return compositionModifiedOp, true
 
@@ -81,7 +108,11 @@ func classifyOp(synth *apiv1.Synthesizer, comp *apiv1.Composition) (opReason, bo
 		syn = comp.Status.InFlightSynthesis
 	}
 
-	nonDeferredInputChanges, deferredInputChanges := inputChangeCount(synth, comp.Status.InputRevisions, syn.InputRevisions)
+	nonDeferredInputChanges, deferredInputChanges := inputChangeCount(synth, comp, comp.Status.InputRevisions, syn.InputRevisions)
+	if comp.ManualResynthesisOnly() {
+		// Input revisions are still tracked, but only forced resynthesis acts on them
+		nonDeferredInputChanges, deferredInputChanges = 0, 0
+	}
 	if nonDeferredInputChanges > 0 {
 		return inputModifiedOp, true
 	}
@@ -94,6 +125,12 @@ func classifyOp(synth *apiv1.Synthesizer, comp *apiv1.Composition) (opReason, bo
 		return synthesizerModifiedOp, true
 	}
 
+	// Periodic resyntheses rank below every other reason so input-driven changes
+	// always win the remaining concurrency budget
+	if interval := comp.ResynthesisInterval(); interval > 0 && syn.Synthesized != nil && time.Since(syn.Synthesized.Time) >= interval {
+		return periodicResynthesisOp, true
+	}
+
 	return 0, false
 }
 
@@ -200,6 +237,7 @@ func (o *op) BuildPatch() any {
 			"uuid":                          o.id.String(),
 			"deferred":                      o.Reason.Deferred(),
 			"attempts":                      attempts + 1,
+			"lastAttempt":                   time.Now().Format(time.RFC3339),
 		},
 	})
 
@@ -221,12 +259,15 @@ const (
 	inputModifiedOp
 	deferredInputModifiedOp
 	synthesizerModifiedOp
+	periodicResynthesisOp
 	retrySynthesisOp
 )
 
-var allReasons = []opReason{initialSynthesisOp, forcedResynthesisOp, compositionModifiedOp, inputModifiedOp, deferredInputModifiedOp, synthesizerModifiedOp}
+var allReasons = []opReason{initialSynthesisOp, forcedResynthesisOp, compositionModifiedOp, inputModifiedOp, deferredInputModifiedOp, synthesizerModifiedOp, periodicResynthesisOp}
 
-func (r opReason) Deferred() bool { return r == deferredInputModifiedOp || r == synthesizerModifiedOp }
+func (r opReason) Deferred() bool {
+	return r == deferredInputModifiedOp || r == synthesizerModifiedOp || r == periodicResynthesisOp
+}
 
 func (r opReason) String() string {
 	switch r {
@@ -242,6 +283,8 @@ func (r opReason) String() string {
 		return "DeferredInputModified"
 	case synthesizerModifiedOp:
 		return "SynthesizerModified"
+	case periodicResynthesisOp:
+		return "PeriodicResynthesis"
 	case retrySynthesisOp:
 		return "Retry"
 	default:
@@ -249,9 +292,9 @@ func (r opReason) String() string {
 	}
 }
 
-func inputChangeCount(synth *apiv1.Synthesizer, a, b []apiv1.InputRevisions) (nonDeferred, deferred int) {
+func inputChangeCount(synth *apiv1.Synthesizer, comp *apiv1.Composition, a, b []apiv1.InputRevisions) (nonDeferred, deferred int) {
 	refsByKey := map[string]apiv1.Ref{}
-	for _, ref := range synth.Spec.Refs {
+	for _, ref := range comp.EffectiveRefs(synth) {
 		ref := ref
 		refsByKey[ref.Key] = ref
 	}
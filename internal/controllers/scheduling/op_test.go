@@ -242,7 +242,7 @@ func TestFuzzInputChangeCount(t *testing.T) {
 			synth.Spec.Refs = append(synth.Spec.Refs, apiv1.Ref{Key: strconv.Itoa(rand.Intn(10)), Defer: rand.Intn(2) == 0})
 		}
 
-		nonDeferred, deferred := inputChangeCount(synth, a, b)
+		nonDeferred, deferred := inputChangeCount(synth, &apiv1.Composition{}, a, b)
 
 		// No refs means no possible input changes
 		if len(synth.Spec.Refs) == 0 {
@@ -461,3 +461,265 @@ func TestOpNewerInputInSynthesis(t *testing.T) {
 
 	assert.Nil(t, newOp(synth, comp, time.Time{}))
 }
+
+func TestOpOneShotIgnoresInputDrift(t *testing.T) {
+	synth := &apiv1.Synthesizer{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-synth", Generation: 1},
+		Spec: apiv1.SynthesizerSpec{
+			Refs: []apiv1.Ref{{Key: "foo"}},
+		},
+	}
+
+	comp := &apiv1.Composition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-comp",
+			Finalizers:  []string{"eno.azure.io/cleanup"},
+			Generation:  1,
+			Annotations: map[string]string{"eno.azure.io/one-shot": "true"},
+		},
+		Spec: apiv1.CompositionSpec{
+			Bindings: []apiv1.Binding{
+				{Key: "foo", Resource: apiv1.ResourceBinding{Name: "foo"}},
+			},
+		},
+		Status: apiv1.CompositionStatus{
+			CurrentSynthesis: &apiv1.Synthesis{
+				ObservedCompositionGeneration: 1,
+				ObservedSynthesizerGeneration: 1,
+				Synthesized:                   ptr.To(metav1.Now()),
+				Reconciled:                    ptr.To(metav1.Now()),
+				Ready:                         ptr.To(metav1.Now()),
+				UUID:                          "initial-uuid",
+				InputRevisions: []apiv1.InputRevisions{
+					{Key: "foo", ResourceVersion: "1"},
+				},
+			},
+			InputRevisions: []apiv1.InputRevisions{
+				{Key: "foo", ResourceVersion: "2"},
+			},
+		},
+	}
+
+	// Input drift is ignored once the one-shot composition has become ready
+	assert.Nil(t, newOp(synth, comp, time.Time{}))
+
+	// Forcing resynthesis makes it eligible again
+	comp.ForceResynthesis()
+	op := newOp(synth, comp, time.Time{})
+	require.NotNil(t, op)
+	assert.Equal(t, forcedResynthesisOp, op.Reason)
+}
+
+func TestOpPeriodicResynthesisPriority(t *testing.T) {
+	synth := &apiv1.Synthesizer{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-synth", Generation: 1},
+		Spec: apiv1.SynthesizerSpec{
+			Refs: []apiv1.Ref{{Key: "foo"}},
+		},
+	}
+
+	newComp := func(name string) *apiv1.Composition {
+		return &apiv1.Composition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				UID:        types.UID(name),
+				Finalizers: []string{"eno.azure.io/cleanup"},
+				Generation: 1,
+			},
+			Spec: apiv1.CompositionSpec{
+				Bindings: []apiv1.Binding{
+					{Key: "foo", Resource: apiv1.ResourceBinding{Name: "foo"}},
+				},
+			},
+			Status: apiv1.CompositionStatus{
+				CurrentSynthesis: &apiv1.Synthesis{
+					ObservedCompositionGeneration: 1,
+					ObservedSynthesizerGeneration: 1,
+					Synthesized:                   ptr.To(metav1.NewTime(time.Now().Add(-time.Hour))),
+					UUID:                          "initial-uuid",
+					InputRevisions: []apiv1.InputRevisions{
+						{Key: "foo", ResourceVersion: "1"},
+					},
+				},
+				InputRevisions: []apiv1.InputRevisions{
+					{Key: "foo", ResourceVersion: "1"},
+				},
+			},
+		}
+	}
+
+	// A composition past its resynthesis interval produces a periodic op
+	periodic := newComp("periodic")
+	periodic.Annotations = map[string]string{"eno.azure.io/resynthesis-interval": "30m"}
+	periodicOp := newOp(synth, periodic, time.Time{})
+	require.NotNil(t, periodicOp)
+	assert.Equal(t, periodicResynthesisOp, periodicOp.Reason)
+
+	// A composition within its interval does not
+	fresh := newComp("fresh")
+	fresh.Annotations = map[string]string{"eno.azure.io/resynthesis-interval": "2h"}
+	assert.Nil(t, newOp(synth, fresh, time.Time{}))
+
+	// Input drift is dispatched ahead of periodic resynthesis
+	drifted := newComp("drifted")
+	drifted.Status.InputRevisions[0].ResourceVersion = "2"
+	driftedOp := newOp(synth, drifted, time.Time{})
+	require.NotNil(t, driftedOp)
+	assert.Equal(t, inputModifiedOp, driftedOp.Reason)
+
+	assert.True(t, driftedOp.Less(periodicOp))
+	assert.False(t, periodicOp.Less(driftedOp))
+}
+
+func TestOpProjectedInputDrift(t *testing.T) {
+	synth := &apiv1.Synthesizer{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-synth", Generation: 1},
+		Spec: apiv1.SynthesizerSpec{
+			Refs: []apiv1.Ref{{Key: "foo"}},
+		},
+	}
+
+	comp := &apiv1.Composition{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-comp", Finalizers: []string{"eno.azure.io/cleanup"}, Generation: 1},
+		Spec: apiv1.CompositionSpec{
+			Bindings: []apiv1.Binding{
+				{Key: "foo", Resource: apiv1.ResourceBinding{Name: "foo"}, Projection: []string{"data.bar"}},
+			},
+		},
+		Status: apiv1.CompositionStatus{
+			CurrentSynthesis: &apiv1.Synthesis{
+				ObservedCompositionGeneration: 1,
+				ObservedSynthesizerGeneration: 1,
+				Synthesized:                   ptr.To(metav1.Now()),
+				UUID:                          "initial-uuid",
+				InputRevisions: []apiv1.InputRevisions{
+					{Key: "foo", ResourceVersion: "1", ProjectedHash: "abc"},
+				},
+			},
+			InputRevisions: []apiv1.InputRevisions{
+				{Key: "foo", ResourceVersion: "2", ProjectedHash: "abc"},
+			},
+		},
+	}
+
+	// Writes to unprojected fields bump the resource version but not the hash
+	assert.Nil(t, newOp(synth, comp, time.Time{}))
+
+	// Writes to projected fields change the hash and trigger resynthesis
+	comp.Status.InputRevisions[0].ProjectedHash = "def"
+	op := newOp(synth, comp, time.Time{})
+	require.NotNil(t, op)
+	assert.Equal(t, inputModifiedOp, op.Reason)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	canceled := metav1.NewTime(time.Unix(1000, 0))
+	initialized := metav1.NewTime(canceled.Add(-10 * time.Second))
+
+	newSynthesis := func(attempts int) *apiv1.Synthesis {
+		return &apiv1.Synthesis{Initialized: &initialized, Canceled: &canceled, Attempts: attempts}
+	}
+
+	// Without a retry policy the backoff scales with the attempt's duration
+	synth := &apiv1.Synthesizer{}
+	assert.Equal(t, 15*time.Second, retryBackoff(synth, newSynthesis(1)))
+	assert.Equal(t, 30*time.Second, retryBackoff(synth, newSynthesis(2)))
+
+	// A configured base doubles with each attempt
+	synth.Spec.RetryPolicy = &apiv1.RetryPolicy{
+		BackoffBase: &metav1.Duration{Duration: time.Second},
+	}
+	assert.Equal(t, time.Second, retryBackoff(synth, newSynthesis(1)))
+	assert.Equal(t, 2*time.Second, retryBackoff(synth, newSynthesis(2)))
+	assert.Equal(t, 8*time.Second, retryBackoff(synth, newSynthesis(4)))
+
+	// The max caps the delay
+	synth.Spec.RetryPolicy.BackoffMax = &metav1.Duration{Duration: 5 * time.Second}
+	assert.Equal(t, 5*time.Second, retryBackoff(synth, newSynthesis(4)))
+	assert.Equal(t, 5*time.Second, retryBackoff(synth, newSynthesis(100))) // doesn't overflow
+
+	// Setting only maxAttempts preserves the default backoff
+	synth.Spec.RetryPolicy = &apiv1.RetryPolicy{MaxAttempts: 3}
+	assert.Equal(t, 15*time.Second, retryBackoff(synth, newSynthesis(1)))
+}
+
+func TestOpRetryPolicyMaxAttempts(t *testing.T) {
+	synth := &apiv1.Synthesizer{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-synth", Generation: 1},
+		Spec: apiv1.SynthesizerSpec{
+			RetryPolicy: &apiv1.RetryPolicy{MaxAttempts: 3},
+		},
+	}
+
+	comp := &apiv1.Composition{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-comp", Finalizers: []string{"eno.azure.io/cleanup"}, Generation: 1},
+		Status: apiv1.CompositionStatus{
+			InFlightSynthesis: &apiv1.Synthesis{
+				ObservedCompositionGeneration: 1,
+				ObservedSynthesizerGeneration: 1,
+				Initialized:                   ptr.To(metav1.NewTime(time.Now().Add(-time.Minute))),
+				Canceled:                      ptr.To(metav1.Now()),
+				UUID:                          "initial-uuid",
+				Attempts:                      2,
+			},
+		},
+	}
+
+	// Attempts below the cap are retried
+	op := newOp(synth, comp, time.Time{})
+	require.NotNil(t, op)
+	assert.Equal(t, retrySynthesisOp, op.Reason)
+
+	// The cap stops further retries
+	comp.Status.InFlightSynthesis.Attempts = 3
+	assert.Nil(t, newOp(synth, comp, time.Time{}))
+}
+
+func TestOpManualResynthesisPolicy(t *testing.T) {
+	synth := &apiv1.Synthesizer{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-synth", Generation: 1},
+		Spec: apiv1.SynthesizerSpec{
+			Refs: []apiv1.Ref{{Key: "foo"}},
+		},
+	}
+
+	comp := &apiv1.Composition{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-comp", Finalizers: []string{"eno.azure.io/cleanup"}, Generation: 1},
+		Spec: apiv1.CompositionSpec{
+			ResynthesisPolicy: apiv1.ResynthesisPolicyManual,
+			Bindings: []apiv1.Binding{
+				{Key: "foo", Resource: apiv1.ResourceBinding{Name: "foo"}},
+			},
+		},
+		Status: apiv1.CompositionStatus{
+			CurrentSynthesis: &apiv1.Synthesis{
+				ObservedCompositionGeneration: 1,
+				ObservedSynthesizerGeneration: 1,
+				Synthesized:                   ptr.To(metav1.Now()),
+				UUID:                          "initial-uuid",
+				InputRevisions: []apiv1.InputRevisions{
+					{Key: "foo", ResourceVersion: "1"},
+				},
+			},
+			InputRevisions: []apiv1.InputRevisions{
+				{Key: "foo", ResourceVersion: "2"},
+			},
+		},
+	}
+
+	// Input drift doesn't trigger resynthesis under the manual policy
+	assert.Nil(t, newOp(synth, comp, time.Time{}))
+
+	// The default policy preserves today's behavior
+	comp.Spec.ResynthesisPolicy = ""
+	op := newOp(synth, comp, time.Time{})
+	require.NotNil(t, op)
+	assert.Equal(t, inputModifiedOp, op.Reason)
+
+	// Forcing resynthesis still works under the manual policy
+	comp.Spec.ResynthesisPolicy = apiv1.ResynthesisPolicyManual
+	comp.ForceResynthesis()
+	op = newOp(synth, comp, time.Time{})
+	require.NotNil(t, op)
+	assert.Equal(t, forcedResynthesisOp, op.Reason)
+}
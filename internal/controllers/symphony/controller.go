@@ -3,8 +3,12 @@ package symphony
 import (
 	"context"
 	"fmt"
+	"maps"
 	"slices"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	apiv1 "github.com/Azure/eno/api/v1"
 	"github.com/Azure/eno/internal/manager"
@@ -18,6 +22,23 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+// rolloutUpdateTimeAnnotation records when a composition was last updated as part
+// of a gradual rollout so stalled batches can be detected.
+const rolloutUpdateTimeAnnotation = "eno.azure.io/rollout-update-time"
+
+// variationLabelKey is stamped onto compositions created by the symphony
+// controller so hand-created compositions that happen to reference the same
+// synthesizer can be told apart from the symphony's own.
+const variationLabelKey = "eno.azure.io/symphony-variation"
+
+// replicaIndexLabelKey distinguishes the compositions of a replicated variation
+// from each other. The same index is passed to the synthesizer through the
+// ENO_REPLICA_INDEX env var so each replica can shard its work.
+const (
+	replicaIndexLabelKey = "eno.azure.io/replica-index"
+	replicaIndexEnvName  = "ENO_REPLICA_INDEX"
+)
+
 type symphonyController struct {
 	client        client.Client
 	noCacheClient client.Reader
@@ -106,16 +127,28 @@ func (c *symphonyController) Reconcile(ctx context.Context, req ctrl.Request) (c
 func (c *symphonyController) reconcileReverse(ctx context.Context, symph *apiv1.Symphony, comps *apiv1.CompositionList) (bool, error) {
 	logger := logr.FromContextOrDiscard(ctx)
 
+	// Deletion still proceeds while paused - otherwise the finalizer could never be removed
+	if symph.Spec.Paused && symph.DeletionTimestamp == nil {
+		logger.V(1).Info("skipping reverse reconciliation because the symphony is paused")
+		return false, nil
+	}
+
 	expectedSynths := map[string]struct{}{}
 	for _, variation := range symph.Spec.Variations {
 		expectedSynths[variation.Synthesizer.Name] = struct{}{}
 	}
 
-	// Delete compositions when their synth has been removed from the symphony
-	existingBySynthName := map[string][]*apiv1.Composition{}
+	// Delete compositions when their synth has been removed from the symphony.
+	// Replicas of the same variation are intentional, not duplicates, so the
+	// grouping key includes the replica index.
+	existingByReplica := map[string][]*apiv1.Composition{}
 	for _, comp := range comps.Items {
 		comp := comp
-		existingBySynthName[comp.Spec.Synthesizer.Name] = append(existingBySynthName[comp.Spec.Synthesizer.Name], &comp)
+		if !ownedBySymphony(symph, &comp) {
+			continue // hand-created composition - not ours to manage
+		}
+		key := fmt.Sprintf("%s/%d", comp.Spec.Synthesizer.Name, compReplicaIndex(&comp))
+		existingByReplica[key] = append(existingByReplica[key], &comp)
 
 		hasVariation := slices.ContainsFunc(symph.Spec.Variations, func(vrn apiv1.Variation) bool {
 			return vrn.Synthesizer.Name == comp.Spec.Synthesizer.Name
@@ -133,8 +166,33 @@ func (c *symphonyController) reconcileReverse(ctx context.Context, symph *apiv1.
 		return true, nil
 	}
 
+	// Scale down replicated variations, deleting the highest-index compositions first
+	for _, vrn := range symph.Spec.Variations {
+		var victim *apiv1.Composition
+		for _, comp := range comps.Items {
+			comp := comp
+			if !ownedBySymphony(symph, &comp) || !matchesVariation(symph, &comp, &vrn) || comp.DeletionTimestamp != nil {
+				continue
+			}
+			if idx := compReplicaIndex(&comp); idx >= replicaCount(&vrn) && (victim == nil || idx > compReplicaIndex(victim)) {
+				victim = &comp
+			}
+		}
+		if victim == nil {
+			continue
+		}
+
+		err := c.client.Delete(ctx, victim)
+		if err != nil {
+			return false, fmt.Errorf("scaling down replicated variation: %w", err)
+		}
+
+		logger.V(0).Info("deleted composition because its variation was scaled down", "compositionName", victim.Name, "compositionNamespace", victim.Namespace)
+		return true, nil
+	}
+
 	// Delete any duplicates we may have created in the past - leave the oldest one
-	for _, comps := range existingBySynthName {
+	for _, comps := range existingByReplica {
 		if len(comps) < 2 {
 			continue
 		}
@@ -156,65 +214,121 @@ func (c *symphonyController) reconcileReverse(ctx context.Context, symph *apiv1.
 func (c *symphonyController) reconcileForward(ctx context.Context, symph *apiv1.Symphony, comps *apiv1.CompositionList) (modified bool, err error) {
 	logger := logr.FromContextOrDiscard(ctx)
 
+	if symph.Spec.Paused {
+		logger.V(1).Info("skipping forward reconciliation because the symphony is paused")
+		return false, nil
+	}
+
+	// Cyclic dependencies can never make progress - refuse to act on them.
+	// The cycle is surfaced through the symphony's status by syncStatus.
+	if cycle := findVariationCycle(symph); cycle != nil {
+		logger.V(0).Info("refusing to reconcile symphony because its variation dependencies form a cycle", "cycle", strings.Join(cycle, " -> "))
+		return false, nil
+	}
+
 	for _, variation := range symph.Spec.Variations {
 		variation := variation
-		comp := &apiv1.Composition{}
-		comp.Namespace = symph.Namespace
-		comp.GenerateName = variation.Synthesizer.Name + "-"
-		comp.Spec.Bindings = getBindings(symph, &variation)
-		comp.Spec.Synthesizer = variation.Synthesizer
-		comp.Spec.SynthesisEnv = getSynthesisEnv(symph, &variation)
-		comp.Labels = variation.Labels
-		comp.Annotations = variation.Annotations
-		err := controllerutil.SetControllerReference(symph, comp, c.client.Scheme())
-		if err != nil {
-			return false, fmt.Errorf("setting composition's controller: %w", err)
+		for replica := 0; replica < replicaCount(&variation); replica++ {
+			modified, err := c.reconcileVariationReplica(ctx, symph, &variation, replica, comps)
+			if modified || err != nil {
+				return modified, err
+			}
 		}
-		logger := logger.WithValues("compositionName", comp.Name, "compositionNamespace", comp.Namespace)
+	}
 
-		// Compose missing variations
-		idx := slices.IndexFunc(comps.Items, func(existing apiv1.Composition) bool {
-			return existing.Spec.Synthesizer.Name == variation.Synthesizer.Name
-		})
-		if idx == -1 {
-			err := c.noCacheClient.List(ctx, comps, client.InNamespace(symph.Namespace))
-			if err != nil {
-				return false, fmt.Errorf("listing existing compositions without cache: %w", err)
-			}
-			for _, cur := range comps.Items {
-				owner := metav1.GetControllerOf(&cur)
-				if owner != nil && owner.UID == symph.UID && cur.Spec.Synthesizer.Name == variation.Synthesizer.Name {
-					return false, fmt.Errorf("stale cache - composition already exists")
-				}
-			}
+	return false, nil
+}
 
-			err = c.client.Create(ctx, comp)
-			if k8serrors.IsForbidden(err) && k8serrors.HasStatusCause(err, corev1.NamespaceTerminatingCause) {
-				logger.V(0).Info("skipping composition creation because the namespace is being terminated")
-				return false, nil
-			}
-			if err != nil {
-				return false, fmt.Errorf("creating composition: %w", err)
+func (c *symphonyController) reconcileVariationReplica(ctx context.Context, symph *apiv1.Symphony, variation *apiv1.Variation, replica int, comps *apiv1.CompositionList) (modified bool, err error) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	comp := &apiv1.Composition{}
+	comp.Namespace = symph.Namespace
+	comp.GenerateName = variation.Synthesizer.Name + "-"
+	comp.Spec.Bindings = getBindings(symph, variation)
+	comp.Spec.Synthesizer = variation.Synthesizer
+	comp.Spec.SynthesisEnv = getSynthesisEnv(symph, variation)
+	comp.Labels = maps.Clone(variation.Labels)
+	if comp.Labels == nil {
+		comp.Labels = map[string]string{}
+	}
+	comp.Labels[variationLabelKey] = variation.Synthesizer.Name
+	if variation.Replicas != nil {
+		comp.Labels[replicaIndexLabelKey] = strconv.Itoa(replica)
+		comp.Spec.SynthesisEnv = append(comp.Spec.SynthesisEnv, apiv1.EnvVar{Name: replicaIndexEnvName, Value: strconv.Itoa(replica)})
+	}
+	comp.Annotations = variation.Annotations
+	err = controllerutil.SetControllerReference(symph, comp, c.client.Scheme())
+	if err != nil {
+		return false, fmt.Errorf("setting composition's controller: %w", err)
+	}
+	logger = logger.WithValues("compositionName", comp.Name, "compositionNamespace", comp.Namespace)
+
+	// Compose missing variations
+	idx := slices.IndexFunc(comps.Items, func(existing apiv1.Composition) bool {
+		return matchesVariation(symph, &existing, variation) && compReplicaIndex(&existing) == replica
+	})
+	if idx == -1 {
+		// Defer creation until every dependency's composition has been reconciled
+		if dep := unreconciledDependency(symph, variation, comps); dep != "" {
+			logger.V(1).Info("deferring composition creation until its dependencies are reconciled", "dependencySynthesizer", dep)
+			return false, nil
+		}
+
+		err := c.noCacheClient.List(ctx, comps, client.InNamespace(symph.Namespace))
+		if err != nil {
+			return false, fmt.Errorf("listing existing compositions without cache: %w", err)
+		}
+		for _, cur := range comps.Items {
+			owner := metav1.GetControllerOf(&cur)
+			if owner != nil && owner.UID == symph.UID && cur.Spec.Synthesizer.Name == variation.Synthesizer.Name && compReplicaIndex(&cur) == replica {
+				return false, fmt.Errorf("stale cache - composition already exists")
 			}
-			logger.V(0).Info("created composition for symphony")
-			return true, nil
 		}
 
-		// Diff and update if needed
-		existing := comps.Items[idx]
-		if equality.Semantic.DeepEqual(comp.Spec, existing.Spec) && !coalesceMetadata(&variation, &existing) {
-			continue // already matches
+		err = c.client.Create(ctx, comp)
+		if k8serrors.IsForbidden(err) && k8serrors.HasStatusCause(err, corev1.NamespaceTerminatingCause) {
+			logger.V(0).Info("skipping composition creation because the namespace is being terminated")
+			return false, nil
 		}
-		existing.Spec = comp.Spec
-		err = c.client.Update(ctx, &existing)
 		if err != nil {
-			return false, fmt.Errorf("updating existing composition: %w", err)
+			return false, fmt.Errorf("creating composition: %w", err)
 		}
-		logger.V(0).Info("updated composition because its variation changed")
+		logger.V(0).Info("created composition for symphony")
 		return true, nil
 	}
 
-	return false, nil
+	// Diff and update if needed
+	existing := comps.Items[idx]
+	labeled := existing.Labels[variationLabelKey] == variation.Synthesizer.Name
+	if equality.Semantic.DeepEqual(comp.Spec, existing.Spec) && !coalesceMetadata(variation, &existing) && labeled {
+		return false, nil // already matches
+	}
+	if rollout := symph.Spec.Rollout; rollout != nil {
+		if haltedBy := rolloutHaltedBy(rollout, comps, time.Now()); haltedBy != "" {
+			logger.V(0).Info("halting gradual rollout because a previous batch failed to reconcile in time", "haltedByComposition", haltedBy)
+			return false, nil
+		}
+		if pendingRollouts(comps) >= max(rollout.BatchSize, 1) {
+			logger.V(1).Info("waiting for the current rollout batch to be reconciled before continuing")
+			return false, nil
+		}
+		if existing.Annotations == nil {
+			existing.Annotations = map[string]string{}
+		}
+		existing.Annotations[rolloutUpdateTimeAnnotation] = time.Now().Format(time.RFC3339)
+	}
+	if existing.Labels == nil {
+		existing.Labels = map[string]string{}
+	}
+	existing.Labels[variationLabelKey] = variation.Synthesizer.Name
+	existing.Spec = comp.Spec
+	err = c.client.Update(ctx, &existing)
+	if err != nil {
+		return false, fmt.Errorf("updating existing composition: %w", err)
+	}
+	logger.V(0).Info("updated composition because its variation changed")
+	return true, nil
 }
 
 func (c *symphonyController) syncStatus(ctx context.Context, symph *apiv1.Symphony, comps *apiv1.CompositionList) error {
@@ -257,6 +371,11 @@ func (c *symphonyController) buildStatus(symph *apiv1.Symphony, comps *apiv1.Com
 		if newStatus.Synthesized.Before(syn.Synthesized) || newStatus.Synthesized == nil {
 			newStatus.Synthesized = syn.Synthesized
 		}
+
+		// Unlike the other timestamps, a single failed composition fails the symphony
+		if syn.Failed != nil && (newStatus.Failed == nil || syn.Failed.Before(newStatus.Failed)) {
+			newStatus.Failed = syn.Failed
+		}
 	}
 
 	// Status should be nil for any states that haven't been reached by all compositions
@@ -266,6 +385,7 @@ func (c *symphonyController) buildStatus(symph *apiv1.Symphony, comps *apiv1.Com
 
 		if synInvalid || syn.Ready == nil {
 			newStatus.Ready = nil
+			newStatus.NotReadySynthesizers = append(newStatus.NotReadySynthesizers, comp.Spec.Synthesizer.Name)
 		}
 		if synInvalid || syn.Reconciled == nil {
 			newStatus.Reconciled = nil
@@ -275,9 +395,102 @@ func (c *symphonyController) buildStatus(symph *apiv1.Symphony, comps *apiv1.Com
 		}
 	}
 
+	sort.Strings(newStatus.NotReadySynthesizers)
+	newStatus.NotReadySynthesizers = slices.Compact(newStatus.NotReadySynthesizers)
+
+	if cycle := findVariationCycle(symph); cycle != nil {
+		newStatus.VariationDependencyCycle = strings.Join(cycle, " -> ")
+	}
+
 	return newStatus
 }
 
+// findVariationCycle returns the synthesizer names along any cycle formed by the
+// variations' dependsOn references, or nil when the ordering is acyclic.
+// Cyclic dependencies can never be satisfied so the controller refuses to act on them.
+func findVariationCycle(symph *apiv1.Symphony) []string {
+	edges := map[string][]string{}
+	for _, vrn := range symph.Spec.Variations {
+		if len(vrn.DependsOn) == 0 {
+			continue
+		}
+		edges[vrn.Synthesizer.Name] = append(edges[vrn.Synthesizer.Name], vrn.DependsOn...)
+	}
+
+	const inProgress, done = 1, 2
+	states := map[string]int{}
+	var walk func(name string, path []string) []string
+	walk = func(name string, path []string) []string {
+		switch states[name] {
+		case inProgress:
+			return append(path, name)
+		case done:
+			return nil
+		}
+		states[name] = inProgress
+		for _, next := range edges[name] {
+			if cycle := walk(next, append(path, name)); cycle != nil {
+				return cycle
+			}
+		}
+		states[name] = done
+		return nil
+	}
+
+	names := make([]string, 0, len(edges))
+	for name := range edges {
+		names = append(names, name)
+	}
+	slices.Sort(names) // deterministic output for status messages
+	for _, name := range names {
+		if cycle := walk(name, nil); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// unreconciledDependency returns the name of a synthesizer listed in the
+// variation's dependsOn whose composition hasn't been reconciled yet, or an
+// empty string when the variation isn't blocked.
+func unreconciledDependency(symph *apiv1.Symphony, vrn *apiv1.Variation, comps *apiv1.CompositionList) string {
+	for _, name := range vrn.DependsOn {
+		i := slices.IndexFunc(symph.Spec.Variations, func(v apiv1.Variation) bool { return v.Synthesizer.Name == name })
+		if i == -1 {
+			continue // dangling reference - don't block forever on a variation that doesn't exist
+		}
+		dep := &symph.Spec.Variations[i]
+
+		j := slices.IndexFunc(comps.Items, func(c apiv1.Composition) bool { return matchesVariation(symph, &c, dep) })
+		if j == -1 {
+			return name
+		}
+		syn := comps.Items[j].Status.CurrentSynthesis
+		if syn == nil || syn.Reconciled == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// replicaCount returns the number of compositions the variation should produce.
+func replicaCount(vrn *apiv1.Variation) int {
+	if vrn.Replicas == nil || *vrn.Replicas < 1 {
+		return 1
+	}
+	return *vrn.Replicas
+}
+
+// compReplicaIndex returns the composition's replica index. Compositions that
+// predate replication don't carry the label and count as replica zero.
+func compReplicaIndex(comp *apiv1.Composition) int {
+	idx, err := strconv.Atoi(comp.Labels[replicaIndexLabelKey])
+	if err != nil || idx < 0 {
+		return 0
+	}
+	return idx
+}
+
 // getBindings generates the bindings for a variation given it's symphony.
 // Bindings specified by a variation take precedence over the symphony.
 func getBindings(symph *apiv1.Symphony, vrn *apiv1.Variation) []apiv1.Binding {
@@ -310,11 +523,78 @@ func getSynthesisEnv(symph *apiv1.Symphony, vrn *apiv1.Variation) []apiv1.EnvVar
 		// Only use symhony var if the variation didn't specify it.
 		if i == -1 {
 			res = append(res, evar)
+			continue
+		}
+		// A valueFrom reference beats an inlined value with the same name
+		// regardless of which spec it came from.
+		if evar.ValueFrom != nil && res[i].ValueFrom == nil {
+			res[i] = evar
 		}
 	}
 	return res
 }
 
+// rolloutPending returns true when a composition was updated by a rollout but
+// hasn't yet reconciled the resulting generation.
+func rolloutPending(comp *apiv1.Composition) bool {
+	if _, ok := comp.Annotations[rolloutUpdateTimeAnnotation]; !ok {
+		return false
+	}
+	syn := comp.Status.CurrentSynthesis
+	return syn == nil || syn.ObservedCompositionGeneration != comp.Generation || syn.Reconciled == nil
+}
+
+func pendingRollouts(comps *apiv1.CompositionList) int {
+	var n int
+	for _, comp := range comps.Items {
+		if rolloutPending(&comp) {
+			n++
+		}
+	}
+	return n
+}
+
+// rolloutHaltedBy returns the name of a composition that has been pending longer
+// than the rollout's failure window, or an empty string if none have.
+func rolloutHaltedBy(rollout *apiv1.RolloutStrategy, comps *apiv1.CompositionList, now time.Time) string {
+	if rollout.FailureWindow == nil {
+		return ""
+	}
+	for _, comp := range comps.Items {
+		if !rolloutPending(&comp) {
+			continue
+		}
+		updated, err := time.Parse(time.RFC3339, comp.Annotations[rolloutUpdateTimeAnnotation])
+		if err != nil {
+			continue
+		}
+		if now.Sub(updated) > rollout.FailureWindow.Duration {
+			return comp.Name
+		}
+	}
+	return ""
+}
+
+// ownedBySymphony returns true when the composition was created by the symphony
+// controller, either because it carries the variation label or - for
+// compositions that predate the label - because the symphony is its controller.
+func ownedBySymphony(symph *apiv1.Symphony, comp *apiv1.Composition) bool {
+	if _, ok := comp.Labels[variationLabelKey]; ok {
+		return true
+	}
+	owner := metav1.GetControllerOf(comp)
+	return owner != nil && owner.UID == symph.UID
+}
+
+// matchesVariation returns true when the composition is the symphony's
+// composition for the given variation.
+func matchesVariation(symph *apiv1.Symphony, comp *apiv1.Composition, vrn *apiv1.Variation) bool {
+	if name, ok := comp.Labels[variationLabelKey]; ok {
+		return name == vrn.Synthesizer.Name
+	}
+	return ownedBySymphony(symph, comp) && comp.Spec.Synthesizer.Name == vrn.Synthesizer.Name
+}
+
 func coalesceMetadata(variation *apiv1.Variation, existing *apiv1.Composition) bool {
 	var metaChanged bool
 
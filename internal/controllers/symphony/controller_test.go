@@ -193,14 +193,22 @@ func TestSymphonyDuplicateCleanup(t *testing.T) {
 	now := metav1.Now()
 	comp.CreationTimestamp = metav1.NewTime(now.Add(time.Second))
 	comp.Name = "foo"
+	comp.Labels = map[string]string{variationLabelKey: "foo"}
 	comp.Spec.Synthesizer.Name = "foo"
 
 	comp2 := apiv1.Composition{}
 	comp2.CreationTimestamp = now
 	comp2.Name = "bar"
+	comp2.Labels = map[string]string{variationLabelKey: "foo"}
 	comp2.Spec.Synthesizer.Name = "foo"
 
-	comps := &apiv1.CompositionList{Items: []apiv1.Composition{comp, comp2}}
+	// Hand-created compositions that reference the same synthesizer aren't duplicates
+	handmade := apiv1.Composition{}
+	handmade.CreationTimestamp = now
+	handmade.Name = "handmade"
+	handmade.Spec.Synthesizer.Name = "foo"
+
+	comps := &apiv1.CompositionList{Items: []apiv1.Composition{comp, comp2, handmade}}
 	_, err := s.reconcileReverse(ctx, sym, comps)
 	require.EqualError(t, err, `deleting duplicate composition: compositions.eno.azure.io "bar" not found`)
 }
@@ -284,7 +292,33 @@ func TestBuildStatus(t *testing.T) {
 		}
 
 		status := c.buildStatus(symph, comps)
-		assert.Equal(t, apiv1.SymphonyStatus{}, status)
+		assert.Equal(t, apiv1.SymphonyStatus{NotReadySynthesizers: []string{"bar"}}, status)
+	})
+
+	t.Run("several not ready", func(t *testing.T) {
+		symph := &apiv1.Symphony{
+			Spec: apiv1.SymphonySpec{
+				Variations: []apiv1.Variation{
+					{Synthesizer: apiv1.SynthesizerRef{Name: "foo"}},
+					{Synthesizer: apiv1.SynthesizerRef{Name: "bar"}},
+				},
+			},
+		}
+		comps := &apiv1.CompositionList{}
+		comps.Items = []apiv1.Composition{
+			{
+				Spec:   apiv1.CompositionSpec{Synthesizer: apiv1.SynthesizerRef{Name: "foo"}},
+				Status: apiv1.CompositionStatus{CurrentSynthesis: &apiv1.Synthesis{}},
+			},
+			{
+				Spec:   apiv1.CompositionSpec{Synthesizer: apiv1.SynthesizerRef{Name: "bar"}},
+				Status: apiv1.CompositionStatus{},
+			},
+		}
+
+		// The laggards are reported deterministically regardless of list ordering
+		status := c.buildStatus(symph, comps)
+		assert.Equal(t, []string{"bar", "foo"}, status.NotReadySynthesizers)
 	})
 
 	t.Run("two ready", func(t *testing.T) {
@@ -486,6 +520,30 @@ func TestGetSynthesisEnv(t *testing.T) {
 			},
 			expectedEnv: []apiv1.EnvVar{{Name: "var1", Value: "comp-override"}, {Name: "val2", Value: "var2"}},
 		},
+		{
+			name: "variation valueFrom takes precedence over symphony value",
+			variation: apiv1.Variation{
+				SynthesisEnv: []apiv1.EnvVar{{Name: "var1", ValueFrom: &apiv1.EnvVarSource{ConfigMapKeyRef: &apiv1.KeyRef{Name: "cm", Key: "key"}}}},
+			},
+			symph: apiv1.Symphony{
+				Spec: apiv1.SymphonySpec{
+					SynthesisEnv: []apiv1.EnvVar{{Name: "var1", Value: "val1"}},
+				},
+			},
+			expectedEnv: []apiv1.EnvVar{{Name: "var1", ValueFrom: &apiv1.EnvVarSource{ConfigMapKeyRef: &apiv1.KeyRef{Name: "cm", Key: "key"}}}},
+		},
+		{
+			name: "symphony valueFrom takes precedence over variation value",
+			variation: apiv1.Variation{
+				SynthesisEnv: []apiv1.EnvVar{{Name: "var1", Value: "val1"}},
+			},
+			symph: apiv1.Symphony{
+				Spec: apiv1.SymphonySpec{
+					SynthesisEnv: []apiv1.EnvVar{{Name: "var1", ValueFrom: &apiv1.EnvVarSource{SecretKeyRef: &apiv1.KeyRef{Name: "secret", Key: "key"}}}},
+				},
+			},
+			expectedEnv: []apiv1.EnvVar{{Name: "var1", ValueFrom: &apiv1.EnvVarSource{SecretKeyRef: &apiv1.KeyRef{Name: "secret", Key: "key"}}}},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -644,3 +702,320 @@ func TestCoalesceMetadata(t *testing.T) {
 		})
 	}
 }
+
+func TestGradualRollout(t *testing.T) {
+	ctx := testutil.NewContext(t)
+
+	symph := &apiv1.Symphony{}
+	symph.Name = "test-symphony"
+	symph.Namespace = "default"
+	symph.UID = "test-uid"
+	symph.Spec.Rollout = &apiv1.RolloutStrategy{
+		BatchSize:     1,
+		FailureWindow: &metav1.Duration{Duration: time.Minute},
+	}
+	symph.Spec.Variations = []apiv1.Variation{
+		{Synthesizer: apiv1.SynthesizerRef{Name: "synth-a"}},
+		{Synthesizer: apiv1.SynthesizerRef{Name: "synth-b"}},
+	}
+	symph.Spec.Bindings = []apiv1.Binding{{Key: "foo", Resource: apiv1.ResourceBinding{Name: "updated"}}}
+
+	newComp := func(name, synth string) *apiv1.Composition {
+		comp := &apiv1.Composition{}
+		comp.Name = name
+		comp.Namespace = symph.Namespace
+		comp.Spec.Synthesizer.Name = synth
+		comp.Spec.Bindings = []apiv1.Binding{{Key: "foo", Resource: apiv1.ResourceBinding{Name: "stale"}}}
+		comp.Labels = map[string]string{variationLabelKey: synth}
+		return comp
+	}
+	compA := newComp("comp-a", "synth-a")
+	compB := newComp("comp-b", "synth-b")
+
+	cli := testutil.NewClient(t, compA, compB)
+	c := &symphonyController{client: cli, noCacheClient: cli}
+
+	// The first composition of the batch is updated and stamped with the rollout annotation
+	comps := &apiv1.CompositionList{Items: []apiv1.Composition{*compA, *compB}}
+	modified, err := c.reconcileForward(ctx, symph, comps)
+	require.NoError(t, err)
+	assert.True(t, modified)
+
+	updatedA := &apiv1.Composition{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(compA), updatedA))
+	assert.Equal(t, "updated", updatedA.Spec.Bindings[0].Resource.Name)
+	assert.NotEmpty(t, updatedA.Annotations[rolloutUpdateTimeAnnotation])
+
+	// The second composition isn't updated until the first has been reconciled
+	comps = &apiv1.CompositionList{Items: []apiv1.Composition{*updatedA, *compB}}
+	modified, err = c.reconcileForward(ctx, symph, comps)
+	require.NoError(t, err)
+	assert.False(t, modified)
+
+	updatedB := &apiv1.Composition{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(compB), updatedB))
+	assert.Equal(t, "stale", updatedB.Spec.Bindings[0].Resource.Name)
+
+	// Once the first composition is reconciled the next batch proceeds
+	updatedA.Status.CurrentSynthesis = &apiv1.Synthesis{
+		ObservedCompositionGeneration: updatedA.Generation,
+		Reconciled:                    ptr.To(metav1.Now()),
+	}
+	comps = &apiv1.CompositionList{Items: []apiv1.Composition{*updatedA, *compB}}
+	modified, err = c.reconcileForward(ctx, symph, comps)
+	require.NoError(t, err)
+	assert.True(t, modified)
+
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(compB), updatedB))
+	assert.Equal(t, "updated", updatedB.Spec.Bindings[0].Resource.Name)
+
+	// The rollout halts when a composition exceeds the failure window without reconciling
+	updatedB.Annotations[rolloutUpdateTimeAnnotation] = time.Now().Add(-2 * time.Minute).Format(time.RFC3339)
+	symph.Spec.Bindings = []apiv1.Binding{{Key: "foo", Resource: apiv1.ResourceBinding{Name: "updated-again"}}}
+	comps = &apiv1.CompositionList{Items: []apiv1.Composition{*updatedA, *updatedB}}
+	modified, err = c.reconcileForward(ctx, symph, comps)
+	require.NoError(t, err)
+	assert.False(t, modified)
+
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(compA), updatedA))
+	assert.Equal(t, "updated", updatedA.Spec.Bindings[0].Resource.Name)
+}
+
+func TestSymphonyPaused(t *testing.T) {
+	ctx := testutil.NewContext(t)
+
+	symph := &apiv1.Symphony{}
+	symph.Name = "test-symphony"
+	symph.Namespace = "default"
+	symph.UID = "test-uid"
+	symph.Spec.Paused = true
+	symph.Spec.Variations = []apiv1.Variation{
+		{Synthesizer: apiv1.SynthesizerRef{Name: "synth-a"}},
+	}
+	symph.Spec.Bindings = []apiv1.Binding{{Key: "foo", Resource: apiv1.ResourceBinding{Name: "updated"}}}
+
+	comp := &apiv1.Composition{}
+	comp.Name = "comp-a"
+	comp.Namespace = symph.Namespace
+	comp.Spec.Synthesizer.Name = "synth-a"
+	comp.Spec.Bindings = []apiv1.Binding{{Key: "foo", Resource: apiv1.ResourceBinding{Name: "stale"}}}
+	comp.Labels = map[string]string{variationLabelKey: "synth-a"}
+
+	orphan := comp.DeepCopy()
+	orphan.Name = "comp-b"
+	orphan.Spec.Synthesizer.Name = "synth-b"
+	orphan.Labels = map[string]string{variationLabelKey: "synth-b"}
+
+	cli := testutil.NewClient(t, comp, orphan)
+	c := &symphonyController{client: cli, noCacheClient: cli}
+
+	// Changes are not applied while paused
+	comps := &apiv1.CompositionList{Items: []apiv1.Composition{*comp, *orphan}}
+	modified, err := c.reconcileForward(ctx, symph, comps)
+	require.NoError(t, err)
+	assert.False(t, modified)
+
+	updated := &apiv1.Composition{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), updated))
+	assert.Equal(t, "stale", updated.Spec.Bindings[0].Resource.Name)
+
+	// Compositions of removed variations are not deleted while paused
+	modified, err = c.reconcileReverse(ctx, symph, comps)
+	require.NoError(t, err)
+	assert.False(t, modified)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(orphan), updated))
+
+	// Unpausing resumes convergence
+	symph.Spec.Paused = false
+	modified, err = c.reconcileReverse(ctx, symph, comps)
+	require.NoError(t, err)
+	assert.True(t, modified)
+	assert.True(t, errors.IsNotFound(cli.Get(ctx, client.ObjectKeyFromObject(orphan), updated)))
+
+	modified, err = c.reconcileForward(ctx, symph, comps)
+	require.NoError(t, err)
+	assert.True(t, modified)
+
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), updated))
+	assert.Equal(t, "updated", updated.Spec.Bindings[0].Resource.Name)
+}
+
+func TestVariationOwnership(t *testing.T) {
+	symph := &apiv1.Symphony{}
+	symph.Name = "test-symphony"
+	symph.UID = "test-uid"
+	vrn := &apiv1.Variation{Synthesizer: apiv1.SynthesizerRef{Name: "foo"}}
+
+	// Labeled compositions are owned and matched by label value
+	comp := &apiv1.Composition{}
+	comp.Labels = map[string]string{variationLabelKey: "foo"}
+	assert.True(t, ownedBySymphony(symph, comp))
+	assert.True(t, matchesVariation(symph, comp, vrn))
+	comp.Labels[variationLabelKey] = "bar"
+	assert.False(t, matchesVariation(symph, comp, vrn))
+
+	// Unlabeled compositions fall back to the controller owner and synthesizer name
+	legacy := &apiv1.Composition{}
+	legacy.Spec.Synthesizer.Name = "foo"
+	legacy.OwnerReferences = []metav1.OwnerReference{{
+		Name:       symph.Name,
+		UID:        symph.UID,
+		Controller: ptr.To(true),
+	}}
+	assert.True(t, ownedBySymphony(symph, legacy))
+	assert.True(t, matchesVariation(symph, legacy, vrn))
+
+	// Hand-created compositions are neither owned nor matched
+	handmade := &apiv1.Composition{}
+	handmade.Spec.Synthesizer.Name = "foo"
+	assert.False(t, ownedBySymphony(symph, handmade))
+	assert.False(t, matchesVariation(symph, handmade, vrn))
+}
+
+func TestVariationDependencies(t *testing.T) {
+	ctx := testutil.NewContext(t)
+
+	symph := &apiv1.Symphony{}
+	symph.Name = "test-symphony"
+	symph.Namespace = "default"
+	symph.UID = "test-uid"
+	symph.Spec.Variations = []apiv1.Variation{
+		{Synthesizer: apiv1.SynthesizerRef{Name: "infra"}},
+		{Synthesizer: apiv1.SynthesizerRef{Name: "app"}, DependsOn: []string{"infra"}},
+	}
+
+	infra := &apiv1.Composition{}
+	infra.Name = "infra-comp"
+	infra.Namespace = symph.Namespace
+	infra.Spec.Synthesizer.Name = "infra"
+	infra.Labels = map[string]string{variationLabelKey: "infra"}
+
+	cli := testutil.NewClient(t, infra)
+	c := &symphonyController{client: cli, noCacheClient: cli}
+
+	// The app composition isn't created while the infra composition is unreconciled
+	comps := &apiv1.CompositionList{Items: []apiv1.Composition{*infra}}
+	modified, err := c.reconcileForward(ctx, symph, comps)
+	require.NoError(t, err)
+	assert.False(t, modified)
+
+	// Reconciling the infra composition unblocks the app composition
+	infra.Status.CurrentSynthesis = &apiv1.Synthesis{Reconciled: ptr.To(metav1.Now())}
+	comps = &apiv1.CompositionList{Items: []apiv1.Composition{*infra}}
+	modified, err = c.reconcileForward(ctx, symph, comps)
+	require.NoError(t, err)
+	assert.True(t, modified)
+
+	all := &apiv1.CompositionList{}
+	require.NoError(t, cli.List(ctx, all))
+	assert.Len(t, all.Items, 2)
+
+	// Dependencies on variations that don't exist never block
+	symph.Spec.Variations[1].DependsOn = []string{"missing"}
+	assert.Empty(t, unreconciledDependency(symph, &symph.Spec.Variations[1], comps))
+}
+
+func TestVariationReplicas(t *testing.T) {
+	ctx := testutil.NewContext(t)
+
+	symph := &apiv1.Symphony{}
+	symph.Name = "test-symphony"
+	symph.Namespace = "default"
+	symph.UID = "test-uid"
+	symph.Spec.Variations = []apiv1.Variation{
+		{Synthesizer: apiv1.SynthesizerRef{Name: "sharded"}, Replicas: ptr.To(2)},
+	}
+
+	cli := testutil.NewClient(t)
+	c := &symphonyController{client: cli, noCacheClient: cli}
+
+	// One composition is created per replica
+	for i := 0; i < 2; i++ {
+		comps := &apiv1.CompositionList{}
+		require.NoError(t, cli.List(ctx, comps))
+		modified, err := c.reconcileForward(ctx, symph, comps)
+		require.NoError(t, err)
+		assert.True(t, modified)
+	}
+
+	comps := &apiv1.CompositionList{}
+	require.NoError(t, cli.List(ctx, comps))
+	require.Len(t, comps.Items, 2)
+
+	indices := map[string]struct{}{}
+	for _, comp := range comps.Items {
+		idx := comp.Labels[replicaIndexLabelKey]
+		indices[idx] = struct{}{}
+		assert.Contains(t, comp.Spec.SynthesisEnv, apiv1.EnvVar{Name: replicaIndexEnvName, Value: idx})
+	}
+	assert.Equal(t, map[string]struct{}{"0": {}, "1": {}}, indices)
+
+	// Nothing left to do once both replicas exist
+	modified, err := c.reconcileForward(ctx, symph, comps)
+	require.NoError(t, err)
+	assert.False(t, modified)
+
+	// Replicas aren't mistaken for duplicates
+	modified, err = c.reconcileReverse(ctx, symph, comps)
+	require.NoError(t, err)
+	assert.False(t, modified)
+
+	// Scaling down deletes the highest-index composition first
+	symph.Spec.Variations[0].Replicas = ptr.To(1)
+	modified, err = c.reconcileReverse(ctx, symph, comps)
+	require.NoError(t, err)
+	assert.True(t, modified)
+
+	require.NoError(t, cli.List(ctx, comps))
+	require.Len(t, comps.Items, 1)
+	assert.Equal(t, "0", comps.Items[0].Labels[replicaIndexLabelKey])
+}
+
+func TestSyncStatusConflictingWriter(t *testing.T) {
+	ctx := testutil.NewContext(t)
+
+	symph := &apiv1.Symphony{}
+	symph.Name = "test-symphony"
+	symph.Namespace = "default"
+	symph.Spec.Variations = []apiv1.Variation{{Synthesizer: apiv1.SynthesizerRef{Name: "foosynth"}}}
+
+	cli := testutil.NewClient(t, symph)
+	c := &symphonyController{client: cli, noCacheClient: cli}
+
+	// Another writer bumps the symphony's resource version after our copy was read
+	stale := symph.DeepCopy()
+	symph.Annotations = map[string]string{"some-concurrent": "writer"}
+	require.NoError(t, cli.Update(ctx, symph))
+
+	// The status is patched rather than updated, so the stale read doesn't conflict
+	comps := &apiv1.CompositionList{}
+	require.NoError(t, c.syncStatus(ctx, stale, comps))
+
+	actual := &apiv1.Symphony{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(symph), actual))
+	assert.Equal(t, symph.Generation, actual.Status.ObservedGeneration)
+	assert.Equal(t, map[string]string{"some-concurrent": "writer"}, actual.Annotations)
+}
+
+func TestFindVariationCycle(t *testing.T) {
+	symph := &apiv1.Symphony{}
+	symph.Spec.Variations = []apiv1.Variation{
+		{Synthesizer: apiv1.SynthesizerRef{Name: "a"}, DependsOn: []string{"b"}},
+		{Synthesizer: apiv1.SynthesizerRef{Name: "b"}},
+	}
+	assert.Nil(t, findVariationCycle(symph))
+
+	symph.Spec.Variations[1].DependsOn = []string{"a"}
+	assert.Equal(t, []string{"a", "b", "a"}, findVariationCycle(symph))
+
+	// Cycles halt reconciliation and surface through the status
+	ctx := testutil.NewContext(t)
+	cli := testutil.NewClient(t)
+	c := &symphonyController{client: cli, noCacheClient: cli}
+	modified, err := c.reconcileForward(ctx, symph, &apiv1.CompositionList{})
+	require.NoError(t, err)
+	assert.False(t, modified)
+
+	status := c.buildStatus(symph, &apiv1.CompositionList{})
+	assert.Equal(t, "a -> b -> a", status.VariationDependencyCycle)
+}
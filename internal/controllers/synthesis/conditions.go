@@ -0,0 +1,66 @@
+package synthesis
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/manager"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// inputsConditionController maintains the InputsUpToDate condition on compositions
+// by comparing the current synthesis's recorded input revisions against the latest
+// revisions observed by the watch controllers.
+type inputsConditionController struct {
+	client client.Client
+}
+
+func NewInputsConditionController(mgr ctrl.Manager) error {
+	c := &inputsConditionController{client: mgr.GetClient()}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1.Composition{}).
+		WithLogConstructor(manager.NewLogConstructor(mgr, "inputsConditionController")).
+		Complete(c)
+}
+
+func (c *inputsConditionController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	comp := &apiv1.Composition{}
+	err := c.client.Get(ctx, req.NamespacedName, comp)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if comp.DeletionTimestamp != nil || comp.Status.CurrentSynthesis == nil {
+		return ctrl.Result{}, nil
+	}
+
+	cond := metav1.Condition{
+		Type:               apiv1.ConditionInputsUpToDate,
+		Status:             metav1.ConditionTrue,
+		Reason:             "InputsUpToDate",
+		Message:            "The current synthesis used the latest observed input revisions",
+		ObservedGeneration: comp.Generation,
+	}
+	if comp.Status.InputsDrifted(comp.Status.InputRevisions) {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "InputsDrifted"
+		cond.Message = "One or more inputs have changed since the current synthesis"
+	}
+
+	if !meta.SetStatusCondition(&comp.Status.Conditions, cond) {
+		return ctrl.Result{}, nil
+	}
+	err = c.client.Status().Update(ctx, comp)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("updating composition status: %w", err)
+	}
+
+	logger.V(1).Info("updated composition inputs condition", "conditionStatus", cond.Status, "conditionReason", cond.Reason)
+	return ctrl.Result{}, nil
+}
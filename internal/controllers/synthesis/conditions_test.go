@@ -0,0 +1,61 @@
+package synthesis
+
+import (
+	"testing"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestInputsCondition(t *testing.T) {
+	ctx := testutil.NewContext(t)
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{
+		UUID:           "test-uuid",
+		InputRevisions: []apiv1.InputRevisions{{Key: "foo", Revision: ptr.To(1)}},
+	}
+	comp.Status.InputRevisions = []apiv1.InputRevisions{{Key: "foo", Revision: ptr.To(1)}}
+
+	cli := testutil.NewClient(t, comp)
+	c := &inputsConditionController{client: cli}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(comp)}
+
+	// The synthesis used the latest revisions
+	_, err := c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, req.NamespacedName, comp))
+	cond := meta.FindStatusCondition(comp.Status.Conditions, apiv1.ConditionInputsUpToDate)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+
+	// An input advances past the revision recorded by the synthesis
+	comp.Status.InputRevisions = []apiv1.InputRevisions{{Key: "foo", Revision: ptr.To(2)}}
+	require.NoError(t, cli.Status().Update(ctx, comp))
+	_, err = c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, req.NamespacedName, comp))
+	cond = meta.FindStatusCondition(comp.Status.Conditions, apiv1.ConditionInputsUpToDate)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, "InputsDrifted", cond.Reason)
+
+	// A new synthesis catches up with the latest revisions
+	comp.Status.CurrentSynthesis.InputRevisions = []apiv1.InputRevisions{{Key: "foo", Revision: ptr.To(2)}}
+	require.NoError(t, cli.Status().Update(ctx, comp))
+	_, err = c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, req.NamespacedName, comp))
+	cond = meta.FindStatusCondition(comp.Status.Conditions, apiv1.ConditionInputsUpToDate)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionTrue, cond.Status)
+}
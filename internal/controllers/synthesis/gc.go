@@ -18,12 +18,14 @@ import (
 type podGarbageCollector struct {
 	client          client.Client
 	creationTimeout time.Duration
+	podRetention    time.Duration
 }
 
-func NewPodGC(mgr ctrl.Manager, creationTimeout time.Duration) error {
+func NewPodGC(mgr ctrl.Manager, creationTimeout, podRetention time.Duration) error {
 	c := &podGarbageCollector{
 		client:          mgr.GetClient(),
 		creationTimeout: creationTimeout,
+		podRetention:    podRetention,
 	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&corev1.Pod{}).
@@ -93,8 +95,20 @@ func (p *podGarbageCollector) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 
 	if syn := comp.Status.InFlightSynthesis; syn != nil {
+		// Capture the failure reason before the pod can be deleted, since
+		// catching a crashing pod before GC is impractical
+		if err := p.recordLastError(ctx, comp, pod); err != nil {
+			logger.Error(err, "failed to record synthesis error")
+			return ctrl.Result{}, err
+		}
+
 		if syn.Canceled != nil {
+			if comp.ShouldDebugSynthesis() {
+				logger.V(0).Info("retaining synthesizer pod for debugging even though its synthesis timed out")
+				return ctrl.Result{}, nil
+			}
 			logger = logger.WithValues("reason", "Timeout")
+			synthesisPodTimeouts.WithLabelValues(comp.Spec.Synthesizer.Name).Inc()
 			return ctrl.Result{}, p.deletePod(ctx, pod, logger)
 		}
 
@@ -109,6 +123,12 @@ func (p *podGarbageCollector) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	// In-flight synthesis being swapped to current == synthesis completed
 	if syn := comp.Status.CurrentSynthesis; syn != nil && syn.UUID == pod.Labels[synthesisIDLabelKey] {
+		// Successful pods can optionally be retained briefly for external log
+		// collectors. They don't block the next synthesis since it runs in a new pod.
+		if wait := p.retentionRemaining(pod, time.Now()); wait > 0 {
+			logger.V(1).Info("retaining successful synthesizer pod", "remaining", wait)
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
 		logger = logger.WithValues("reason", "Success")
 		return ctrl.Result{}, p.deletePod(ctx, pod, logger)
 	}
@@ -133,6 +153,62 @@ func (p *podGarbageCollector) deletePod(ctx context.Context, pod *corev1.Pod, lo
 	return nil
 }
 
+// retentionRemaining returns how much longer a successful pod should be kept
+// before deletion, anchored at the container's exit when it's known.
+func (p *podGarbageCollector) retentionRemaining(pod *corev1.Pod, now time.Time) time.Duration {
+	if p.podRetention <= 0 {
+		return 0
+	}
+	anchor := pod.CreationTimestamp.Time
+	if ts := containerExitedTime(pod); ts != nil {
+		anchor = *ts
+	}
+	return p.podRetention - now.Sub(anchor)
+}
+
+// maxSynthesisErrorBytes bounds the container termination message retained in
+// the composition's status.
+const maxSynthesisErrorBytes = 2048
+
+// recordLastError stores the exit code and termination message of the pod's
+// terminated synthesizer container in the in-flight synthesis, so the failure
+// reason outlives the pod.
+func (p *podGarbageCollector) recordLastError(ctx context.Context, comp *apiv1.Composition, pod *corev1.Pod) error {
+	syn := comp.Status.InFlightSynthesis
+	synErr := synthesisError(pod)
+	if syn == nil || synErr == nil || syn.UUID != pod.Labels[synthesisIDLabelKey] {
+		return nil
+	}
+	if last := syn.LastError; last != nil && last.ExitCode == synErr.ExitCode && last.Message == synErr.Message {
+		return nil // already recorded
+	}
+	syn.LastError = synErr
+	if err := p.client.Status().Update(ctx, comp); err != nil {
+		return fmt.Errorf("updating composition status: %w", err)
+	}
+	return nil
+}
+
+// synthesisError derives a SynthesisError from the pod's most recently
+// terminated, non-zero container state.
+func synthesisError(pod *corev1.Pod) *apiv1.SynthesisError {
+	for _, cont := range pod.Status.ContainerStatuses {
+		state := cont.State.Terminated
+		if state == nil {
+			state = cont.LastTerminationState.Terminated
+		}
+		if state == nil || state.ExitCode == 0 {
+			continue
+		}
+		msg := state.Message
+		if len(msg) > maxSynthesisErrorBytes {
+			msg = msg[:maxSynthesisErrorBytes]
+		}
+		return &apiv1.SynthesisError{ExitCode: state.ExitCode, Message: msg}
+	}
+	return nil
+}
+
 func timeWaitingForKubelet(pod *corev1.Pod, now time.Time) time.Duration {
 	if len(pod.Status.ContainerStatuses) > 0 {
 		return 0
@@ -1,6 +1,8 @@
 package synthesis
 
 import (
+	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,8 +13,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 func TestPodGCMissingSynthesis(t *testing.T) {
@@ -20,7 +25,7 @@ func TestPodGCMissingSynthesis(t *testing.T) {
 	mgr := testutil.NewManager(t)
 	cli := mgr.GetClient()
 
-	require.NoError(t, NewPodGC(mgr.Manager, 0))
+	require.NoError(t, NewPodGC(mgr.Manager, 0, 0))
 	mgr.Start(t)
 
 	synth := &apiv1.Synthesizer{}
@@ -48,7 +53,7 @@ func TestPodGCContainerCreationTimeout(t *testing.T) {
 	mgr := testutil.NewManager(t)
 	cli := mgr.GetClient()
 
-	require.NoError(t, NewPodGC(mgr.Manager, time.Millisecond*10))
+	require.NoError(t, NewPodGC(mgr.Manager, time.Millisecond*10, 0))
 	mgr.Start(t)
 
 	synth := &apiv1.Synthesizer{}
@@ -156,6 +161,63 @@ func TestTimeWaitingForKubelet(t *testing.T) {
 	}
 }
 
+func TestRetentionRemaining(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		Name      string
+		Retention time.Duration
+		Pod       *corev1.Pod
+		Expected  time.Duration
+	}{
+		{
+			Name:      "retention disabled",
+			Retention: 0,
+			Pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Time{Time: now}},
+			},
+			Expected: 0,
+		},
+		{
+			Name:      "anchored at pod creation when the container hasn't exited",
+			Retention: time.Minute,
+			Pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Time{Time: now.Add(-30 * time.Second)}},
+			},
+			Expected: 30 * time.Second,
+		},
+		{
+			Name:      "anchored at container exit",
+			Retention: time.Minute,
+			Pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Time{Time: now.Add(-time.Hour)}},
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{{
+						LastTerminationState: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{FinishedAt: metav1.Time{Time: now.Add(-45 * time.Second)}},
+						},
+					}},
+				},
+			},
+			Expected: 15 * time.Second,
+		},
+		{
+			Name:      "retention expired",
+			Retention: time.Minute,
+			Pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Time{Time: now.Add(-2 * time.Minute)}},
+			},
+			Expected: -time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			p := &podGarbageCollector{podRetention: tt.Retention}
+			assert.Equal(t, tt.Expected, p.retentionRemaining(tt.Pod, now))
+		})
+	}
+}
+
 func TestContainerExitedTime(t *testing.T) {
 	ts := time.Now()
 	tests := []struct {
@@ -241,3 +303,105 @@ func TestContainerExitedTime(t *testing.T) {
 		assert.Equal(t, test.Exp, ts)
 	}
 }
+
+func TestPodGCDebugRetention(t *testing.T) {
+	ctx := context.Background()
+
+	synth := &apiv1.Synthesizer{}
+	synth.Name = "test-syn"
+	synth.Spec.Image = "test-syn-image"
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	comp.Spec.Synthesizer.Name = synth.Name
+	comp.Annotations = map[string]string{"eno.azure.io/debug-synthesis": "true"}
+	comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "test-uuid", Canceled: ptr.To(metav1.Now())}
+
+	pod := newPod(minimalTestConfig, comp, synth)
+	pod.Name = "test-pod"
+	pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Minute))
+	cli := testutil.NewClient(t, synth, comp, pod)
+	p := &podGarbageCollector{client: cli}
+
+	// The timed out synthesis pod is retained for debugging
+	_, err := p.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}})
+	require.NoError(t, err)
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(pod), pod))
+
+	// Without the annotation it would have been reaped
+	comp.Annotations = nil
+	require.NoError(t, cli.Update(ctx, comp))
+	_, err = p.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}})
+	require.NoError(t, err)
+	assert.True(t, errors.IsNotFound(cli.Get(ctx, client.ObjectKeyFromObject(pod), pod)))
+}
+
+func TestRecordLastError(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	cli := testutil.NewClient(t)
+	p := &podGarbageCollector{client: cli}
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	require.NoError(t, cli.Create(ctx, comp))
+	comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "test-uuid"}
+	require.NoError(t, cli.Status().Update(ctx, comp))
+
+	pod := &corev1.Pod{}
+	pod.Name = "test-pod"
+	pod.Namespace = "default"
+	pod.Labels = map[string]string{synthesisIDLabelKey: "test-uuid"}
+
+	// Nothing is recorded while the container hasn't terminated
+	require.NoError(t, p.recordLastError(ctx, comp, pod))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	assert.Nil(t, comp.Status.InFlightSynthesis.LastError)
+
+	// The terminated container's exit code and message are persisted
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		LastTerminationState: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{ExitCode: 2, Message: "oh no"},
+		},
+	}}
+	require.NoError(t, p.recordLastError(ctx, comp, pod))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	require.NotNil(t, comp.Status.InFlightSynthesis.LastError)
+	assert.Equal(t, int32(2), comp.Status.InFlightSynthesis.LastError.ExitCode)
+	assert.Equal(t, "oh no", comp.Status.InFlightSynthesis.LastError.Message)
+
+	// Identical failures aren't rewritten
+	rv := comp.ResourceVersion
+	require.NoError(t, p.recordLastError(ctx, comp, pod))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	assert.Equal(t, rv, comp.ResourceVersion)
+
+	// Pods from other syntheses are ignored
+	pod.Labels[synthesisIDLabelKey] = "stale-uuid"
+	pod.Status.ContainerStatuses[0].LastTerminationState.Terminated.ExitCode = 3
+	require.NoError(t, p.recordLastError(ctx, comp, pod))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	assert.Equal(t, int32(2), comp.Status.InFlightSynthesis.LastError.ExitCode)
+}
+
+func TestSynthesisError(t *testing.T) {
+	pod := &corev1.Pod{}
+	assert.Nil(t, synthesisError(pod))
+
+	// Successful terminations don't count as errors
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+	}}
+	assert.Nil(t, synthesisError(pod))
+
+	// Long termination messages are truncated
+	pod.Status.ContainerStatuses[0].State.Terminated = &corev1.ContainerStateTerminated{
+		ExitCode: 1,
+		Message:  strings.Repeat("x", maxSynthesisErrorBytes+1),
+	}
+	err := synthesisError(pod)
+	require.NotNil(t, err)
+	assert.Equal(t, int32(1), err.ExitCode)
+	assert.Len(t, err.Message, maxSynthesisErrorBytes)
+}
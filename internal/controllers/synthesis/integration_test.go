@@ -33,9 +33,9 @@ func TestControllerHappyPath(t *testing.T) {
 	mgr := testutil.NewManager(t)
 	cli := mgr.GetClient()
 
-	require.NoError(t, scheduling.NewController(mgr.Manager, 10, 2*time.Second, time.Second))
+	require.NoError(t, scheduling.NewController(mgr.Manager, 10, 0, 2*time.Second, time.Second))
 	require.NoError(t, NewPodLifecycleController(mgr.Manager, minimalTestConfig))
-	require.NoError(t, NewPodGC(mgr.Manager, 0))
+	require.NoError(t, NewPodGC(mgr.Manager, 0, 0))
 
 	calls := atomic.Int64{}
 	testutil.WithFakeExecutor(t, mgr, func(ctx context.Context, s *apiv1.Synthesizer, input *krmv1.ResourceList) (*krmv1.ResourceList, error) {
@@ -114,9 +114,9 @@ func TestControllerFastCompositionUpdates(t *testing.T) {
 	mgr := testutil.NewManager(t)
 	cli := mgr.GetClient()
 
-	require.NoError(t, scheduling.NewController(mgr.Manager, 10, 2*time.Second, time.Second))
+	require.NoError(t, scheduling.NewController(mgr.Manager, 10, 0, 2*time.Second, time.Second))
 	require.NoError(t, NewPodLifecycleController(mgr.Manager, minimalTestConfig))
-	require.NoError(t, NewPodGC(mgr.Manager, 0))
+	require.NoError(t, NewPodGC(mgr.Manager, 0, 0))
 	testutil.WithFakeExecutor(t, mgr, func(ctx context.Context, s *apiv1.Synthesizer, input *krmv1.ResourceList) (*krmv1.ResourceList, error) {
 		output := &krmv1.ResourceList{}
 		// simulate real pods taking some random amount of time to generation
@@ -194,9 +194,9 @@ func TestControllerSwitchingSynthesizers(t *testing.T) {
 		return output, nil
 	})
 
-	require.NoError(t, scheduling.NewController(mgr.Manager, 10, 2*time.Second, time.Second))
+	require.NoError(t, scheduling.NewController(mgr.Manager, 10, 0, 2*time.Second, time.Second))
 	require.NoError(t, NewPodLifecycleController(mgr.Manager, minimalTestConfig))
-	require.NoError(t, NewPodGC(mgr.Manager, 0))
+	require.NoError(t, NewPodGC(mgr.Manager, 0, 0))
 	mgr.Start(t)
 
 	syn1 := &apiv1.Synthesizer{}
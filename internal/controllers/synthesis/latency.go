@@ -0,0 +1,99 @@
+package synthesis
+
+import (
+	"context"
+	"sync"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/manager"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// latencyMetricsController observes synthesis latency histograms and attempt
+// counters from composition status transitions. It's read-only instrumentation:
+// observations are deduplicated in memory rather than in the API, so a restart
+// can at worst re-observe the most recent transition of each composition.
+// Label cardinality is bounded to the synthesizer name.
+type latencyMetricsController struct {
+	client client.Client
+
+	mut    sync.Mutex
+	states map[types.NamespacedName]*latencyState
+}
+
+type latencyState struct {
+	uuid                   string // latest synthesis UUID seen for this composition
+	attempts               int
+	firstSynthesisObserved bool
+	reconciledUUID         string // UUID of the last synthesis whose reconciliation latency was observed
+}
+
+func NewLatencyMetricsController(mgr ctrl.Manager) error {
+	l := &latencyMetricsController{
+		client: mgr.GetClient(),
+		states: map[types.NamespacedName]*latencyState{},
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiv1.Composition{}).
+		WithLogConstructor(manager.NewLogConstructor(mgr, "latencyMetricsController")).
+		Complete(l)
+}
+
+func (l *latencyMetricsController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	comp := &apiv1.Composition{}
+	err := l.client.Get(ctx, req.NamespacedName, comp)
+	if errors.IsNotFound(err) {
+		l.mut.Lock()
+		delete(l.states, req.NamespacedName)
+		l.mut.Unlock()
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	state, ok := l.states[req.NamespacedName]
+	if !ok {
+		state = &latencyState{}
+		l.states[req.NamespacedName] = state
+	}
+	synthName := comp.Spec.Synthesizer.Name
+
+	// Count attempts of the latest synthesis, including retries
+	latest := comp.Status.InFlightSynthesis
+	if latest == nil {
+		latest = comp.Status.CurrentSynthesis
+	}
+	if syn := latest; syn != nil {
+		if state.uuid != syn.UUID {
+			state.uuid = syn.UUID
+			state.attempts = 0
+		}
+		if syn.Attempts > state.attempts {
+			synthesisAttempts.WithLabelValues(synthName).Add(float64(syn.Attempts - state.attempts))
+			state.attempts = syn.Attempts
+		}
+	}
+
+	syn := comp.Status.CurrentSynthesis
+	if syn == nil || syn.Synthesized == nil {
+		return ctrl.Result{}, nil
+	}
+
+	if !state.firstSynthesisObserved {
+		firstSynthesisLatency.WithLabelValues(synthName).Observe(syn.Synthesized.Sub(comp.CreationTimestamp.Time).Seconds())
+		state.firstSynthesisObserved = true
+	}
+
+	if syn.Reconciled != nil && state.reconciledUUID != syn.UUID {
+		synthesisReconciliationLatency.WithLabelValues(synthName).Observe(syn.Reconciled.Sub(syn.Synthesized.Time).Seconds())
+		state.reconciledUUID = syn.UUID
+	}
+
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,80 @@
+package synthesis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/testutil"
+)
+
+func TestLatencyMetrics(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	cli := testutil.NewClient(t)
+	l := &latencyMetricsController{client: cli, states: map[types.NamespacedName]*latencyState{}}
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	comp.Spec.Synthesizer.Name = "test-synth"
+	require.NoError(t, cli.Create(ctx, comp))
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(comp)}
+	key := req.NamespacedName
+
+	// In-flight synthesis with retries
+	comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "uuid-1", Attempts: 2}
+	require.NoError(t, cli.Status().Update(ctx, comp))
+	_, err := l.Reconcile(ctx, req)
+	require.NoError(t, err)
+	require.NotNil(t, l.states[key])
+	assert.Equal(t, 2, l.states[key].attempts)
+	assert.False(t, l.states[key].firstSynthesisObserved)
+
+	// Repeat reconciles don't advance the attempt count
+	_, err = l.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 2, l.states[key].attempts)
+
+	// Synthesis completes and reconciles
+	now := metav1.Now()
+	comp.Status.InFlightSynthesis = nil
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{
+		UUID:        "uuid-1",
+		Attempts:    3,
+		Synthesized: &now,
+		Reconciled:  &now,
+	}
+	require.NoError(t, cli.Status().Update(ctx, comp))
+	_, err = l.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 3, l.states[key].attempts)
+	assert.True(t, l.states[key].firstSynthesisObserved)
+	assert.Equal(t, "uuid-1", l.states[key].reconciledUUID)
+
+	// A new synthesis resets the per-synthesis state but not the per-composition state
+	later := metav1.NewTime(now.Add(time.Minute))
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{
+		UUID:        "uuid-2",
+		Attempts:    1,
+		Synthesized: &later,
+	}
+	require.NoError(t, cli.Status().Update(ctx, comp))
+	_, err = l.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, l.states[key].attempts)
+	assert.True(t, l.states[key].firstSynthesisObserved)
+	assert.Equal(t, "uuid-1", l.states[key].reconciledUUID) // uuid-2 hasn't reconciled yet
+
+	// Deleting the composition clears its state
+	require.NoError(t, cli.Delete(ctx, comp))
+	_, err = l.Reconcile(ctx, req)
+	require.NoError(t, err)
+	assert.Nil(t, l.states[key])
+}
@@ -3,10 +3,12 @@ package synthesis
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -30,20 +32,34 @@ type Config struct {
 
 	NodeAffinityKey   string
 	NodeAffinityValue string
+
+	// CompositionLabelKeys are label keys copied from compositions onto the
+	// pods that synthesize them e.g. for cost attribution.
+	CompositionLabelKeys []string
 }
 
+// synthesisRetryThreshold is the attempt count past which repeated synthesis
+// failures are surfaced to users as a warning event on the composition.
+const synthesisRetryThreshold = 4
+
 type podLifecycleController struct {
 	config        *Config
 	client        client.Client
 	noCacheReader client.Reader
+	recorder      record.EventRecorder
+
+	mut             sync.Mutex
+	warnedSyntheses map[types.NamespacedName]string // last synthesis UUID warned about per composition
 }
 
 // NewPodLifecycleController is responsible for creating and deleting pods as needed to synthesize compositions.
 func NewPodLifecycleController(mgr ctrl.Manager, cfg *Config) error {
 	c := &podLifecycleController{
-		config:        cfg,
-		client:        mgr.GetClient(),
-		noCacheReader: mgr.GetAPIReader(),
+		config:          cfg,
+		client:          mgr.GetClient(),
+		noCacheReader:   mgr.GetAPIReader(),
+		recorder:        mgr.GetEventRecorderFor("podLifecycleController"),
+		warnedSyntheses: map[types.NamespacedName]string{},
 	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&apiv1.Composition{}).
@@ -88,6 +104,10 @@ func (c *podLifecycleController) Reconcile(ctx context.Context, req ctrl.Request
 
 	logger = logger.WithValues("compositionName", comp.Name, "compositionNamespace", comp.Namespace, "compositionGeneration", comp.Generation, "synthesisID", comp.Status.InFlightSynthesis.UUID)
 
+	// Surface repeated synthesis failures as an event so they're visible in
+	// `kubectl describe` without digging through controller logs
+	c.warnRetriesExhausted(ctx, comp)
+
 	syn := &apiv1.Synthesizer{}
 	syn.Name = comp.Spec.Synthesizer.Name
 	err = c.client.Get(ctx, client.ObjectKeyFromObject(syn), syn)
@@ -99,6 +119,13 @@ func (c *podLifecycleController) Reconcile(ctx context.Context, req ctrl.Request
 		logger = logger.WithValues("synthesizerName", syn.Name, "synthesizerGeneration", syn.Generation)
 	}
 
+	// Fail fast when the composition doesn't bind every required ref - synthesis would
+	// otherwise run with missing inputs and produce garbage.
+	if unsatisfied := comp.UnsatisfiedRefs(syn); len(unsatisfied) > 0 {
+		logger.V(0).Info("refusing to create synthesizer pod because the composition does not bind every required ref", "unsatisfiedRefs", unsatisfied)
+		return ctrl.Result{}, nil
+	}
+
 	// Confirm that a pod doesn't already exist for this synthesis without trusting informers.
 	// This protects against cases where synthesis has recently started and something causes
 	// another tick of this loop before the pod write hits the informer.
@@ -116,6 +143,11 @@ func (c *podLifecycleController) Reconcile(ctx context.Context, req ctrl.Request
 		}
 	}
 
+	// Resolve any env vars sourced from configmaps/secrets before building the pod
+	if err := resolveEnv(ctx, c.noCacheReader, comp); err != nil {
+		return ctrl.Result{}, fmt.Errorf("resolving synthesis env: %w", err)
+	}
+
 	// If we made it this far it's safe to create a pod
 	pod := newPod(c.config, comp, syn)
 	err = c.client.Create(ctx, pod)
@@ -127,3 +159,56 @@ func (c *podLifecycleController) Reconcile(ctx context.Context, req ctrl.Request
 
 	return ctrl.Result{}, nil
 }
+
+// warnRetriesExhausted records a warning event on compositions whose synthesis has
+// crossed the retry threshold, at most once per synthesis UUID.
+func (c *podLifecycleController) warnRetriesExhausted(ctx context.Context, comp *apiv1.Composition) {
+	syn := comp.Status.InFlightSynthesis
+	if syn.Attempts <= synthesisRetryThreshold {
+		return
+	}
+
+	key := types.NamespacedName{Name: comp.Name, Namespace: comp.Namespace}
+	c.mut.Lock()
+	warned := c.warnedSyntheses[key] == syn.UUID
+	c.warnedSyntheses[key] = syn.UUID
+	c.mut.Unlock()
+	if warned {
+		return
+	}
+
+	msg := fmt.Sprintf("Synthesis failed %d times and will continue to be retried with backoff", syn.Attempts-1)
+	if reason := c.lastPodExitReason(ctx, comp); reason != "" {
+		msg = fmt.Sprintf("%s (last pod exit reason: %s)", msg, reason)
+	}
+	c.recorder.Event(comp, corev1.EventTypeWarning, "SynthesisRetriesExhausted", msg)
+}
+
+// lastPodExitReason returns the termination reason of the composition's most
+// recently created synthesizer pod, or an empty string if none is available.
+func (c *podLifecycleController) lastPodExitReason(ctx context.Context, comp *apiv1.Composition) string {
+	pods := &corev1.PodList{}
+	err := c.noCacheReader.List(ctx, pods, client.InNamespace(c.config.PodNamespace), client.MatchingLabels{
+		compositionNameLabelKey:      comp.Name,
+		compositionNamespaceLabelKey: comp.Namespace,
+	})
+	if err != nil {
+		return "" // the exit reason is best-effort
+	}
+
+	var latest *corev1.Pod
+	var reason string
+	for i, pod := range pods.Items {
+		if latest != nil && pod.CreationTimestamp.Before(&latest.CreationTimestamp) {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if t := status.State.Terminated; t != nil && t.Reason != "" {
+				latest, reason = &pods.Items[i], t.Reason
+			} else if t := status.LastTerminationState.Terminated; t != nil && t.Reason != "" {
+				latest, reason = &pods.Items[i], t.Reason
+			}
+		}
+	}
+	return reason
+}
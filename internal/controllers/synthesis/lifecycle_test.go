@@ -11,8 +11,11 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/Azure/eno/api/v1"
@@ -47,9 +50,9 @@ func TestCompositionDeletion(t *testing.T) {
 
 	require.NoError(t, NewPodLifecycleController(mgr.Manager, minimalTestConfig))
 	require.NoError(t, resourceslice.NewCleanupController(mgr.Manager))
-	require.NoError(t, scheduling.NewController(mgr.Manager, 10, 2*time.Second, time.Second))
+	require.NoError(t, scheduling.NewController(mgr.Manager, 10, 0, 2*time.Second, time.Second))
 	require.NoError(t, composition.NewController(mgr.Manager))
-	require.NoError(t, NewPodGC(mgr.Manager, 0))
+	require.NoError(t, NewPodGC(mgr.Manager, 0, 0))
 	mgr.Start(t)
 
 	syn := &apiv1.Synthesizer{}
@@ -132,3 +135,82 @@ func TestNonExistentComposition(t *testing.T) {
 		return errors.IsNotFound(err)
 	})
 }
+
+func TestSynthesisRetriesExhaustedEvent(t *testing.T) {
+	ctx := testutil.NewContext(t)
+
+	synth := &apiv1.Synthesizer{}
+	synth.Name = "test-synth"
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	comp.Finalizers = []string{"eno.azure.io/cleanup"}
+	comp.Spec.Synthesizer.Name = synth.Name
+	comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "test-uuid", Attempts: synthesisRetryThreshold + 1}
+
+	// A crashed pod from a previous attempt
+	pod := newPod(minimalTestConfig, comp, synth)
+	pod.Name = "test-pod"
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error"}},
+	}}
+
+	cli := testutil.NewClient(t, synth, comp, pod)
+	recorder := record.NewFakeRecorder(4)
+	c := &podLifecycleController{
+		config:          minimalTestConfig,
+		client:          cli,
+		noCacheReader:   cli,
+		recorder:        recorder,
+		warnedSyntheses: map[types.NamespacedName]string{},
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: comp.Name, Namespace: comp.Namespace}}
+
+	// The first reconcile emits the event, including the pod's exit reason
+	_, err := c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	select {
+	case event := <-recorder.Events:
+		assert.Contains(t, event, "SynthesisRetriesExhausted")
+		assert.Contains(t, event, "last pod exit reason: Error")
+	default:
+		t.Fatal("expected an event")
+	}
+
+	// Reconciling again doesn't emit a duplicate for the same synthesis
+	_, err = c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	select {
+	case event := <-recorder.Events:
+		t.Fatalf("unexpected duplicate event: %s", event)
+	default:
+	}
+
+	// A new synthesis that also crosses the threshold gets its own event
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "test-uuid-2", Attempts: synthesisRetryThreshold + 2}
+	require.NoError(t, cli.Status().Update(ctx, comp))
+
+	_, err = c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	select {
+	case event := <-recorder.Events:
+		assert.Contains(t, event, "SynthesisRetriesExhausted")
+	default:
+		t.Fatal("expected an event for the new synthesis")
+	}
+
+	// Syntheses below the threshold never emit
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "test-uuid-3", Attempts: 1}
+	require.NoError(t, cli.Status().Update(ctx, comp))
+
+	_, err = c.Reconcile(ctx, req)
+	require.NoError(t, err)
+	select {
+	case event := <-recorder.Events:
+		t.Fatalf("unexpected event: %s", event)
+	default:
+	}
+}
@@ -19,8 +19,38 @@ var (
 			Help: "Pods deleted due to timeout",
 		},
 	)
+
+	synthesisAttempts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "eno_synthesis_attempts_total",
+			Help: "Synthesis attempts including retries",
+		}, []string{"synthesizer"},
+	)
+
+	synthesisPodTimeouts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "eno_synthesis_pod_timeouts_total",
+			Help: "Synthesizer pods deleted because their synthesis timed out",
+		}, []string{"synthesizer"},
+	)
+
+	firstSynthesisLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "eno_first_synthesis_latency_seconds",
+			Help:    "Time from composition creation to its first completed synthesis",
+			Buckets: []float64{1, 5, 15, 30, 60, 120, 300, 600},
+		}, []string{"synthesizer"},
+	)
+
+	synthesisReconciliationLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "eno_synthesis_reconciliation_latency_seconds",
+			Help:    "Time from the completion of a synthesis to the reconciliation of its resources",
+			Buckets: []float64{1, 5, 15, 30, 60, 120, 300, 600},
+		}, []string{"synthesizer"},
+	)
 )
 
 func init() {
-	metrics.Registry.MustRegister(sytheses, synthesPodRecreations)
+	metrics.Registry.MustRegister(sytheses, synthesPodRecreations, synthesisAttempts, synthesisPodTimeouts, firstSynthesisLatency, synthesisReconciliationLatency)
 }
@@ -1,12 +1,17 @@
 package synthesis
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"slices"
 
 	"github.com/imdario/mergo"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "github.com/Azure/eno/api/v1"
 	"github.com/Azure/eno/internal/manager"
@@ -19,6 +24,8 @@ const (
 )
 
 func newPod(cfg *Config, comp *apiv1.Composition, syn *apiv1.Synthesizer) *corev1.Pod {
+	overrides := mergedPodOverrides(syn, comp)
+
 	pod := &corev1.Pod{}
 	pod.GenerateName = "synthesis-"
 	pod.Namespace = cfg.PodNamespace
@@ -28,12 +35,17 @@ func newPod(cfg *Config, comp *apiv1.Composition, syn *apiv1.Synthesizer) *corev
 		synthesisIDLabelKey:          comp.Status.InFlightSynthesis.UUID,
 		manager.ManagerLabelKey:      manager.ManagerLabelValue,
 	}
-	for k, v := range syn.Spec.PodOverrides.Labels {
+	for _, key := range cfg.CompositionLabelKeys {
+		if v, ok := comp.Labels[key]; ok {
+			pod.Labels[key] = v
+		}
+	}
+	for k, v := range overrides.Labels {
 		pod.Labels[k] = v
 	}
 
 	pod.Annotations = map[string]string{}
-	for k, v := range syn.Spec.PodOverrides.Annotations {
+	for k, v := range overrides.Annotations {
 		pod.Annotations[k] = v
 	}
 
@@ -51,11 +63,19 @@ func newPod(cfg *Config, comp *apiv1.Composition, syn *apiv1.Synthesizer) *corev
 			Value: comp.Status.InFlightSynthesis.UUID,
 		},
 	}
+	if comp.ShouldDebugSynthesis() {
+		env = append(env, corev1.EnvVar{Name: "DEBUG_SYNTHESIS", Value: "true"})
+	}
 
 	for _, ev := range filterEnv(env, comp.Spec.SynthesisEnv) {
 		env = append(env, corev1.EnvVar{Name: ev.Name, Value: ev.Value})
 	}
 
+	image := syn.Spec.Image
+	if comp.Spec.Synthesizer.ImageOverride != "" {
+		image = comp.Spec.Synthesizer.ImageOverride
+	}
+
 	pod.Spec = corev1.PodSpec{
 		ServiceAccountName: cfg.PodServiceAccount,
 		RestartPolicy:      corev1.RestartPolicyOnFailure,
@@ -85,13 +105,13 @@ func newPod(cfg *Config, comp *apiv1.Composition, syn *apiv1.Synthesizer) *corev
 		}},
 		Containers: []corev1.Container{{
 			Name:    "executor",
-			Image:   syn.Spec.Image,
+			Image:   image,
 			Command: []string{"/eno/executor"},
 			VolumeMounts: []corev1.VolumeMount{{
 				Name:      "sharedfs",
 				MountPath: "/eno",
 			}},
-			Resources: syn.Spec.PodOverrides.Resources,
+			Resources: overrides.Resources,
 			Env:       env,
 			SecurityContext: &corev1.SecurityContext{
 				AllowPrivilegeEscalation: ptr.To(false),
@@ -129,6 +149,10 @@ func newPod(cfg *Config, comp *apiv1.Composition, syn *apiv1.Synthesizer) *corev
 		}
 		pod.Spec.Tolerations = append(pod.Spec.Tolerations, toleration)
 	}
+	pod.Spec.Tolerations = append(pod.Spec.Tolerations, overrides.Tolerations...)
+	if len(overrides.NodeSelector) > 0 {
+		pod.Spec.NodeSelector = overrides.NodeSelector
+	}
 
 	if cfg.NodeAffinityKey != "" {
 		expr := corev1.NodeSelectorRequirement{
@@ -148,44 +172,124 @@ func newPod(cfg *Config, comp *apiv1.Composition, syn *apiv1.Synthesizer) *corev
 		}
 	}
 
-	// now that taints/toleration defaults have been set, time to merge in any overrides specified on the synthesizer
-	if syn.Spec.PodOverrides.Affinity != nil {
+	// now that taints/toleration defaults have been set, time to merge in any overrides
+	if overrides.Affinity != nil {
 		// do the merge
 		// easy one first
-		if syn.Spec.PodOverrides.Affinity.PodAffinity != nil {
-			pod.Spec.Affinity.PodAffinity = syn.Spec.PodOverrides.Affinity.PodAffinity
+		if overrides.Affinity.PodAffinity != nil {
+			pod.Spec.Affinity.PodAffinity = overrides.Affinity.PodAffinity
 		}
 
 		// merge in antiaffinity
-		if syn.Spec.PodOverrides.Affinity.PodAntiAffinity != nil {
+		if overrides.Affinity.PodAntiAffinity != nil {
 			// preferred is specified above so we want to append to that if specified
 			_ = mergo.Merge(&pod.Spec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
-				syn.Spec.PodOverrides.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+				overrides.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
 				mergo.WithAppendSlice,
 				mergo.WithoutDereference,
 				mergo.WithSliceDeepCopy)
 			// we can just overwrite the required one if specified
-			pod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = syn.Spec.PodOverrides.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+			pod.Spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = overrides.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution
 		}
 
-		if syn.Spec.PodOverrides.Affinity.NodeAffinity != nil {
+		if overrides.Affinity.NodeAffinity != nil {
 			// only need to merge the nodeaffinity terms if cfg.NodeAffinity was specified
 			// easy way to check is if it's not empty
 			if pod.Spec.Affinity.NodeAffinity != nil {
 				_ = mergo.Merge(&pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms,
-					syn.Spec.PodOverrides.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms,
+					overrides.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms,
 					mergo.WithAppendSlice,
 					mergo.WithoutDereference,
 					mergo.WithSliceDeepCopy)
 			}
 		} else {
 			// cfg.NodeAffinity was not specified, so we can just overwrite the nodeaffinity
-			pod.Spec.Affinity.NodeAffinity = syn.Spec.PodOverrides.Affinity.NodeAffinity
+			pod.Spec.Affinity.NodeAffinity = overrides.Affinity.NodeAffinity
 		}
 	}
 	return pod
 }
 
+// mergedPodOverrides flattens the composition's pod overrides over the
+// synthesizer's, with the composition winning on conflict.
+func mergedPodOverrides(syn *apiv1.Synthesizer, comp *apiv1.Composition) apiv1.PodOverrides {
+	merged := syn.Spec.PodOverrides
+	override := comp.Spec.PodOverrides
+
+	merged.Labels = mergeStringMaps(merged.Labels, override.Labels)
+	merged.Annotations = mergeStringMaps(merged.Annotations, override.Annotations)
+	merged.NodeSelector = mergeStringMaps(merged.NodeSelector, override.NodeSelector)
+	merged.Tolerations = append(slices.Clone(merged.Tolerations), override.Tolerations...)
+	if len(override.Resources.Limits)+len(override.Resources.Requests) > 0 {
+		merged.Resources = override.Resources
+	}
+	if override.Affinity != nil {
+		merged.Affinity = override.Affinity
+	}
+	return merged
+}
+
+func mergeStringMaps(base, override map[string]string) map[string]string {
+	if len(override) == 0 {
+		return base
+	}
+	merged := make(map[string]string, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
+}
+
+// resolveEnv replaces any synthesis env vars that use ValueFrom with the value
+// of the referenced ConfigMap or Secret key, so the executor pod's environment
+// is fully inlined. References are resolved in the composition's namespace.
+func resolveEnv(ctx context.Context, reader client.Reader, comp *apiv1.Composition) error {
+	for i, ev := range comp.Spec.SynthesisEnv {
+		if ev.ValueFrom == nil {
+			continue
+		}
+		val, err := resolveEnvSource(ctx, reader, comp.Namespace, ev.ValueFrom)
+		if err != nil {
+			return fmt.Errorf("resolving env var %q: %w", ev.Name, err)
+		}
+		comp.Spec.SynthesisEnv[i].Value = val
+		comp.Spec.SynthesisEnv[i].ValueFrom = nil
+	}
+	return nil
+}
+
+func resolveEnvSource(ctx context.Context, reader client.Reader, ns string, src *apiv1.EnvVarSource) (string, error) {
+	switch {
+	case src.ConfigMapKeyRef != nil:
+		cm := &corev1.ConfigMap{}
+		err := reader.Get(ctx, types.NamespacedName{Namespace: ns, Name: src.ConfigMapKeyRef.Name}, cm)
+		if err != nil {
+			return "", fmt.Errorf("getting configmap: %w", err)
+		}
+		val, ok := cm.Data[src.ConfigMapKeyRef.Key]
+		if !ok {
+			return "", fmt.Errorf("key %q not found in configmap %q", src.ConfigMapKeyRef.Key, src.ConfigMapKeyRef.Name)
+		}
+		return val, nil
+
+	case src.SecretKeyRef != nil:
+		secret := &corev1.Secret{}
+		err := reader.Get(ctx, types.NamespacedName{Namespace: ns, Name: src.SecretKeyRef.Name}, secret)
+		if err != nil {
+			return "", fmt.Errorf("getting secret: %w", err)
+		}
+		val, ok := secret.Data[src.SecretKeyRef.Key]
+		if !ok {
+			return "", fmt.Errorf("key %q not found in secret %q", src.SecretKeyRef.Key, src.SecretKeyRef.Name)
+		}
+		return string(val), nil
+	}
+	return "", errors.New("no configmap or secret key was referenced")
+}
+
 // filterEnv returns env taking out any items that have the same name as
 // any item in filter.
 func filterEnv(filter []corev1.EnvVar, env []apiv1.EnvVar) []apiv1.EnvVar {
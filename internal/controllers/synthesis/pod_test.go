@@ -5,6 +5,7 @@ import (
 
 	apiv1 "github.com/Azure/eno/api/v1"
 	"github.com/Azure/eno/internal/manager"
+	"github.com/Azure/eno/internal/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -90,6 +91,26 @@ var newPodTests = []struct {
 			assert.True(t, p.Spec.Containers[0].Resources.Limits["cpu"].Equal(resource.MustParse("9001")))
 		},
 	},
+	{
+		Name: "with composition label propagation",
+		Cfg: &Config{
+			CompositionLabelKeys: []string{"cost-center", "team"},
+		},
+		Comp: func() *apiv1.Composition {
+			comp := &apiv1.Composition{}
+			comp.Name = "test-composition"
+			comp.Namespace = "test-composition-ns"
+			comp.Generation = 123
+			comp.Labels = map[string]string{"cost-center": "1234", "unrelated": "value"}
+			comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "test-uuid"}
+			return comp
+		}(),
+		Assert: func(t *testing.T, p *corev1.Pod) {
+			assert.Equal(t, "1234", p.Labels["cost-center"])
+			assert.NotContains(t, p.Labels, "unrelated")
+			assert.NotContains(t, p.Labels, "team")
+		},
+	},
 	{
 		Name: "with synthesis env",
 		Comp: func() *apiv1.Composition {
@@ -103,6 +124,22 @@ var newPodTests = []struct {
 		}(),
 		Assert: func(t *testing.T, p *corev1.Pod) {
 			assert.Contains(t, p.Spec.Containers[0].Env, corev1.EnvVar{Name: "some_env", Value: "some-val"})
+			assert.NotContains(t, p.Spec.Containers[0].Env, corev1.EnvVar{Name: "DEBUG_SYNTHESIS", Value: "true"})
+		},
+	},
+	{
+		Name: "with debug synthesis",
+		Comp: func() *apiv1.Composition {
+			comp := &apiv1.Composition{}
+			comp.Name = "test-composition"
+			comp.Namespace = "test-composition-ns"
+			comp.Generation = 123
+			comp.Annotations = map[string]string{"eno.azure.io/debug-synthesis": "true"}
+			comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "test-uuid"}
+			return comp
+		}(),
+		Assert: func(t *testing.T, p *corev1.Pod) {
+			assert.Contains(t, p.Spec.Containers[0].Env, corev1.EnvVar{Name: "DEBUG_SYNTHESIS", Value: "true"})
 		},
 	},
 	{
@@ -124,6 +161,28 @@ var newPodTests = []struct {
 			assert.Contains(t, p.Spec.Containers[0].Env, corev1.EnvVar{Name: "COMPOSITION_NAME", Value: "test-composition"})
 		},
 	},
+	{
+		Name: "with synthesizer image override",
+		Cfg: &Config{
+			ExecutorImage: "executor-image",
+		},
+		Synth: &apiv1.Synthesizer{
+			Spec: apiv1.SynthesizerSpec{Image: "floating-image"},
+		},
+		Comp: func() *apiv1.Composition {
+			comp := &apiv1.Composition{}
+			comp.Name = "test-composition"
+			comp.Namespace = "test-composition-ns"
+			comp.Generation = 123
+			comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "test-uuid"}
+			comp.Spec.Synthesizer = apiv1.SynthesizerRef{Name: "test-synth", ImageOverride: "pinned-image@sha256:abc"}
+			return comp
+		}(),
+		Assert: func(t *testing.T, p *corev1.Pod) {
+			assert.Equal(t, "pinned-image@sha256:abc", p.Spec.Containers[0].Image)
+			assert.Equal(t, "executor-image", p.Spec.InitContainers[0].Image)
+		},
+	},
 	{
 		Name: "With affinity overrides",
 		Assert: func(t *testing.T, p *corev1.Pod) {
@@ -211,6 +270,42 @@ var newPodTests = []struct {
 			},
 		},
 	},
+	{
+		Name: "with composition pod overrides",
+		Synth: &apiv1.Synthesizer{
+			Spec: apiv1.SynthesizerSpec{
+				PodOverrides: apiv1.PodOverrides{
+					Labels:       map[string]string{"foo": "from-synth", "synth-only": "value"},
+					NodeSelector: map[string]string{"pool": "on-demand"},
+				},
+			},
+		},
+		Comp: func() *apiv1.Composition {
+			comp := &apiv1.Composition{}
+			comp.Name = "test-composition"
+			comp.Namespace = "test-composition-ns"
+			comp.Generation = 123
+			comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "test-uuid"}
+			comp.Spec.PodOverrides = apiv1.PodOverrides{
+				Labels:       map[string]string{"foo": "from-comp"},
+				NodeSelector: map[string]string{"pool": "spot"},
+				Tolerations: []corev1.Toleration{{
+					Key:      "spot",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				}},
+			}
+			return comp
+		}(),
+		Assert: func(t *testing.T, p *corev1.Pod) {
+			// The composition wins on conflict but synthesizer-only keys survive
+			assert.Equal(t, "from-comp", p.Labels["foo"])
+			assert.Equal(t, "value", p.Labels["synth-only"])
+			assert.Equal(t, map[string]string{"pool": "spot"}, p.Spec.NodeSelector)
+			require.Len(t, p.Spec.Tolerations, 1)
+			assert.Equal(t, "spot", p.Spec.Tolerations[0].Key)
+		},
+	},
 }
 
 func TestNewPod(t *testing.T) {
@@ -241,3 +336,45 @@ func TestNewPod(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveEnv(t *testing.T) {
+	ctx := testutil.NewContext(t)
+
+	cm := &corev1.ConfigMap{}
+	cm.Name = "test-cm"
+	cm.Namespace = "default"
+	cm.Data = map[string]string{"some-key": "cm-value"}
+
+	secret := &corev1.Secret{}
+	secret.Name = "test-secret"
+	secret.Namespace = "default"
+	secret.Data = map[string][]byte{"some-key": []byte("secret-value")}
+
+	cli := testutil.NewClient(t, cm, secret)
+
+	comp := &apiv1.Composition{}
+	comp.Namespace = "default"
+	comp.Spec.SynthesisEnv = []apiv1.EnvVar{
+		{Name: "inline", Value: "inline-value"},
+		{Name: "from_cm", ValueFrom: &apiv1.EnvVarSource{ConfigMapKeyRef: &apiv1.KeyRef{Name: "test-cm", Key: "some-key"}}},
+		{Name: "from_secret", ValueFrom: &apiv1.EnvVarSource{SecretKeyRef: &apiv1.KeyRef{Name: "test-secret", Key: "some-key"}}},
+	}
+
+	require.NoError(t, resolveEnv(ctx, cli, comp))
+	assert.Equal(t, []apiv1.EnvVar{
+		{Name: "inline", Value: "inline-value"},
+		{Name: "from_cm", Value: "cm-value"},
+		{Name: "from_secret", Value: "secret-value"},
+	}, comp.Spec.SynthesisEnv)
+
+	// Missing keys and objects are surfaced as errors
+	comp.Spec.SynthesisEnv = []apiv1.EnvVar{{Name: "from_cm", ValueFrom: &apiv1.EnvVarSource{ConfigMapKeyRef: &apiv1.KeyRef{Name: "test-cm", Key: "missing"}}}}
+	assert.ErrorContains(t, resolveEnv(ctx, cli, comp), `key "missing" not found in configmap "test-cm"`)
+
+	comp.Spec.SynthesisEnv = []apiv1.EnvVar{{Name: "from_secret", ValueFrom: &apiv1.EnvVarSource{SecretKeyRef: &apiv1.KeyRef{Name: "missing", Key: "some-key"}}}}
+	assert.ErrorContains(t, resolveEnv(ctx, cli, comp), "getting secret")
+
+	// Empty sources are rejected rather than silently resolving to ""
+	comp.Spec.SynthesisEnv = []apiv1.EnvVar{{Name: "empty", ValueFrom: &apiv1.EnvVarSource{}}}
+	assert.ErrorContains(t, resolveEnv(ctx, cli, comp), "no configmap or secret key was referenced")
+}
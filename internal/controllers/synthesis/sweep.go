@@ -0,0 +1,83 @@
+package synthesis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// orphanPodSweeper periodically deletes synthesizer pods whose composition no longer exists.
+// The pod garbage collector already handles this per-pod, but it relies on a reconcile of
+// each pod - if a composition is deleted while many of its pods exist, cleanup can lag
+// behind the event stream. The sweep covers that case independent of per-pod events.
+type orphanPodSweeper struct {
+	client   client.Client
+	interval time.Duration
+}
+
+func NewOrphanPodSweeper(mgr ctrl.Manager, interval time.Duration) error {
+	return mgr.Add(&orphanPodSweeper{
+		client:   mgr.GetClient(),
+		interval: interval,
+	})
+}
+
+func (o *orphanPodSweeper) Start(ctx context.Context) error {
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := o.sweep(ctx); err != nil {
+				logr.FromContextOrDiscard(ctx).Error(err, "unable to sweep orphaned synthesizer pods")
+			}
+		}
+	}
+}
+
+func (o *orphanPodSweeper) sweep(ctx context.Context) error {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	pods := &corev1.PodList{}
+	err := o.client.List(ctx, pods, client.HasLabels{compositionNameLabelKey})
+	if err != nil {
+		return fmt.Errorf("listing synthesizer pods: %w", err)
+	}
+
+	for _, pod := range pods.Items {
+		pod := pod
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		comp := &apiv1.Composition{}
+		comp.Name = pod.Labels[compositionNameLabelKey]
+		comp.Namespace = pod.Labels[compositionNamespaceLabelKey]
+		err := o.client.Get(ctx, client.ObjectKeyFromObject(comp), comp)
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("getting composition %s/%s: %w", comp.Namespace, comp.Name, err)
+		}
+		if err == nil && comp.DeletionTimestamp == nil {
+			continue // composition still exists
+		}
+
+		err = o.client.Delete(ctx, &pod, &client.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &pod.UID, ResourceVersion: &pod.ResourceVersion}})
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("deleting pod %q: %w", pod.Name, err)
+		}
+		logger.V(0).Info("deleted orphaned synthesizer pod", "podName", pod.Name, "podNamespace", pod.Namespace, "compositionName", comp.Name, "compositionNamespace", comp.Namespace)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package synthesis
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/testutil"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestOrphanPodSweep(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	mgr := testutil.NewManager(t)
+	cli := mgr.GetClient()
+
+	require.NoError(t, NewOrphanPodSweeper(mgr.Manager, time.Millisecond*50))
+	mgr.Start(t)
+
+	synth := &apiv1.Synthesizer{}
+	synth.Name = "test-syn"
+	synth.Spec.Image = "test-syn-image"
+	require.NoError(t, cli.Create(ctx, synth))
+
+	// The composition is never created - every pod referencing it is an orphan
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	comp.Spec.Synthesizer.Name = synth.Name
+	comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "anything"}
+
+	pods := []*corev1.Pod{}
+	for i := 0; i < 3; i++ {
+		pod := newPod(minimalTestConfig, comp, synth)
+		pod.GenerateName = ""
+		pod.Name = fmt.Sprintf("orphan-%d", i)
+		require.NoError(t, cli.Create(ctx, pod))
+		pods = append(pods, pod)
+	}
+
+	testutil.Eventually(t, func() bool {
+		for _, pod := range pods {
+			if mgr.GetAPIReader().Get(ctx, client.ObjectKeyFromObject(pod), pod) == nil {
+				return false
+			}
+		}
+		return true
+	})
+}
@@ -0,0 +1,117 @@
+package synthesis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// stuckSynthesisWatchdog re-drives syntheses that were initialized but whose
+// synthesizer pod never materialized e.g. because a scheduler hiccup dropped
+// the pod or its creation event was missed. It periodically scans for in-flight
+// syntheses past a grace period with no live pod and bumps the synthesis's
+// lastAttempt timestamp, which re-enqueues the composition with the pod
+// lifecycle controller. The scheduler's in-flight accounting isn't disturbed -
+// the synthesis UUID and attempt count are left untouched, and optimistic
+// concurrency protects against racing concurrent status writers.
+type stuckSynthesisWatchdog struct {
+	client        client.Client
+	noCacheReader client.Reader
+	podNamespace  string
+	interval      time.Duration
+	gracePeriod   time.Duration
+}
+
+func NewStuckSynthesisWatchdog(mgr ctrl.Manager, cfg *Config, interval, gracePeriod time.Duration) error {
+	return mgr.Add(&stuckSynthesisWatchdog{
+		client:        mgr.GetClient(),
+		noCacheReader: mgr.GetAPIReader(),
+		podNamespace:  cfg.PodNamespace,
+		interval:      interval,
+		gracePeriod:   gracePeriod,
+	})
+}
+
+func (w *stuckSynthesisWatchdog) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := w.sweep(ctx); err != nil {
+				logr.FromContextOrDiscard(ctx).Error(err, "unable to sweep for stuck syntheses")
+			}
+		}
+	}
+}
+
+func (w *stuckSynthesisWatchdog) sweep(ctx context.Context) error {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	comps := &apiv1.CompositionList{}
+	err := w.client.List(ctx, comps)
+	if err != nil {
+		return fmt.Errorf("listing compositions: %w", err)
+	}
+
+	now := time.Now()
+	for _, comp := range comps.Items {
+		comp := comp
+		if !w.isStuck(&comp, now) {
+			continue
+		}
+
+		// Confirm the pod is actually missing without trusting informers, since a
+		// lagging watch is indistinguishable from a pod that was never created
+		pods := &corev1.PodList{}
+		err := w.noCacheReader.List(ctx, pods, client.InNamespace(w.podNamespace), client.MatchingLabels{
+			synthesisIDLabelKey: comp.Status.InFlightSynthesis.UUID,
+		})
+		if err != nil {
+			return fmt.Errorf("listing synthesizer pods: %w", err)
+		}
+		if len(pods.Items) > 0 {
+			continue // a pod exists - even terminating pods re-enqueue the lifecycle controller when they go away
+		}
+
+		comp.Status.InFlightSynthesis.LastAttempt = ptr.To(metav1.Now())
+		err = w.client.Status().Update(ctx, &comp)
+		if err != nil {
+			return fmt.Errorf("updating composition %s/%s: %w", comp.Namespace, comp.Name, err)
+		}
+		logger.V(0).Info("re-enqueued stuck synthesis because its pod never materialized", "compositionName", comp.Name, "compositionNamespace", comp.Namespace, "synthesisID", comp.Status.InFlightSynthesis.UUID)
+	}
+
+	return nil
+}
+
+// isStuck returns true when the composition's in-flight synthesis hasn't made
+// progress for longer than the grace period. The grace period is anchored at
+// the most recent sign of progress so recently dispatched or re-driven
+// syntheses aren't bumped again prematurely.
+func (w *stuckSynthesisWatchdog) isStuck(comp *apiv1.Composition, now time.Time) bool {
+	syn := comp.Status.InFlightSynthesis
+	if comp.DeletionTimestamp != nil || syn == nil || syn.Canceled != nil || syn.Synthesized != nil || syn.Initialized == nil {
+		return false
+	}
+
+	anchor := syn.Initialized.Time
+	if syn.PodCreation != nil && syn.PodCreation.After(anchor) {
+		anchor = syn.PodCreation.Time
+	}
+	if syn.LastAttempt != nil && syn.LastAttempt.After(anchor) {
+		anchor = syn.LastAttempt.Time
+	}
+	return now.Sub(anchor) > w.gracePeriod
+}
@@ -0,0 +1,135 @@
+package synthesis
+
+import (
+	"testing"
+	"time"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestStuckSynthesisWatchdogRecovery(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	cli := testutil.NewClient(t)
+	w := &stuckSynthesisWatchdog{client: cli, noCacheReader: cli, podNamespace: "default", gracePeriod: time.Minute}
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	require.NoError(t, cli.Create(ctx, comp))
+
+	// The synthesis was dispatched and its pod was created, but the pod has since
+	// vanished without completing synthesis
+	comp.Status.InFlightSynthesis = &apiv1.Synthesis{
+		UUID:        "test-uuid",
+		Initialized: ptr.To(metav1.NewTime(time.Now().Add(-time.Hour))),
+		PodCreation: ptr.To(metav1.NewTime(time.Now().Add(-time.Hour))),
+	}
+	require.NoError(t, cli.Status().Update(ctx, comp))
+
+	// The sweep bumps lastAttempt to re-enqueue the composition for pod creation
+	require.NoError(t, w.sweep(ctx))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	require.NotNil(t, comp.Status.InFlightSynthesis.LastAttempt)
+	assert.Equal(t, "test-uuid", comp.Status.InFlightSynthesis.UUID)
+
+	// The bump anchors the grace period so the next sweep doesn't fire again
+	last := *comp.Status.InFlightSynthesis.LastAttempt
+	require.NoError(t, w.sweep(ctx))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	assert.True(t, comp.Status.InFlightSynthesis.LastAttempt.Equal(&last))
+}
+
+func TestStuckSynthesisWatchdogLivePod(t *testing.T) {
+	ctx := testutil.NewContext(t)
+	cli := testutil.NewClient(t)
+	w := &stuckSynthesisWatchdog{client: cli, noCacheReader: cli, podNamespace: "default", gracePeriod: time.Minute}
+
+	synth := &apiv1.Synthesizer{}
+	synth.Name = "test-synth"
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	require.NoError(t, cli.Create(ctx, comp))
+
+	comp.Status.InFlightSynthesis = &apiv1.Synthesis{
+		UUID:        "test-uuid",
+		Initialized: ptr.To(metav1.NewTime(time.Now().Add(-time.Hour))),
+	}
+	require.NoError(t, cli.Status().Update(ctx, comp))
+
+	pod := newPod(minimalTestConfig, comp, synth)
+	require.NoError(t, cli.Create(ctx, pod))
+
+	// The synthesis still has a pod - nothing to re-drive
+	require.NoError(t, w.sweep(ctx))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	assert.Nil(t, comp.Status.InFlightSynthesis.LastAttempt)
+}
+
+func TestStuckSynthesisWatchdogIsStuck(t *testing.T) {
+	w := &stuckSynthesisWatchdog{gracePeriod: time.Minute}
+	now := time.Now()
+	old := ptr.To(metav1.NewTime(now.Add(-time.Hour)))
+
+	tests := []struct {
+		Name     string
+		Comp     *apiv1.Composition
+		Expected bool
+	}{
+		{
+			Name:     "no synthesis",
+			Comp:     &apiv1.Composition{},
+			Expected: false,
+		},
+		{
+			Name: "past the grace period",
+			Comp: &apiv1.Composition{Status: apiv1.CompositionStatus{InFlightSynthesis: &apiv1.Synthesis{
+				Initialized: old,
+			}}},
+			Expected: true,
+		},
+		{
+			Name: "within the grace period",
+			Comp: &apiv1.Composition{Status: apiv1.CompositionStatus{InFlightSynthesis: &apiv1.Synthesis{
+				Initialized: ptr.To(metav1.NewTime(now)),
+			}}},
+			Expected: false,
+		},
+		{
+			Name: "recently re-driven",
+			Comp: &apiv1.Composition{Status: apiv1.CompositionStatus{InFlightSynthesis: &apiv1.Synthesis{
+				Initialized: old,
+				LastAttempt: ptr.To(metav1.NewTime(now)),
+			}}},
+			Expected: false,
+		},
+		{
+			Name: "canceled",
+			Comp: &apiv1.Composition{Status: apiv1.CompositionStatus{InFlightSynthesis: &apiv1.Synthesis{
+				Initialized: old,
+				Canceled:    old,
+			}}},
+			Expected: false,
+		},
+		{
+			Name: "synthesized",
+			Comp: &apiv1.Composition{Status: apiv1.CompositionStatus{InFlightSynthesis: &apiv1.Synthesis{
+				Initialized: old,
+				Synthesized: old,
+			}}},
+			Expected: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, w.isStuck(tc.Comp, now))
+		})
+	}
+}
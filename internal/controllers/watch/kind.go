@@ -3,6 +3,7 @@ package watch
 import (
 	"context"
 	"fmt"
+	"maps"
 	"math/rand"
 	"path"
 	"reflect"
@@ -15,6 +16,7 @@ import (
 	"github.com/go-logr/logr"
 	"golang.org/x/time/rate"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
@@ -125,32 +127,41 @@ func (k *KindWatchController) newResourceWatchController(parent *WatchController
 
 // buildRequests returns a reconcile request for every binding to this resource kind.
 func (k *KindWatchController) buildRequests(synth *apiv1.Synthesizer, comps ...apiv1.Composition) []reconcile.Request {
-	keys := map[string]struct{}{}
 	reqs := []reconcile.Request{}
+	appendRequest := func(nsn types.NamespacedName) {
+		req := reconcile.Request{NamespacedName: nsn}
+		if !slices.Contains(reqs, req) {
+			reqs = append(reqs, req)
+		}
+	}
+
+	keys := map[string]struct{}{}
 	for _, ref := range synth.Spec.Refs {
 		if ref.Resource.Name == "" {
 			keys[ref.Key] = struct{}{}
 			continue // ref does not have an "implicit" binding
 		}
-
-		nsn := types.NamespacedName{Namespace: ref.Resource.Namespace, Name: ref.Resource.Name}
-		req := reconcile.Request{NamespacedName: nsn}
-		if !slices.Contains(reqs, req) {
-			reqs = append(reqs, req)
-		}
+		appendRequest(types.NamespacedName{Namespace: ref.Resource.Namespace, Name: ref.Resource.Name})
 	}
 
 	for _, comp := range comps {
-		for _, binding := range comp.Spec.Bindings {
-			if _, found := keys[binding.Key]; !found {
-				continue
+		compKeys := keys
+		if len(comp.Spec.Refs) > 0 {
+			compKeys = maps.Clone(keys)
+			for _, ref := range comp.Spec.Refs {
+				if ref.Resource.Name == "" {
+					compKeys[ref.Key] = struct{}{}
+					continue
+				}
+				appendRequest(types.NamespacedName{Namespace: ref.Resource.Namespace, Name: ref.Resource.Name})
 			}
+		}
 
-			nsn := types.NamespacedName{Namespace: binding.Resource.Namespace, Name: binding.Resource.Name}
-			req := reconcile.Request{NamespacedName: nsn}
-			if !slices.Contains(reqs, req) {
-				reqs = append(reqs, req)
+		for _, binding := range comp.Spec.Bindings {
+			if _, found := compKeys[binding.Key]; !found {
+				continue
 			}
+			appendRequest(types.NamespacedName{Namespace: binding.Resource.Namespace, Name: binding.Resource.Name})
 		}
 	}
 
@@ -216,6 +227,29 @@ func (k *KindWatchController) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
+	// Compositions can declare refs of their own - cover them even when no synthesizer references this kind
+	comps := &apiv1.CompositionList{}
+	err = k.client.List(ctx, comps, client.MatchingFields{
+		manager.IdxCompositionsByRef: path.Join(k.gvk.Group, k.gvk.Version, k.gvk.Kind),
+	})
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing compositions: %w", err)
+	}
+	for _, comp := range comps.Items {
+		comp := comp
+		synth := &apiv1.Synthesizer{}
+		synth.Name = comp.Spec.Synthesizer.Name
+		err := k.client.Get(ctx, client.ObjectKeyFromObject(synth), synth)
+		if err != nil {
+			logger.Error(err, "unable to get synthesizer for composition", "compositionName", comp.Name, "compositionNamespace", comp.Namespace)
+			continue
+		}
+		modified, err := k.updateCompositions(ctx, logger, synth, meta, &apiv1.CompositionList{Items: []apiv1.Composition{comp}})
+		if modified || err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -228,9 +262,22 @@ func (k *KindWatchController) updateCompositions(ctx context.Context, logger log
 		}
 
 		revs := resource.NewInputRevisions(meta, key)
+		if binding := findBinding(&comp, key); binding != nil && (len(binding.Projection) > 0 || binding.TrackContent) {
+			// Projections and content hashes cover fields not visible in the metadata-only watch
+			obj := &unstructured.Unstructured{}
+			obj.SetGroupVersionKind(k.gvk)
+			if err := k.client.Get(ctx, client.ObjectKeyFromObject(meta), obj); err != nil {
+				return false, fmt.Errorf("getting input resource for projection: %w", err)
+			}
+			revs = resource.NewInputRevisions(obj, key, binding.Projection...)
+			if binding.TrackContent && revs.ProjectedHash == "" {
+				revs.ProjectedHash = resource.HashContent(obj)
+			}
+		}
 		if !setInputRevisions(&comp, revs) {
 			continue
 		}
+		pruneInputRevisions(&comp, synth)
 
 		// TODO: Reduce risk of conflict errors here
 		err := k.client.Status().Update(ctx, &comp)
@@ -244,6 +291,15 @@ func (k *KindWatchController) updateCompositions(ctx context.Context, logger log
 	return false, nil
 }
 
+func findBinding(comp *apiv1.Composition, key string) *apiv1.Binding {
+	for i, binding := range comp.Spec.Bindings {
+		if binding.Key == key {
+			return &comp.Spec.Bindings[i]
+		}
+	}
+	return nil
+}
+
 func findRefKey(comp *apiv1.Composition, synth *apiv1.Synthesizer, meta *metav1.PartialObjectMetadata) string {
 	var bindingKey string
 	for _, binding := range comp.Spec.Bindings {
@@ -253,7 +309,7 @@ func findRefKey(comp *apiv1.Composition, synth *apiv1.Synthesizer, meta *metav1.
 		}
 	}
 
-	for _, ref := range synth.Spec.Refs {
+	for _, ref := range comp.EffectiveRefs(synth) {
 		gvk := meta.GetObjectKind().GroupVersionKind()
 		matchesGVK := ref.Resource.Group == gvk.Group && ref.Resource.Version == gvk.Version && ref.Resource.Kind == gvk.Kind
 		matchesKey := bindingKey == ref.Key
@@ -266,6 +322,26 @@ func findRefKey(comp *apiv1.Composition, synth *apiv1.Synthesizer, meta *metav1.
 	return ""
 }
 
+// maxInputRevisions bounds the number of input revisions retained in composition status.
+// The pruning controller removes unreferenced revisions asynchronously, but it can lag
+// behind for compositions with churning inputs - enforce the bound on write as well.
+const maxInputRevisions = 64
+
+// pruneInputRevisions drops revisions of inputs that are no longer referenced once the
+// status has grown beyond maxInputRevisions. Currently-referenced inputs are always retained.
+func pruneInputRevisions(comp *apiv1.Composition, synth *apiv1.Synthesizer) {
+	if len(comp.Status.InputRevisions) <= maxInputRevisions {
+		return
+	}
+	revs := make([]apiv1.InputRevisions, 0, len(comp.Status.InputRevisions))
+	for _, ir := range comp.Status.InputRevisions {
+		if hasBindingKey(comp, synth, ir.Key) {
+			revs = append(revs, ir)
+		}
+	}
+	comp.Status.InputRevisions = revs
+}
+
 func setInputRevisions(comp *apiv1.Composition, revs *apiv1.InputRevisions) bool {
 	for i, ir := range comp.Status.InputRevisions {
 		if ir.Key != revs.Key {
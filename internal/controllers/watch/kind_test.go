@@ -1,6 +1,7 @@
 package watch
 
 import (
+	"fmt"
 	"testing"
 
 	apiv1 "github.com/Azure/eno/api/v1"
@@ -265,3 +266,27 @@ func TestBuildRequests(t *testing.T) {
 		})
 	}
 }
+
+func TestPruneInputRevisions(t *testing.T) {
+	synth := &apiv1.Synthesizer{}
+	synth.Spec.Refs = []apiv1.Ref{{Key: "current-ref"}}
+
+	comp := &apiv1.Composition{}
+	comp.Spec.Bindings = []apiv1.Binding{{Key: "current-ref", Resource: apiv1.ResourceBinding{Name: "test-resource"}}}
+
+	// Under the cap nothing is pruned
+	comp.Status.InputRevisions = []apiv1.InputRevisions{
+		{Key: "current-ref", Revision: ptr.To(1)},
+		{Key: "stale-ref", Revision: ptr.To(1)},
+	}
+	pruneInputRevisions(comp, synth)
+	assert.Len(t, comp.Status.InputRevisions, 2)
+
+	// Over the cap only stale revisions are dropped
+	comp.Status.InputRevisions = []apiv1.InputRevisions{{Key: "current-ref", Revision: ptr.To(1)}}
+	for i := 0; i <= maxInputRevisions; i++ {
+		comp.Status.InputRevisions = append(comp.Status.InputRevisions, apiv1.InputRevisions{Key: fmt.Sprintf("stale-ref-%d", i), Revision: ptr.To(i)})
+	}
+	pruneInputRevisions(comp, synth)
+	assert.Equal(t, []apiv1.InputRevisions{{Key: "current-ref", Revision: ptr.To(1)}}, comp.Status.InputRevisions)
+}
@@ -55,7 +55,7 @@ func hasBindingKey(comp *apiv1.Composition, synth *apiv1.Synthesizer, key string
 			return true
 		}
 	}
-	for _, ref := range synth.Spec.Refs {
+	for _, ref := range comp.EffectiveRefs(synth) {
 		if ref.Key == key && ref.Resource.Name != "" {
 			return true // implicit binding
 		}
@@ -22,6 +22,7 @@ func NewController(mgr ctrl.Manager) error {
 	err := ctrl.NewControllerManagedBy(mgr).
 		Named("watchControllerController").
 		Watches(&apiv1.Synthesizer{}, manager.SingleEventHandler()).
+		Watches(&apiv1.Composition{}, manager.SingleEventHandler()).
 		WithLogConstructor(manager.NewLogConstructor(mgr, "watchController")).
 		Complete(&WatchController{
 			mgr:            mgr,
@@ -54,29 +55,46 @@ func (c *WatchController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	// otherwise one bad resource reference can block the loop
 	rand.Shuffle(len(synths.Items), func(i, j int) { synths.Items[i], synths.Items[j] = synths.Items[j], synths.Items[i] })
 
-	// Start any missing controllers
-	synthsByRef := map[apiv1.ResourceRef]struct{}{}
+	comps := &apiv1.CompositionList{}
+	err = c.client.List(ctx, comps)
+	if err != nil {
+		logger.Error(err, "failed to list compositions")
+		return ctrl.Result{}, err
+	}
+
+	// Compositions can declare refs beyond their synthesizer's - watch those kinds too
+	refs := []apiv1.Ref{}
 	for _, syn := range synths.Items {
 		if syn.DeletionTimestamp != nil {
 			continue
 		}
-		for _, ref := range syn.Spec.Refs {
-			ref := ref
-			synthsByRef[ref.Resource] = struct{}{}
-
-			current := c.refControllers[ref.Resource]
-			if current != nil {
-				continue // already running
-			}
-
-			rc, err := NewKindWatchController(ctx, c, &ref.Resource)
-			if err != nil {
-				logger.Error(err, "failed to create kind watch controller", "resource", ref.Resource)
-				return ctrl.Result{}, err
-			}
-			c.refControllers[ref.Resource] = rc
-			return ctrl.Result{Requeue: true}, nil
+		refs = append(refs, syn.Spec.Refs...)
+	}
+	for _, comp := range comps.Items {
+		if comp.DeletionTimestamp != nil {
+			continue
 		}
+		refs = append(refs, comp.Spec.Refs...)
+	}
+
+	// Start any missing controllers
+	synthsByRef := map[apiv1.ResourceRef]struct{}{}
+	for _, ref := range refs {
+		ref := ref
+		synthsByRef[ref.Resource] = struct{}{}
+
+		current := c.refControllers[ref.Resource]
+		if current != nil {
+			continue // already running
+		}
+
+		rc, err := NewKindWatchController(ctx, c, &ref.Resource)
+		if err != nil {
+			logger.Error(err, "failed to create kind watch controller", "resource", ref.Resource)
+			return ctrl.Result{}, err
+		}
+		c.refControllers[ref.Resource] = rc
+		return ctrl.Result{Requeue: true}, nil
 	}
 
 	// Stop controllers that are no longer needed
@@ -3,12 +3,14 @@ package discovery
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	runtimeschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/flowcontrol"
@@ -70,6 +72,38 @@ func (c *Cache) Get(ctx context.Context, gvk schema.GroupVersionKind) (*smdschem
 	return nil, nil, nil
 }
 
+// PreferredVersion returns the version of the given group/kind that should be used
+// when talking to the apiserver: the given version when it's still served, otherwise
+// the highest-priority served version. ok is false when the group/kind is unknown.
+func (c *Cache) PreferredVersion(ctx context.Context, gvk schema.GroupVersionKind) (string, bool) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if c.current == nil || time.Since(c.lastFill) > time.Hour*24 {
+		if err := c.fillUnlocked(ctx); err != nil {
+			return "", false
+		}
+	}
+
+	if _, ok := c.typeNameMap[gvk]; ok {
+		return gvk.Version, true
+	}
+
+	var versions []string
+	for cur := range c.typeNameMap {
+		if cur.Group == gvk.Group && cur.Kind == gvk.Kind {
+			versions = append(versions, cur.Version)
+		}
+	}
+	if len(versions) == 0 {
+		return "", false
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return version.CompareKubeAwareVersionStrings(versions[i], versions[j]) > 0
+	})
+	return versions[0], true
+}
+
 func (c *Cache) fillUnlocked(ctx context.Context) error {
 	doc, err := c.client.OpenAPISchema()
 	if err != nil {
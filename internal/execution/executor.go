@@ -3,6 +3,8 @@ package execution
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	apiv1 "github.com/Azure/eno/api/v1"
@@ -24,6 +26,14 @@ type Executor struct {
 	Reader  client.Reader
 	Writer  client.Client
 	Handler SynthesizerHandle
+
+	// MaxResultBytes overrides the default cap on the total size of synthesizer
+	// results stored inline on the composition status. Zero means default.
+	MaxResultBytes int
+
+	// SchemaGetter optionally provides the cluster's OpenAPI schemas for
+	// pre-apply validation of output resources. Nil disables schema validation.
+	SchemaGetter resource.SchemaGetter
 }
 
 func (e *Executor) Synthesize(ctx context.Context, env *Env) error {
@@ -48,22 +58,76 @@ func (e *Executor) Synthesize(ctx context.Context, env *Env) error {
 		return fmt.Errorf("fetching synthesizer: %w", err)
 	}
 
+	if err := validateProtocolVersion(syn); err != nil {
+		e.recordFailure(ctx, env, comp, err)
+		return fmt.Errorf("validating synthesizer protocol version: %w", err)
+	}
+
 	input, revs, err := e.buildPodInput(ctx, comp, syn)
 	if err != nil {
 		return fmt.Errorf("building synthesizer input: %w", err)
 	}
 
+	if env.DebugSynthesis {
+		logger.V(0).Info("DEBUG starting synthesis", "synthesisUUID", env.SynthesisUUID, "inputCount", len(input.Items), "inputRevisions", fmt.Sprintf("%+v", revs))
+	}
+
+	start := time.Now()
 	output, err := e.Handler(ctx, syn, input)
 	if err != nil {
+		e.recordFailure(ctx, env, comp, err)
 		return fmt.Errorf("executing synthesizer: %w", err)
 	}
+	if env.DebugSynthesis {
+		logger.V(0).Info("DEBUG synthesizer returned", "latency", time.Since(start).Milliseconds(), "outputCount", len(output.Items), "resultCount", len(output.Results))
+	}
+
+	if err := validateOutputs(ctx, syn, output); err != nil {
+		e.recordFailure(ctx, env, comp, err)
+		return fmt.Errorf("validating synthesizer output: %w", err)
+	}
+	if err := validateReadinessOrder(ctx, output); err != nil {
+		e.recordFailure(ctx, env, comp, err)
+		return fmt.Errorf("validating synthesizer output: %w", err)
+	}
+	if err := validateOutputSchemas(ctx, e.SchemaGetter, syn, output); err != nil {
+		e.recordFailure(ctx, env, comp, err)
+		return fmt.Errorf("validating synthesizer output: %w", err)
+	}
+	if err := validateOutputQuota(syn, output); err != nil {
+		e.recordFailure(ctx, env, comp, err)
+		return fmt.Errorf("validating synthesizer output: %w", err)
+	}
+
+	// Surface non-fatal per-resource issues (e.g. readiness checks that don't
+	// compile) as warning results so typos are visible in the composition status
+	output.Results = append(output.Results, validationWarnings(ctx, output)...)
+
+	// Duplicate refs silently clobber each other - warn rather than fail since
+	// the reconciler still applies them deterministically
+	output.Results = append(output.Results, duplicateRefWarnings(ctx, syn, output)...)
 
-	sliceRefs, err := e.writeSlices(ctx, comp, output)
+	if comp.ShouldPruneStaleResources() {
+		labelOutputs(comp, output)
+	}
+
+	sliceRefs, bundleHash, err := e.writeSlices(ctx, comp, output)
 	if err != nil {
 		return err
 	}
 
-	return e.updateComposition(ctx, env, comp, syn, sliceRefs, revs, output)
+	return e.updateComposition(ctx, env, comp, syn, sliceRefs, bundleHash, revs, output)
+}
+
+// supportedProtocolVersions enumerates the KRM ResourceList contract versions
+// this build of Eno can speak.
+var supportedProtocolVersions = []string{krmv1.SchemeGroupVersion.Version}
+
+func validateProtocolVersion(syn *apiv1.Synthesizer) error {
+	if syn.Spec.ProtocolVersion == "" || slices.Contains(supportedProtocolVersions, syn.Spec.ProtocolVersion) {
+		return nil
+	}
+	return fmt.Errorf("synthesizer declares protocol version %q, which is incompatible with this version of Eno (supported: %s)", syn.Spec.ProtocolVersion, strings.Join(supportedProtocolVersions, ", "))
 }
 
 func (e *Executor) buildPodInput(ctx context.Context, comp *apiv1.Composition, syn *apiv1.Synthesizer) (*krmv1.ResourceList, []apiv1.InputRevisions, error) {
@@ -79,7 +143,7 @@ func (e *Executor) buildPodInput(ctx context.Context, comp *apiv1.Composition, s
 		APIVersion: krmv1.SchemeGroupVersion.String(),
 	}
 	revs := []apiv1.InputRevisions{}
-	for _, r := range syn.Spec.Refs {
+	for _, r := range comp.EffectiveRefs(syn) {
 		key := r.Key
 		b, ok := bindings[key]
 		if !ok {
@@ -88,13 +152,19 @@ func (e *Executor) buildPodInput(ctx context.Context, comp *apiv1.Composition, s
 
 		// Get the resource
 		start := time.Now()
-		obj := &unstructured.Unstructured{}
-		obj.SetGroupVersionKind(schema.GroupVersionKind{Group: r.Resource.Group, Version: r.Resource.Version, Kind: r.Resource.Kind})
-		obj.SetName(b.Resource.Name)
-		obj.SetNamespace(b.Resource.Namespace)
-		err := e.Reader.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		obj, err := e.resolveBinding(ctx, r, b)
 		if err != nil {
-			return nil, nil, fmt.Errorf("getting resource for ref %q: %w", key, err)
+			return nil, nil, err
+		}
+		// Store the revision to be written to the synthesis status later
+		ir := resource.NewInputRevisions(obj, key, b.Projection...)
+		if b.TrackContent && ir.ProjectedHash == "" {
+			ir.ProjectedHash = resource.HashContent(obj)
+		}
+		revs = append(revs, *ir)
+
+		if len(b.Projection) > 0 {
+			obj = resource.ProjectFields(obj, b.Projection)
 		}
 		anno := obj.GetAnnotations()
 		if anno == nil {
@@ -104,41 +174,119 @@ func (e *Executor) buildPodInput(ctx context.Context, comp *apiv1.Composition, s
 		obj.SetAnnotations(anno)
 		rl.Items = append(rl.Items, obj)
 		logger.V(0).Info("retrieved input", "key", key, "latency", time.Since(start).Abs().Milliseconds())
+	}
 
-		// Store the revision to be written to the synthesis status later
-		revs = append(revs, *resource.NewInputRevisions(obj, key))
+	if comp.ShouldExposeChangedInputs() {
+		var prev []apiv1.InputRevisions
+		if syn := comp.Status.CurrentSynthesis; syn != nil {
+			prev = syn.InputRevisions
+		}
+		keys := []any{} // []any so the unstructured object can be deep copied
+		for _, key := range changedInputKeys(prev, revs) {
+			keys = append(keys, key)
+		}
+		rl.FunctionConfig = &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion":       apiv1.SchemeGroupVersion.String(),
+			"kind":             "SynthesisMetadata",
+			"changedInputKeys": keys,
+		}}
 	}
 
 	return rl, revs, nil
 }
 
-func (e *Executor) writeSlices(ctx context.Context, comp *apiv1.Composition, rl *krmv1.ResourceList) ([]*apiv1.ResourceSliceRef, error) {
-	logger := logr.FromContextOrDiscard(ctx)
+// changedInputKeys returns the keys whose revisions differ between the last
+// synthesis and now, including keys that were added or removed entirely.
+func changedInputKeys(prev, current []apiv1.InputRevisions) []string {
+	prevByKey := map[string]apiv1.InputRevisions{}
+	for _, ir := range prev {
+		prevByKey[ir.Key] = ir
+	}
 
-	previous, err := e.fetchPreviousSlices(ctx, comp)
-	if err != nil {
-		return nil, err
+	keys := []string{}
+	for _, ir := range current {
+		old, ok := prevByKey[ir.Key]
+		if !ok || old.Less(ir) || ir.Less(old) {
+			keys = append(keys, ir.Key)
+		}
+		delete(prevByKey, ir.Key)
+	}
+	for key := range prevByKey {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+	return keys
+}
+
+// resolveBinding fetches the resource bound to a ref, either by exact name or
+// by label selector. Selectors must match exactly one resource.
+func (e *Executor) resolveBinding(ctx context.Context, r apiv1.Ref, b *apiv1.Binding) (*unstructured.Unstructured, error) {
+	gvk := schema.GroupVersionKind{Group: r.Resource.Group, Version: r.Resource.Version, Kind: r.Resource.Kind}
+
+	if b.Resource.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(b.Resource.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("parsing selector of ref %q: %w", r.Key, err)
+		}
+
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		err = e.Reader.List(ctx, list, client.InNamespace(b.Resource.Namespace), client.MatchingLabelsSelector{Selector: selector})
+		if err != nil {
+			return nil, fmt.Errorf("listing resources for ref %q: %w", r.Key, err)
+		}
+		if len(list.Items) == 0 {
+			return nil, fmt.Errorf("no resources match the selector of ref %q", r.Key)
+		}
+		if len(list.Items) > 1 {
+			return nil, fmt.Errorf("%d resources match the selector of ref %q - exactly one must match", len(list.Items), r.Key)
+		}
+		return &list.Items[0], nil
 	}
 
-	slices, err := resource.Slice(comp, previous, rl.Items, maxSliceJsonBytes)
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(b.Resource.Name)
+	obj.SetNamespace(b.Resource.Namespace)
+	if err := e.Reader.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		return nil, fmt.Errorf("getting resource for ref %q: %w", r.Key, err)
+	}
+	return obj, nil
+}
+
+func (e *Executor) writeSlices(ctx context.Context, comp *apiv1.Composition, rl *krmv1.ResourceList) ([]*apiv1.ResourceSliceRef, string, error) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	previous, err := e.fetchPreviousSlices(ctx, comp)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	sliceRefs := make([]*apiv1.ResourceSliceRef, len(slices))
-	for i, slice := range slices {
+	// Slices are written as they fill up rather than all at once, so peak memory
+	// stays bounded even for compositions that output thousands of resources.
+	// The composition status only references the slices after every write has
+	// succeeded, so partially written syntheses are never visible to consumers.
+	var sliceRefs []*apiv1.ResourceSliceRef
+	hasher := &resource.BundleHasher{}
+	err = resource.StreamSlices(comp, previous, rl.Items, maxSliceJsonBytes, func(slice *apiv1.ResourceSlice) error {
 		start := time.Now()
 
-		err = e.writeResourceSlice(ctx, slice)
+		err := e.writeResourceSlice(ctx, slice)
 		if err != nil {
-			return nil, fmt.Errorf("creating resource slice %d: %w", i, err)
+			return fmt.Errorf("creating resource slice %d: %w", len(sliceRefs), err)
 		}
 
 		logger.V(0).Info("wrote resource slice", "resourceSliceName", slice.Name, "latency", time.Since(start).Milliseconds())
-		sliceRefs[i] = &apiv1.ResourceSliceRef{Name: slice.Name}
+		sliceRefs = append(sliceRefs, &apiv1.ResourceSliceRef{Name: slice.Name})
+		hasher.Add(slice)
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
 	}
 
-	return sliceRefs, nil
+	return sliceRefs, hasher.Sum(), nil
 }
 
 // fetchPreviousSlices retrieves the previous slices from the composition's current synthesis status.
@@ -187,7 +335,7 @@ func (e *Executor) writeResourceSlice(ctx context.Context, slice *apiv1.Resource
 	})
 }
 
-func (e *Executor) updateComposition(ctx context.Context, env *Env, oldComp *apiv1.Composition, syn *apiv1.Synthesizer, refs []*apiv1.ResourceSliceRef, revs []apiv1.InputRevisions, rl *krmv1.ResourceList) error {
+func (e *Executor) updateComposition(ctx context.Context, env *Env, oldComp *apiv1.Composition, syn *apiv1.Synthesizer, refs []*apiv1.ResourceSliceRef, bundleHash string, revs []apiv1.InputRevisions, rl *krmv1.ResourceList) error {
 	logger := logr.FromContextOrDiscard(ctx)
 	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 		comp := &apiv1.Composition{}
@@ -200,20 +348,31 @@ func (e *Executor) updateComposition(ctx context.Context, env *Env, oldComp *api
 			return nil
 		}
 
-		now := metav1.Now()
-		comp.Status.InFlightSynthesis.Synthesized = &now
-		comp.Status.InFlightSynthesis.ResourceSlices = refs
-		comp.Status.InFlightSynthesis.ObservedSynthesizerGeneration = syn.Generation
-		comp.Status.InFlightSynthesis.InputRevisions = revs
+		results := make([]apiv1.Result, 0, len(rl.Results))
 		for _, result := range rl.Results {
-			comp.Status.InFlightSynthesis.Results = append(comp.Status.InFlightSynthesis.Results, apiv1.Result{
+			results = append(results, apiv1.Result{
 				Message:  result.Message,
 				Severity: result.Severity,
 				Tags:     result.Tags,
 			})
 		}
+		results, overflowRef, err := e.boundResults(ctx, comp, results)
+		if err != nil {
+			return err
+		}
+
+		now := metav1.Now()
+		comp.Status.InFlightSynthesis.Synthesized = &now
+		comp.Status.InFlightSynthesis.ResourceSlices = refs
+		comp.Status.InFlightSynthesis.ObservedSynthesizerGeneration = syn.Generation
+		comp.Status.InFlightSynthesis.InputRevisions = revs
+		comp.Status.InFlightSynthesis.BundleHash = bundleHash
+		comp.Status.InFlightSynthesis.Results = append(comp.Status.InFlightSynthesis.Results, results...)
+		comp.Status.InFlightSynthesis.ResultOverflow = overflowRef
+		comp.Status.PromotedTags = promoteResultTags(comp, rl)
 
 		// Swap pending->current->previous syntheses
+		retainSynthesisHistory(comp)
 		comp.Status.PreviousSynthesis = comp.Status.CurrentSynthesis
 		comp.Status.CurrentSynthesis = comp.Status.InFlightSynthesis
 		comp.Status.InFlightSynthesis = nil
@@ -228,6 +387,98 @@ func (e *Executor) updateComposition(ctx context.Context, env *Env, oldComp *api
 	})
 }
 
+// retainSynthesisHistory records the synthesis about to fall out of the
+// current/previous pair in status.synthesisHistory, trimming it so the total
+// number of retained generations doesn't exceed spec.historyLimit.
+func retainSynthesisHistory(comp *apiv1.Composition) {
+	limit := 0
+	if comp.Spec.HistoryLimit != nil {
+		limit = *comp.Spec.HistoryLimit - 2 // the current and previous syntheses are always retained
+	}
+	if limit <= 0 {
+		comp.Status.SynthesisHistory = nil
+		return
+	}
+	if syn := comp.Status.PreviousSynthesis; syn != nil {
+		comp.Status.SynthesisHistory = append([]apiv1.RetainedSynthesis{{
+			UUID:           syn.UUID,
+			Synthesized:    syn.Synthesized,
+			ResourceSlices: syn.ResourceSlices,
+		}}, comp.Status.SynthesisHistory...)
+	}
+	if len(comp.Status.SynthesisHistory) > limit {
+		comp.Status.SynthesisHistory = comp.Status.SynthesisHistory[:limit]
+	}
+}
+
+// promoteResultTags surfaces selected synthesizer result tags in the composition's status.
+// Only tag keys listed in the composition's spec are promoted.
+func promoteResultTags(comp *apiv1.Composition, rl *krmv1.ResourceList) map[string]string {
+	if len(comp.Spec.PromotedResultTags) == 0 {
+		return nil
+	}
+	promoted := map[string]string{}
+	for _, result := range rl.Results {
+		for _, key := range comp.Spec.PromotedResultTags {
+			if val, ok := result.Tags[key]; ok {
+				promoted[key] = val
+			}
+		}
+	}
+	if len(promoted) == 0 {
+		return nil
+	}
+	return promoted
+}
+
+// labelOutputs stamps synthesized resources with the identity labels consumed by label-based pruning.
+func labelOutputs(comp *apiv1.Composition, rl *krmv1.ResourceList) {
+	for _, item := range rl.Items {
+		if item.GetAnnotations()["eno.azure.io/disable-synthesis-labels"] == "true" {
+			// Some resources can't tolerate extra labels e.g. strict label selectors.
+			// They opt out of prune-by-label support entirely.
+			continue
+		}
+		labels := item.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[resource.CompositionNameLabelKey] = comp.Name
+		labels[resource.CompositionNamespaceLabelKey] = comp.Namespace
+		labels[resource.SynthesisUUIDLabelKey] = comp.Status.InFlightSynthesis.UUID
+		item.SetLabels(labels)
+	}
+}
+
+// recordFailure surfaces a synthesizer failure on the in-flight synthesis so the error -
+// including any stderr written by the synthesizer process - isn't lost when the pod restarts.
+func (e *Executor) recordFailure(ctx context.Context, env *Env, oldComp *apiv1.Composition, cause error) {
+	logger := logr.FromContextOrDiscard(ctx)
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		comp := &apiv1.Composition{}
+		err := e.Reader.Get(ctx, client.ObjectKeyFromObject(oldComp), comp)
+		if err != nil {
+			return err
+		}
+		if _, skip := skipSynthesis(comp, env); skip {
+			return nil
+		}
+
+		msg := cause.Error()
+		if max := e.maxResultBytes(); len(msg) > max {
+			msg = msg[:max] + " [truncated]"
+		}
+		comp.Status.InFlightSynthesis.Results = []apiv1.Result{{
+			Message:  msg,
+			Severity: krmv1.ResultSeverityError,
+		}}
+		return e.Writer.Status().Update(ctx, comp)
+	})
+	if err != nil {
+		logger.Error(err, "unable to record synthesis failure")
+	}
+}
+
 func skipSynthesis(comp *apiv1.Composition, env *Env) (string, bool) {
 	synthesis := comp.Status.InFlightSynthesis
 	if synthesis == nil {
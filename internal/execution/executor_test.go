@@ -2,18 +2,24 @@ package execution
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"slices"
 	"strings"
 	"testing"
 
 	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/resource"
 	krmv1 "github.com/Azure/eno/pkg/krm/functions/api/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -513,3 +519,547 @@ func TestDeleteResource(t *testing.T) {
 	assert.NotEqual(t, -1, deletedIdx)
 	assert.Equal(t, rs.Spec.Resources[deletedIdx].Deleted, true)
 }
+
+func TestPromotedResultTags(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1.SchemeBuilder.AddToScheme(scheme))
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&apiv1.ResourceSlice{}, &apiv1.Composition{}).
+		Build()
+
+	syn := &apiv1.Synthesizer{}
+	syn.Name = "test-synth"
+	err := cli.Create(ctx, syn)
+	require.NoError(t, err)
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	comp.Spec.Synthesizer.Name = syn.Name
+	comp.Spec.PromotedResultTags = []string{"version"}
+	err = cli.Create(ctx, comp)
+	require.NoError(t, err)
+
+	comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "test-uuid"}
+	err = cli.Status().Update(ctx, comp)
+	require.NoError(t, err)
+
+	e := &Executor{
+		Reader: cli,
+		Writer: cli,
+		Handler: func(ctx context.Context, s *apiv1.Synthesizer, rl *krmv1.ResourceList) (*krmv1.ResourceList, error) {
+			return &krmv1.ResourceList{
+				Results: []*krmv1.Result{{
+					Message:  "computed version",
+					Severity: "info",
+					Tags:     map[string]string{"version": "v1.2.3", "internal": "should-not-propagate"},
+				}},
+			}, nil
+		},
+	}
+	env := &Env{
+		CompositionName:      comp.Name,
+		CompositionNamespace: comp.Namespace,
+		SynthesisUUID:        comp.Status.InFlightSynthesis.UUID,
+	}
+
+	err = e.Synthesize(ctx, env)
+	require.NoError(t, err)
+
+	err = cli.Get(ctx, client.ObjectKeyFromObject(comp), comp)
+	require.NoError(t, err)
+
+	// Only the configured tag key is promoted
+	assert.Equal(t, map[string]string{"version": "v1.2.3"}, comp.Status.PromotedTags)
+}
+
+// TestWithCompositionRefs proves that refs declared by the composition itself are resolved
+// for synthesis inputs and tracked via input revisions, even when the synthesizer doesn't
+// declare them.
+func TestWithCompositionRefs(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1.SchemeBuilder.AddToScheme(scheme))
+	require.NoError(t, corev1.SchemeBuilder.AddToScheme(scheme))
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&apiv1.ResourceSlice{}, &apiv1.Composition{}).
+		Build()
+
+	input := &corev1.ConfigMap{}
+	input.Name = "test-input"
+	input.Namespace = "default"
+	err := cli.Create(ctx, input)
+	require.NoError(t, err)
+
+	syn := &apiv1.Synthesizer{}
+	syn.Name = "test-synth"
+	err = cli.Create(ctx, syn)
+	require.NoError(t, err)
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	comp.Spec.Refs = []apiv1.Ref{{
+		Key:      "extra",
+		Resource: apiv1.ResourceRef{Kind: "ConfigMap", Version: "v1"},
+	}}
+	comp.Spec.Bindings = []apiv1.Binding{{
+		Key: "extra",
+		Resource: apiv1.ResourceBinding{
+			Name:      input.Name,
+			Namespace: input.Namespace,
+		},
+	}}
+	comp.Spec.Synthesizer.Name = syn.Name
+	err = cli.Create(ctx, comp)
+	require.NoError(t, err)
+
+	comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "test-uuid"}
+	err = cli.Status().Update(ctx, comp)
+	require.NoError(t, err)
+
+	e := &Executor{
+		Reader: cli,
+		Writer: cli,
+		Handler: func(ctx context.Context, s *apiv1.Synthesizer, rl *krmv1.ResourceList) (*krmv1.ResourceList, error) {
+			require.Len(t, rl.Items, 1)
+			assert.Equal(t, "test-input", rl.Items[0].GetName())
+			assert.Equal(t, map[string]string{"eno.azure.io/input-key": "extra"}, rl.Items[0].GetAnnotations())
+			return &krmv1.ResourceList{}, nil
+		},
+	}
+	env := &Env{
+		CompositionName:      comp.Name,
+		CompositionNamespace: comp.Namespace,
+		SynthesisUUID:        comp.Status.InFlightSynthesis.UUID,
+	}
+
+	err = e.Synthesize(ctx, env)
+	require.NoError(t, err)
+
+	err = cli.Get(ctx, client.ObjectKeyFromObject(comp), comp)
+	require.NoError(t, err)
+	require.Len(t, comp.Status.CurrentSynthesis.InputRevisions, 1)
+	assert.Equal(t, "extra", comp.Status.CurrentSynthesis.InputRevisions[0].Key)
+}
+
+func TestFailureRecordsStderr(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1.SchemeBuilder.AddToScheme(scheme))
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&apiv1.ResourceSlice{}, &apiv1.Composition{}).
+		Build()
+
+	syn := &apiv1.Synthesizer{}
+	syn.Name = "test-synth"
+	require.NoError(t, cli.Create(ctx, syn))
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	comp.Spec.Synthesizer.Name = syn.Name
+	require.NoError(t, cli.Create(ctx, comp))
+
+	comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "test-uuid"}
+	require.NoError(t, cli.Status().Update(ctx, comp))
+
+	e := &Executor{
+		Reader: cli,
+		Writer: cli,
+		Handler: func(ctx context.Context, s *apiv1.Synthesizer, rl *krmv1.ResourceList) (*krmv1.ResourceList, error) {
+			return nil, &ExecError{Err: errors.New("exit status 1"), Stderr: "some diagnostic"}
+		},
+	}
+	env := &Env{
+		CompositionName:      comp.Name,
+		CompositionNamespace: comp.Namespace,
+		SynthesisUUID:        comp.Status.InFlightSynthesis.UUID,
+	}
+
+	err := e.Synthesize(ctx, env)
+	require.Error(t, err)
+
+	// The stderr text is preserved on the in-flight synthesis
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	require.NotNil(t, comp.Status.InFlightSynthesis)
+	require.Len(t, comp.Status.InFlightSynthesis.Results, 1)
+	assert.Equal(t, "exit status 1 (stderr: some diagnostic)", comp.Status.InFlightSynthesis.Results[0].Message)
+	assert.Equal(t, string(krmv1.ResultSeverityError), comp.Status.InFlightSynthesis.Results[0].Severity)
+}
+
+func TestLabelOutputsOptOut(t *testing.T) {
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "test-uuid"}
+
+	labeled := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": "labeled", "namespace": "default"},
+	}}
+	optedOut := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]any{
+			"name":        "opted-out",
+			"namespace":   "default",
+			"annotations": map[string]any{"eno.azure.io/disable-synthesis-labels": "true"},
+		},
+	}}
+	rl := &krmv1.ResourceList{Items: []*unstructured.Unstructured{labeled, optedOut}}
+
+	labelOutputs(comp, rl)
+	assert.Equal(t, "test-uuid", labeled.GetLabels()[resource.SynthesisUUIDLabelKey])
+	assert.Empty(t, optedOut.GetLabels())
+}
+
+func TestProtocolVersion(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1.SchemeBuilder.AddToScheme(scheme))
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&apiv1.ResourceSlice{}, &apiv1.Composition{}).
+		Build()
+
+	syn := &apiv1.Synthesizer{}
+	syn.Name = "test-synth"
+	syn.Spec.ProtocolVersion = "v9"
+	require.NoError(t, cli.Create(ctx, syn))
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	comp.Spec.Synthesizer.Name = syn.Name
+	require.NoError(t, cli.Create(ctx, comp))
+
+	comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "test-uuid"}
+	require.NoError(t, cli.Status().Update(ctx, comp))
+
+	var handlerCalled bool
+	e := &Executor{
+		Reader: cli,
+		Writer: cli,
+		Handler: func(ctx context.Context, s *apiv1.Synthesizer, rl *krmv1.ResourceList) (*krmv1.ResourceList, error) {
+			handlerCalled = true
+			return &krmv1.ResourceList{}, nil
+		},
+	}
+	env := &Env{
+		CompositionName:      comp.Name,
+		CompositionNamespace: comp.Namespace,
+		SynthesisUUID:        comp.Status.InFlightSynthesis.UUID,
+	}
+
+	// Unsupported versions fail before the synthesizer is executed
+	err := e.Synthesize(ctx, env)
+	require.ErrorContains(t, err, "incompatible with this version of Eno")
+	assert.False(t, handlerCalled)
+
+	// Supported versions proceed
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(syn), syn))
+	syn.Spec.ProtocolVersion = "v1"
+	require.NoError(t, cli.Update(ctx, syn))
+	require.NoError(t, e.Synthesize(ctx, env))
+	assert.True(t, handlerCalled)
+}
+
+func TestWithSelectorBoundInput(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1.SchemeBuilder.AddToScheme(scheme))
+	require.NoError(t, corev1.SchemeBuilder.AddToScheme(scheme))
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&apiv1.ResourceSlice{}, &apiv1.Composition{}).
+		Build()
+
+	input := &corev1.ConfigMap{}
+	input.Name = "labeled-input"
+	input.Namespace = "default"
+	input.Labels = map[string]string{"tier": "config"}
+	require.NoError(t, cli.Create(ctx, input))
+
+	unrelated := &corev1.ConfigMap{}
+	unrelated.Name = "unrelated"
+	unrelated.Namespace = "default"
+	require.NoError(t, cli.Create(ctx, unrelated))
+
+	syn := &apiv1.Synthesizer{}
+	syn.Name = "test-synth"
+	syn.Spec.Refs = []apiv1.Ref{{
+		Key:      "foo",
+		Resource: apiv1.ResourceRef{Kind: "ConfigMap", Version: "v1"},
+	}}
+	require.NoError(t, cli.Create(ctx, syn))
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	comp.Spec.Bindings = []apiv1.Binding{{
+		Key: "foo",
+		Resource: apiv1.ResourceBinding{
+			Namespace: "default",
+			Selector:  &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "config"}},
+		},
+	}}
+	comp.Spec.Synthesizer.Name = syn.Name
+	require.NoError(t, cli.Create(ctx, comp))
+
+	comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "test-uuid"}
+	require.NoError(t, cli.Status().Update(ctx, comp))
+
+	var handlerCalled bool
+	e := &Executor{
+		Reader: cli,
+		Writer: cli,
+		Handler: func(ctx context.Context, s *apiv1.Synthesizer, rl *krmv1.ResourceList) (*krmv1.ResourceList, error) {
+			handlerCalled = true
+			require.Len(t, rl.Items, 1)
+			assert.Equal(t, "labeled-input", rl.Items[0].GetName())
+			return &krmv1.ResourceList{}, nil
+		},
+	}
+	env := &Env{
+		CompositionName:      comp.Name,
+		CompositionNamespace: comp.Namespace,
+		SynthesisUUID:        comp.Status.InFlightSynthesis.UUID,
+	}
+
+	require.NoError(t, e.Synthesize(ctx, env))
+	assert.True(t, handlerCalled)
+
+	resynthesize := func(uuid string) error {
+		require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+		comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: uuid}
+		require.NoError(t, cli.Status().Update(ctx, comp))
+		env.SynthesisUUID = uuid
+		return e.Synthesize(ctx, env)
+	}
+
+	// Multiple matches are rejected
+	unrelated.Labels = map[string]string{"tier": "config"}
+	require.NoError(t, cli.Update(ctx, unrelated))
+	err := resynthesize("test-uuid-2")
+	require.ErrorContains(t, err, `2 resources match the selector of ref "foo" - exactly one must match`)
+
+	// Zero matches are rejected
+	require.NoError(t, cli.Delete(ctx, input))
+	require.NoError(t, cli.Delete(ctx, unrelated))
+	err = resynthesize("test-uuid-3")
+	require.ErrorContains(t, err, `no resources match the selector of ref "foo"`)
+}
+
+func TestLargeOutputSlicing(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1.SchemeBuilder.AddToScheme(scheme))
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&apiv1.ResourceSlice{}, &apiv1.Composition{}).
+		Build()
+
+	syn := &apiv1.Synthesizer{}
+	syn.Name = "test-synth"
+	require.NoError(t, cli.Create(ctx, syn))
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	comp.Spec.Synthesizer.Name = syn.Name
+	require.NoError(t, cli.Create(ctx, comp))
+
+	comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "test-uuid"}
+	require.NoError(t, cli.Status().Update(ctx, comp))
+
+	// Enough output to overflow several slices
+	const outputCount = 64
+	e := &Executor{
+		Reader: cli,
+		Writer: cli,
+		Handler: func(ctx context.Context, s *apiv1.Synthesizer, rl *krmv1.ResourceList) (*krmv1.ResourceList, error) {
+			out := &krmv1.ResourceList{}
+			for i := 0; i < outputCount; i++ {
+				out.Items = append(out.Items, &unstructured.Unstructured{
+					Object: map[string]any{
+						"apiVersion": "v1",
+						"kind":       "ConfigMap",
+						"metadata": map[string]any{
+							"name":      fmt.Sprintf("test-%d", i),
+							"namespace": "default",
+						},
+						"data": map[string]any{"padding": strings.Repeat("a", 1024*32)},
+					},
+				})
+			}
+			return out, nil
+		},
+	}
+	env := &Env{
+		CompositionName:      comp.Name,
+		CompositionNamespace: comp.Namespace,
+		SynthesisUUID:        comp.Status.InFlightSynthesis.UUID,
+	}
+
+	require.NoError(t, e.Synthesize(ctx, env))
+
+	// The composition references every slice, and the slices cover every output
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	require.NotNil(t, comp.Status.CurrentSynthesis)
+	assert.Greater(t, len(comp.Status.CurrentSynthesis.ResourceSlices), 1)
+
+	resources := 0
+	for _, ref := range comp.Status.CurrentSynthesis.ResourceSlices {
+		slice := &apiv1.ResourceSlice{}
+		slice.Name = ref.Name
+		slice.Namespace = comp.Namespace
+		require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(slice), slice))
+
+		bytes := 0
+		for _, res := range slice.Spec.Resources {
+			bytes += len(res.Manifest)
+		}
+		assert.Less(t, bytes, maxSliceJsonBytes+1024*64)
+		resources += len(slice.Spec.Resources)
+	}
+	assert.Equal(t, outputCount, resources)
+}
+
+func TestResultOverflow(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1.SchemeBuilder.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&apiv1.ResourceSlice{}, &apiv1.Composition{}).
+		Build()
+
+	syn := &apiv1.Synthesizer{}
+	syn.Name = "test-synth"
+	require.NoError(t, cli.Create(ctx, syn))
+
+	comp := &apiv1.Composition{}
+	comp.Name = "test-comp"
+	comp.Namespace = "default"
+	comp.Spec.Synthesizer.Name = syn.Name
+	require.NoError(t, cli.Create(ctx, comp))
+
+	comp.Status.InFlightSynthesis = &apiv1.Synthesis{UUID: "test-uuid"}
+	require.NoError(t, cli.Status().Update(ctx, comp))
+
+	// 10 noisy info results plus one error that must survive truncation
+	const resultCount = 11
+	e := &Executor{
+		Reader:         cli,
+		Writer:         cli,
+		MaxResultBytes: 512,
+		Handler: func(ctx context.Context, s *apiv1.Synthesizer, rl *krmv1.ResourceList) (*krmv1.ResourceList, error) {
+			out := &krmv1.ResourceList{}
+			for i := 0; i < resultCount-1; i++ {
+				out.Results = append(out.Results, &krmv1.Result{Message: strings.Repeat("x", 200), Severity: krmv1.ResultSeverityInfo})
+			}
+			out.Results = append(out.Results, &krmv1.Result{Message: "the error", Severity: krmv1.ResultSeverityError})
+			return out, nil
+		},
+	}
+	env := &Env{
+		CompositionName:      comp.Name,
+		CompositionNamespace: comp.Namespace,
+		SynthesisUUID:        comp.Status.InFlightSynthesis.UUID,
+	}
+	require.NoError(t, e.Synthesize(ctx, env))
+
+	// The inline results stay under the cap and keep the error
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(comp), comp))
+	require.NotNil(t, comp.Status.CurrentSynthesis)
+	results := comp.Status.CurrentSynthesis.Results
+	assert.Less(t, len(results), resultCount)
+	total := 0
+	foundError := false
+	for _, res := range results {
+		total += len(res.Message) + len(res.Severity)
+		foundError = foundError || res.Severity == string(krmv1.ResultSeverityError)
+	}
+	assert.LessOrEqual(t, total, 512)
+	assert.True(t, foundError)
+
+	// The full set is spilled to the referenced configmap
+	require.NotEmpty(t, comp.Status.CurrentSynthesis.ResultOverflow)
+	cm := &corev1.ConfigMap{}
+	cm.Name = comp.Status.CurrentSynthesis.ResultOverflow
+	cm.Namespace = comp.Namespace
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(cm), cm))
+	spilled := []apiv1.Result{}
+	require.NoError(t, json.Unmarshal([]byte(cm.Data["results"]), &spilled))
+	assert.Len(t, spilled, resultCount)
+}
+
+func TestRetainSynthesisHistory(t *testing.T) {
+	now := metav1.Now()
+	comp := &apiv1.Composition{}
+	comp.Status.PreviousSynthesis = &apiv1.Synthesis{
+		UUID:           "uuid-1",
+		Synthesized:    &now,
+		ResourceSlices: []*apiv1.ResourceSliceRef{{Name: "slice-1"}},
+	}
+
+	// No limit - history stays empty
+	retainSynthesisHistory(comp)
+	assert.Nil(t, comp.Status.SynthesisHistory)
+
+	// A limit of 2 only covers the current/previous pair
+	comp.Spec.HistoryLimit = ptr.To(2)
+	retainSynthesisHistory(comp)
+	assert.Nil(t, comp.Status.SynthesisHistory)
+
+	// Larger limits retain the synthesis falling out of the pair, newest first
+	comp.Spec.HistoryLimit = ptr.To(3)
+	retainSynthesisHistory(comp)
+	require.Len(t, comp.Status.SynthesisHistory, 1)
+	assert.Equal(t, "uuid-1", comp.Status.SynthesisHistory[0].UUID)
+	assert.Equal(t, []*apiv1.ResourceSliceRef{{Name: "slice-1"}}, comp.Status.SynthesisHistory[0].ResourceSlices)
+
+	comp.Status.PreviousSynthesis = &apiv1.Synthesis{UUID: "uuid-2"}
+	retainSynthesisHistory(comp)
+	require.Len(t, comp.Status.SynthesisHistory, 1)
+	assert.Equal(t, "uuid-2", comp.Status.SynthesisHistory[0].UUID)
+
+	// Removing the limit clears the history
+	comp.Spec.HistoryLimit = nil
+	retainSynthesisHistory(comp)
+	assert.Nil(t, comp.Status.SynthesisHistory)
+}
+
+func TestChangedInputKeys(t *testing.T) {
+	prev := []apiv1.InputRevisions{
+		{Key: "unchanged", ResourceVersion: "1"},
+		{Key: "bumped", ResourceVersion: "1"},
+		{Key: "removed", ResourceVersion: "1"},
+		{Key: "revision", Revision: ptr.To(1)},
+	}
+	current := []apiv1.InputRevisions{
+		{Key: "unchanged", ResourceVersion: "1"},
+		{Key: "bumped", ResourceVersion: "2"},
+		{Key: "added", ResourceVersion: "1"},
+		{Key: "revision", Revision: ptr.To(2)},
+	}
+	assert.Equal(t, []string{"added", "bumped", "removed", "revision"}, changedInputKeys(prev, current))
+
+	// No previous synthesis - everything is new
+	assert.Equal(t, []string{"added"}, changedInputKeys(nil, []apiv1.InputRevisions{{Key: "added", ResourceVersion: "1"}}))
+	assert.Equal(t, []string{}, changedInputKeys(nil, nil))
+}
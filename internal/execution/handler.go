@@ -4,17 +4,24 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"strings"
 
 	apiv1 "github.com/Azure/eno/api/v1"
 	krmv1 "github.com/Azure/eno/pkg/krm/functions/api/v1"
 )
 
+// maxStderrBytes bounds the amount of synthesizer stderr retained for failure messages.
+const maxStderrBytes = 2048
+
 type Env struct {
 	CompositionName      string
 	CompositionNamespace string
 	SynthesisUUID        string
+	DebugSynthesis       bool
 }
 
 func LoadEnv() *Env {
@@ -22,11 +29,42 @@ func LoadEnv() *Env {
 		CompositionName:      os.Getenv("COMPOSITION_NAME"),
 		CompositionNamespace: os.Getenv("COMPOSITION_NAMESPACE"),
 		SynthesisUUID:        os.Getenv("SYNTHESIS_UUID"),
+		DebugSynthesis:       os.Getenv("DEBUG_SYNTHESIS") == "true",
 	}
 }
 
 type SynthesizerHandle func(context.Context, *apiv1.Synthesizer, *krmv1.ResourceList) (*krmv1.ResourceList, error)
 
+// ExecError is returned when the synthesizer process exits non-zero. It carries the tail of
+// the process's stderr since that's usually the most useful debugging context.
+type ExecError struct {
+	Err    error
+	Stderr string
+}
+
+func (e *ExecError) Error() string {
+	if e.Stderr == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s (stderr: %s)", e.Err, e.Stderr)
+}
+
+func (e *ExecError) Unwrap() error { return e.Err }
+
+// tailBuffer retains the last limit bytes written to it.
+type tailBuffer struct {
+	limit int
+	buf   bytes.Buffer
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	n, err := t.buf.Write(p)
+	if extra := t.buf.Len() - t.limit; extra > 0 {
+		t.buf.Next(extra)
+	}
+	return n, err
+}
+
 func NewExecHandler() SynthesizerHandle {
 	return func(ctx context.Context, s *apiv1.Synthesizer, rl *krmv1.ResourceList) (*krmv1.ResourceList, error) {
 		stdin := &bytes.Buffer{}
@@ -48,13 +86,14 @@ func NewExecHandler() SynthesizerHandle {
 			defer cancel()
 		}
 
+		stderr := &tailBuffer{limit: maxStderrBytes}
 		cmd := exec.CommandContext(ctx, command[0], command[1:]...)
 		cmd.Stdin = stdin
-		cmd.Stderr = os.Stdout // logger uses stderr, so use stdout to avoid race condition
+		cmd.Stderr = io.MultiWriter(os.Stdout, stderr) // logger uses stderr, so use stdout to avoid race condition
 		cmd.Stdout = stdout
 		err = cmd.Run()
 		if err != nil {
-			return nil, err
+			return nil, &ExecError{Err: err, Stderr: strings.TrimSpace(stderr.buf.String())}
 		}
 
 		output := &krmv1.ResourceList{}
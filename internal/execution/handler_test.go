@@ -55,3 +55,18 @@ func TestExecHandlerEmpty(t *testing.T) {
 	_, err := handle(context.Background(), syn, rl)
 	require.EqualError(t, err, "exec: \"synthesize\": executable file not found in $PATH")
 }
+
+func TestExecHandlerStderr(t *testing.T) {
+	handle := NewExecHandler()
+
+	syn := &apiv1.Synthesizer{}
+	syn.Spec.Command = []string{"/bin/sh", "-c", "echo some diagnostic >&2; exit 1"}
+	rl := &krmv1.ResourceList{}
+
+	_, err := handle(context.Background(), syn, rl)
+	require.EqualError(t, err, "exit status 1 (stderr: some diagnostic)")
+
+	execErr := &ExecError{}
+	require.ErrorAs(t, err, &execErr)
+	require.Equal(t, "some diagnostic", execErr.Stderr)
+}
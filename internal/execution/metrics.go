@@ -0,0 +1,19 @@
+package execution
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	duplicateRefs = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "eno_synthesis_duplicate_refs_total",
+			Help: "Number of synthesized refs that were produced by more than one output resource",
+		}, []string{"synthesizer"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(duplicateRefs)
+}
@@ -0,0 +1,119 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	krmv1 "github.com/Azure/eno/pkg/krm/functions/api/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultMaxResultBytes caps the total bytes of synthesizer results persisted
+// inline on the composition status. Chatty synthesizers can otherwise bloat the
+// composition past etcd's object size limits.
+const defaultMaxResultBytes = 1024 * 32
+
+func (e *Executor) maxResultBytes() int {
+	if e.MaxResultBytes > 0 {
+		return e.MaxResultBytes
+	}
+	return defaultMaxResultBytes
+}
+
+// boundResults enforces the inline diagnostic size cap. When the results don't
+// fit, the full set is spilled to a ConfigMap and the returned slice keeps only
+// what fits inline - preferring the most severe and most recent results.
+func (e *Executor) boundResults(ctx context.Context, comp *apiv1.Composition, results []apiv1.Result) ([]apiv1.Result, string, error) {
+	budget := e.maxResultBytes()
+	total := 0
+	for _, res := range results {
+		total += resultSize(res)
+	}
+	if total <= budget {
+		return results, "", nil
+	}
+
+	name, err := e.writeResultOverflow(ctx, comp, results)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Errors are kept over other severities, newer results over older ones.
+	// Original ordering is preserved for whatever fits.
+	keep := map[int]struct{}{}
+	for _, errorsOnly := range []bool{true, false} {
+		for i := len(results) - 1; i >= 0; i-- {
+			if _, ok := keep[i]; ok {
+				continue
+			}
+			if errorsOnly != (results[i].Severity == string(krmv1.ResultSeverityError)) {
+				continue
+			}
+			if size := resultSize(results[i]); size <= budget {
+				keep[i] = struct{}{}
+				budget -= size
+			}
+		}
+	}
+
+	inline := make([]apiv1.Result, 0, len(keep))
+	for i, res := range results {
+		if _, ok := keep[i]; ok {
+			inline = append(inline, res)
+		}
+	}
+	return inline, name, nil
+}
+
+// writeResultOverflow upserts a ConfigMap holding the full set of results.
+// An unstructured representation is used since the executor's scheme only
+// covers Eno's own types.
+func (e *Executor) writeResultOverflow(ctx context.Context, comp *apiv1.Composition, results []apiv1.Result) (string, error) {
+	js, err := json.Marshal(results)
+	if err != nil {
+		return "", fmt.Errorf("encoding results: %w", err)
+	}
+
+	cm := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"data":       map[string]any{"results": string(js)},
+	}}
+	cm.SetName(comp.Name + "-diagnostics")
+	cm.SetNamespace(comp.Namespace)
+	cm.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion: apiv1.SchemeGroupVersion.Identifier(),
+		Kind:       "Composition",
+		Name:       comp.Name,
+		UID:        comp.UID,
+	}})
+
+	err = e.Writer.Create(ctx, cm.DeepCopy())
+	if errors.IsAlreadyExists(err) {
+		existing := &unstructured.Unstructured{}
+		existing.SetAPIVersion("v1")
+		existing.SetKind("ConfigMap")
+		err = e.Reader.Get(ctx, client.ObjectKeyFromObject(cm), existing)
+		if err == nil {
+			cm.SetResourceVersion(existing.GetResourceVersion())
+			err = e.Writer.Update(ctx, cm)
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("writing result overflow configmap: %w", err)
+	}
+	return cm.GetName(), nil
+}
+
+func resultSize(res apiv1.Result) int {
+	size := len(res.Message) + len(res.Severity)
+	for k, v := range res.Tags {
+		size += len(k) + len(v)
+	}
+	return size
+}
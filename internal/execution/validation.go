@@ -0,0 +1,207 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/resource"
+	krmv1 "github.com/Azure/eno/pkg/krm/functions/api/v1"
+	"github.com/google/cel-go/cel"
+	celtypes "github.com/google/cel-go/common/types"
+	"sigs.k8s.io/structured-merge-diff/v4/typed"
+	"sigs.k8s.io/structured-merge-diff/v4/value"
+)
+
+var validationEnv *cel.Env
+
+func init() {
+	var err error
+	validationEnv, err = cel.NewEnv(cel.Variable("self", cel.DynType))
+	if err != nil {
+		panic(fmt.Sprintf("failed to create validation CEL environment: %v", err))
+	}
+}
+
+// validateOutputs evaluates the synthesizer's validations against every output
+// resource. The returned error aggregates one violation per failing resource/expression pair.
+func validateOutputs(ctx context.Context, syn *apiv1.Synthesizer, rl *krmv1.ResourceList) error {
+	if len(syn.Spec.Validations) == 0 {
+		return nil
+	}
+
+	programs := make([]cel.Program, len(syn.Spec.Validations))
+	for i, v := range syn.Spec.Validations {
+		ast, iss := validationEnv.Compile(v.Expression)
+		if iss != nil && iss.Err() != nil {
+			return fmt.Errorf("compiling validation %q: %w", v.Expression, iss.Err())
+		}
+		prgm, err := validationEnv.Program(ast, cel.InterruptCheckFrequency(10))
+		if err != nil {
+			return fmt.Errorf("compiling validation %q: %w", v.Expression, err)
+		}
+		programs[i] = prgm
+	}
+
+	var violations []error
+	for _, item := range rl.Items {
+		for i, prgm := range programs {
+			val, _, err := prgm.ContextEval(ctx, map[string]any{"self": item.Object})
+			if err == nil && val == celtypes.True {
+				continue
+			}
+
+			msg := syn.Spec.Validations[i].Message
+			if msg == "" {
+				msg = syn.Spec.Validations[i].Expression
+			}
+			violations = append(violations, fmt.Errorf("resource %s %s/%s violates synthesizer validation: %s", item.GetKind(), item.GetNamespace(), item.GetName(), msg))
+		}
+	}
+	return errors.Join(violations...)
+}
+
+// validateOutputQuota enforces the synthesizer's output quotas against the
+// whole synthesis - not per slice - so a runaway synthesizer fails with a clear
+// status before any slices are written.
+func validateOutputQuota(syn *apiv1.Synthesizer, rl *krmv1.ResourceList) error {
+	if max := syn.Spec.MaxResources; max > 0 && len(rl.Items) > max {
+		return fmt.Errorf("synthesizer produced %d resources, exceeding the maximum of %d", len(rl.Items), max)
+	}
+
+	if max := syn.Spec.MaxManifestBytes; max > 0 {
+		var total int
+		for _, item := range rl.Items {
+			js, err := item.MarshalJSON()
+			if err != nil {
+				return fmt.Errorf("encoding resource %s %s/%s: %w", item.GetKind(), item.GetNamespace(), item.GetName(), err)
+			}
+			total += len(js)
+		}
+		if total > max {
+			return fmt.Errorf("synthesizer produced %d bytes of manifests, exceeding the maximum of %d", total, max)
+		}
+	}
+
+	return nil
+}
+
+// parseOutputs parses each output resource the same way the reconciler will.
+// The returned slice is parallel to rl.Items, with nils for items that fail to
+// parse - fatal manifest errors fail synthesis elsewhere.
+func parseOutputs(ctx context.Context, rl *krmv1.ResourceList) []*resource.Resource {
+	resources := make([]*resource.Resource, len(rl.Items))
+	for i, item := range rl.Items {
+		js, err := item.MarshalJSON()
+		if err != nil {
+			continue
+		}
+		slice := &apiv1.ResourceSlice{
+			Spec: apiv1.ResourceSliceSpec{Resources: []apiv1.Manifest{{Manifest: string(js)}}},
+		}
+		res, err := resource.NewResource(ctx, slice, 0)
+		if err != nil {
+			continue
+		}
+		resources[i] = res
+	}
+	return resources
+}
+
+// validateReadinessOrder rejects output sets whose named readiness groups
+// order themselves after each other in a cycle, since the reconciler could
+// never make progress applying them.
+func validateReadinessOrder(ctx context.Context, rl *krmv1.ResourceList) error {
+	resources := parseOutputs(ctx, rl)
+	resources = slices.DeleteFunc(resources, func(r *resource.Resource) bool { return r == nil })
+	if cycle := resource.FindReadinessCycle(resources); cycle != nil {
+		return fmt.Errorf("readiness groups form a cycle: %s", strings.Join(cycle, " -> "))
+	}
+	return nil
+}
+
+// validateOutputSchemas checks every output resource against the cluster's
+// OpenAPI schema so invalid resources fail synthesis immediately instead of
+// surfacing later as partial applies. Types the schema getter doesn't know
+// about are skipped, but synthesizers still have to opt in since resources
+// whose CRDs haven't been installed yet can't be validated.
+func validateOutputSchemas(ctx context.Context, sg resource.SchemaGetter, syn *apiv1.Synthesizer, rl *krmv1.ResourceList) error {
+	if sg == nil || !syn.Spec.ValidateSchema {
+		return nil
+	}
+
+	var violations []error
+	for _, item := range rl.Items {
+		typeref, schem, err := sg.Get(ctx, item.GroupVersionKind())
+		if err != nil {
+			return fmt.Errorf("looking up schema for %s: %w", item.GroupVersionKind(), err)
+		}
+		if typeref == nil {
+			continue // unknown type e.g. a CRD that hasn't been installed yet
+		}
+		_, err = typed.AsTyped(value.NewValueInterface(item.Object), schem, *typeref)
+		if err != nil {
+			violations = append(violations, fmt.Errorf("resource %s %s/%s does not match its schema: %w", item.GetKind(), item.GetNamespace(), item.GetName(), err))
+		}
+	}
+	return errors.Join(violations...)
+}
+
+// duplicateRefWarnings surfaces output sets in which two full manifests share
+// the same ref (GVK + namespace + name). The reconciler orders conflicting
+// resources deterministically so one always wins, but that's rarely what the
+// synthesizer author intended. Patches targeting a resource that's also
+// emitted as a full manifest are expected and not counted.
+func duplicateRefWarnings(ctx context.Context, syn *apiv1.Synthesizer, rl *krmv1.ResourceList) []*krmv1.Result {
+	counts := map[resource.Ref]int{}
+	for _, res := range parseOutputs(ctx, rl) {
+		if res == nil || res.IsPatch() {
+			continue
+		}
+		counts[res.Ref]++
+	}
+
+	refs := make([]string, 0, len(counts))
+	byStr := map[string]int{}
+	for ref, n := range counts {
+		if n > 1 {
+			refs = append(refs, ref.String())
+			byStr[ref.String()] = n
+		}
+	}
+	slices.Sort(refs) // deterministic result ordering
+
+	var results []*krmv1.Result
+	for _, ref := range refs {
+		duplicateRefs.WithLabelValues(syn.Name).Inc()
+		results = append(results, &krmv1.Result{
+			Message:  fmt.Sprintf("%d resources share the ref %s - only one will be applied", byStr[ref], ref),
+			Severity: krmv1.ResultSeverityWarning,
+		})
+	}
+	return results
+}
+
+// validationWarnings parses each output resource the same way the reconciler
+// will, surfacing non-fatal issues (e.g. readiness checks that fail to compile)
+// as warning results. One bad check shouldn't break synthesis, but it also
+// shouldn't go unnoticed.
+func validationWarnings(ctx context.Context, rl *krmv1.ResourceList) []*krmv1.Result {
+	var results []*krmv1.Result
+	for i, res := range parseOutputs(ctx, rl) {
+		if res == nil {
+			continue
+		}
+		item := rl.Items[i]
+		for _, verr := range res.ValidationErrors() {
+			results = append(results, &krmv1.Result{
+				Message:  fmt.Sprintf("resource %s %s/%s: %s", item.GetKind(), item.GetNamespace(), item.GetName(), verr),
+				Severity: krmv1.ResultSeverityWarning,
+			})
+		}
+	}
+	return results
+}
@@ -0,0 +1,274 @@
+package execution
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	krmv1 "github.com/Azure/eno/pkg/krm/functions/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	smdschema "sigs.k8s.io/structured-merge-diff/v4/schema"
+	"sigs.k8s.io/structured-merge-diff/v4/typed"
+)
+
+func TestValidateOutputs(t *testing.T) {
+	ctx := context.Background()
+	syn := &apiv1.Synthesizer{}
+	syn.Spec.Validations = []apiv1.Validation{{
+		Expression: "has(self.metadata.labels) && has(self.metadata.labels.team)",
+		Message:    "all resources must set the team label",
+	}}
+
+	newCM := func(name string, labels map[string]any) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": name, "namespace": "default"},
+		}}
+		if labels != nil {
+			obj.Object["metadata"].(map[string]any)["labels"] = labels
+		}
+		return obj
+	}
+
+	// Compliant output passes
+	rl := &krmv1.ResourceList{Items: []*unstructured.Unstructured{
+		newCM("compliant", map[string]any{"team": "foo"}),
+	}}
+	require.NoError(t, validateOutputs(ctx, syn, rl))
+
+	// Output missing the label fails and surfaces the specific violation
+	rl.Items = append(rl.Items, newCM("non-compliant", nil))
+	err := validateOutputs(ctx, syn, rl)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "resource ConfigMap default/non-compliant violates synthesizer validation: all resources must set the team label")
+	assert.NotContains(t, err.Error(), "default/compliant")
+
+	// Invalid expressions fail closed
+	syn.Spec.Validations = []apiv1.Validation{{Expression: "not valid cel ("}}
+	require.Error(t, validateOutputs(ctx, syn, rl))
+
+	// Synthesizers without validations are unaffected
+	syn.Spec.Validations = nil
+	require.NoError(t, validateOutputs(ctx, syn, rl))
+}
+
+func TestValidateOutputQuota(t *testing.T) {
+	newCM := func(name string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": name, "namespace": "default"},
+		}}
+	}
+	rl := &krmv1.ResourceList{Items: []*unstructured.Unstructured{newCM("foo"), newCM("bar")}}
+
+	var totalBytes int
+	for _, item := range rl.Items {
+		js, err := item.MarshalJSON()
+		require.NoError(t, err)
+		totalBytes += len(js)
+	}
+
+	// No quotas - unlimited
+	syn := &apiv1.Synthesizer{}
+	require.NoError(t, validateOutputQuota(syn, rl))
+
+	// Just under the resource count limit
+	syn.Spec.MaxResources = 2
+	require.NoError(t, validateOutputQuota(syn, rl))
+
+	// Just over the resource count limit
+	syn.Spec.MaxResources = 1
+	err := validateOutputQuota(syn, rl)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "produced 2 resources, exceeding the maximum of 1")
+
+	// Just under the manifest byte limit
+	syn.Spec.MaxResources = 0
+	syn.Spec.MaxManifestBytes = totalBytes
+	require.NoError(t, validateOutputQuota(syn, rl))
+
+	// Just over the manifest byte limit
+	syn.Spec.MaxManifestBytes = totalBytes - 1
+	err = validateOutputQuota(syn, rl)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bytes of manifests, exceeding the maximum of")
+}
+
+func TestValidationWarnings(t *testing.T) {
+	ctx := context.Background()
+
+	cm := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]any{
+			"name":      "foo",
+			"namespace": "default",
+			"annotations": map[string]any{
+				"eno.azure.io/readiness": "not valid cel (",
+			},
+		},
+	}}
+	rl := &krmv1.ResourceList{Items: []*unstructured.Unstructured{cm}}
+
+	results := validationWarnings(ctx, rl)
+	require.Len(t, results, 1)
+	assert.Equal(t, krmv1.ResultSeverityWarning, results[0].Severity)
+	assert.Contains(t, results[0].Message, `resource ConfigMap default/foo: readiness check "default" failed to compile`)
+
+	// Valid readiness checks don't produce warnings
+	cm.SetAnnotations(map[string]string{"eno.azure.io/readiness": "self.status.ready == true"})
+	assert.Empty(t, validationWarnings(ctx, rl))
+}
+
+func TestValidateReadinessOrder(t *testing.T) {
+	ctx := context.Background()
+
+	newCM := func(name string, anno map[string]any) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": name, "namespace": "default", "annotations": anno},
+		}}
+	}
+
+	// Acyclic orderings pass
+	rl := &krmv1.ResourceList{Items: []*unstructured.Unstructured{
+		newCM("db", map[string]any{"eno.azure.io/readiness-group-name": "db-ready"}),
+		newCM("app", map[string]any{"eno.azure.io/readiness-after": "db-ready"}),
+	}}
+	require.NoError(t, validateReadinessOrder(ctx, rl))
+
+	// Cycles fail synthesis and name the groups involved
+	rl.Items = append(rl.Items,
+		newCM("a", map[string]any{"eno.azure.io/readiness-group-name": "a", "eno.azure.io/readiness-after": "b"}),
+		newCM("b", map[string]any{"eno.azure.io/readiness-group-name": "b", "eno.azure.io/readiness-after": "a"}),
+	)
+	err := validateReadinessOrder(ctx, rl)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "readiness groups form a cycle: a -> b -> a")
+}
+
+func TestDuplicateRefWarnings(t *testing.T) {
+	ctx := context.Background()
+	syn := &apiv1.Synthesizer{}
+	syn.Name = "test-synth"
+
+	newCM := func(name string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]any{"name": name, "namespace": "default"},
+		}}
+	}
+
+	// Distinct refs don't warn
+	rl := &krmv1.ResourceList{Items: []*unstructured.Unstructured{newCM("foo"), newCM("bar")}}
+	assert.Empty(t, duplicateRefWarnings(ctx, syn, rl))
+
+	// Two full manifests sharing a ref do
+	rl.Items = append(rl.Items, newCM("foo"))
+	results := duplicateRefWarnings(ctx, syn, rl)
+	require.Len(t, results, 1)
+	assert.Equal(t, krmv1.ResultSeverityWarning, results[0].Severity)
+	assert.Contains(t, results[0].Message, "2 resources share the ref (.ConfigMap)/default/foo")
+
+	// A patch targeting the same resource is expected and doesn't count
+	patch := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "eno.azure.io/v1",
+		"kind":       "Patch",
+		"metadata":   map[string]any{"name": "bar", "namespace": "default"},
+		"patch": map[string]any{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"ops":        []any{map[string]any{"op": "add", "path": "/data", "value": map[string]any{}}},
+		},
+	}}
+	rl = &krmv1.ResourceList{Items: []*unstructured.Unstructured{newCM("bar"), patch}}
+	assert.Empty(t, duplicateRefWarnings(ctx, syn, rl))
+}
+
+type staticSchemaGetter struct {
+	name   string
+	schema *smdschema.Schema
+}
+
+func (s *staticSchemaGetter) Get(ctx context.Context, gvk schema.GroupVersionKind) (*smdschema.TypeRef, *smdschema.Schema, error) {
+	if gvk.Kind != "ConfigMap" {
+		return nil, nil, nil
+	}
+	return &smdschema.TypeRef{NamedType: &s.name}, s.schema, nil
+}
+
+func TestValidateOutputSchemas(t *testing.T) {
+	ctx := context.Background()
+
+	parser, err := typed.NewParser(`types:
+- name: configmap
+  map:
+    fields:
+    - name: apiVersion
+      type:
+        scalar: string
+    - name: kind
+      type:
+        scalar: string
+    - name: metadata
+      type:
+        map:
+          elementType:
+            scalar: string
+    - name: data
+      type:
+        map:
+          elementType:
+            scalar: string
+`)
+	require.NoError(t, err)
+	sg := &staticSchemaGetter{name: "configmap", schema: &parser.Schema}
+
+	syn := &apiv1.Synthesizer{}
+	syn.Spec.ValidateSchema = true
+
+	valid := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": "foo", "namespace": "default"},
+		"data":       map[string]any{"key": "value"},
+	}}
+	rl := &krmv1.ResourceList{Items: []*unstructured.Unstructured{valid}}
+	require.NoError(t, validateOutputSchemas(ctx, sg, syn, rl))
+
+	// Violations fail synthesis and name the offending resource/field
+	invalid := valid.DeepCopy()
+	invalid.SetName("bad")
+	invalid.Object["data"] = map[string]any{"key": int64(123)}
+	rl.Items = append(rl.Items, invalid)
+	err = validateOutputSchemas(ctx, sg, syn, rl)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "resource ConfigMap default/bad does not match its schema")
+	assert.Contains(t, err.Error(), ".data.key")
+
+	// Types without a known schema are skipped
+	unknown := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "example.com/v1",
+		"kind":       "Widget",
+		"metadata":   map[string]any{"name": "foo"},
+		"spec":       map[string]any{"anything": "goes"},
+	}}
+	rl.Items = []*unstructured.Unstructured{unknown}
+	require.NoError(t, validateOutputSchemas(ctx, sg, syn, rl))
+
+	// Synthesizers that don't opt in are unaffected
+	rl.Items = []*unstructured.Unstructured{invalid}
+	syn.Spec.ValidateSchema = false
+	require.NoError(t, validateOutputSchemas(ctx, sg, syn, rl))
+
+	// A nil schema getter disables validation entirely
+	syn.Spec.ValidateSchema = true
+	require.NoError(t, validateOutputSchemas(ctx, nil, syn, rl))
+}
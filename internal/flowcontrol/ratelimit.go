@@ -0,0 +1,39 @@
+package flowcontrol
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/time/rate"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// CompositionRateLimiter caps the rate of apiserver writes per composition using
+// a token bucket. It smooths the initial burst of large compositions without
+// limiting how many resources can be reconciled concurrently.
+type CompositionRateLimiter struct {
+	mut      sync.Mutex
+	limiters map[types.NamespacedName]*rate.Limiter
+	qps      float64
+}
+
+func NewCompositionRateLimiter(qps float64) *CompositionRateLimiter {
+	return &CompositionRateLimiter{
+		limiters: map[types.NamespacedName]*rate.Limiter{},
+		qps:      qps,
+	}
+}
+
+// Wait blocks until the composition's token bucket permits another write,
+// or the context is canceled.
+func (c *CompositionRateLimiter) Wait(ctx context.Context, comp types.NamespacedName) error {
+	c.mut.Lock()
+	limiter, ok := c.limiters[comp]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(c.qps), 1)
+		c.limiters[comp] = limiter
+	}
+	c.mut.Unlock()
+
+	return limiter.Wait(ctx)
+}
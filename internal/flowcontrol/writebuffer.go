@@ -183,6 +183,14 @@ func (*ResourceSliceWriteBuffer) buildPatch(slice *apiv1.ResourceSlice, updates
 	var patches []*jsonPatch
 	unsafeSlice := slice.Status.Resources
 
+	// Manifests may expand into multiple resources (multi-document YAML), so the
+	// status array can be longer than spec.resources. Informers prune manifests
+	// out of cached slices, in which case each one degrades to a count of 1.
+	maxResources := 0
+	for _, res := range slice.Spec.Resources {
+		maxResources += resource.CountManifestDocuments(res.Manifest)
+	}
+
 	if len(unsafeSlice) == 0 {
 		patches = append(patches,
 			&jsonPatch{
@@ -198,12 +206,12 @@ func (*ResourceSliceWriteBuffer) buildPatch(slice *apiv1.ResourceSlice, updates
 			&jsonPatch{
 				Op:    "add",
 				Path:  "/status/resources",
-				Value: make([]apiv1.ResourceState, len(slice.Spec.Resources)),
+				Value: make([]apiv1.ResourceState, maxResources),
 			})
 	}
 
 	for _, update := range updates {
-		if update.SlicedResource.Index > len(slice.Spec.Resources)-1 || update.SlicedResource.Index < 0 {
+		if update.SlicedResource.Index > maxResources-1 || update.SlicedResource.Index < 0 {
 			continue // impossible
 		}
 
@@ -0,0 +1,32 @@
+package manager
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// transientErrorRequeueInterval bounds how quickly reconcilers retry after a
+// transient apiserver error. Jitter is applied to avoid thundering herds.
+const transientErrorRequeueInterval = 5 * time.Second
+
+// IsTransientErr returns true for apiserver errors that are expected to resolve
+// on their own e.g. timeouts and throttling.
+func IsTransientErr(err error) bool {
+	return errors.IsServerTimeout(err) || errors.IsTimeout(err) || errors.IsTooManyRequests(err) || errors.IsServiceUnavailable(err) || errors.IsInternalError(err)
+}
+
+// RequeueTransientErr converts transient apiserver errors into a bounded,
+// jittered requeue instead of the immediate retries controller-runtime would
+// otherwise perform. Non-transient errors (and nil) are passed through.
+func RequeueTransientErr(err error) (ctrl.Result, error) {
+	if err == nil || !IsTransientErr(err) {
+		return ctrl.Result{}, err
+	}
+	if seconds, ok := errors.SuggestsClientDelay(err); ok && seconds > 0 {
+		return ctrl.Result{RequeueAfter: wait.Jitter(time.Duration(seconds)*time.Second, 0.1)}, nil
+	}
+	return ctrl.Result{RequeueAfter: wait.Jitter(transientErrorRequeueInterval, 0.5)}, nil
+}
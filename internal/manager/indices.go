@@ -12,6 +12,7 @@ const (
 	IdxCompositionsBySynthesizer = ".spec.synthesizer"
 	IdxCompositionsBySymphony    = ".compositionsBySymphony"
 	IdxCompositionsByBinding     = ".compositionsByBinding"
+	IdxCompositionsByRef         = ".compositionsByRef"
 	IdxSynthesizersByRef         = ".synthesizersByRef"
 )
 
@@ -40,6 +41,21 @@ func indexResourceBindings() client.IndexerFunc {
 	}
 }
 
+func indexCompRefs() client.IndexerFunc {
+	return func(o client.Object) []string {
+		comp, ok := o.(*apiv1.Composition)
+		if !ok {
+			return nil
+		}
+
+		keys := []string{}
+		for _, ref := range comp.Spec.Refs {
+			keys = append(keys, path.Join(ref.Resource.Group, ref.Resource.Version, ref.Resource.Kind))
+		}
+		return keys
+	}
+}
+
 func indexSynthRefs() client.IndexerFunc {
 	return func(o client.Object) []string {
 		synth, ok := o.(*apiv1.Synthesizer)
@@ -171,6 +171,11 @@ func newMgr(logger logr.Logger, opts *Options, isController, isReconciler bool)
 			return nil, err
 		}
 
+		err = mgr.GetFieldIndexer().IndexField(context.Background(), &apiv1.Composition{}, IdxCompositionsByRef, indexCompRefs())
+		if err != nil {
+			return nil, err
+		}
+
 		err = mgr.GetFieldIndexer().IndexField(context.Background(), &apiv1.Synthesizer{}, IdxSynthesizersByRef, indexSynthRefs())
 		if err != nil {
 			return nil, err
@@ -3,7 +3,10 @@ package readiness
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	celtypes "github.com/google/cel-go/common/types"
@@ -14,7 +17,10 @@ import (
 	"github.com/google/cel-go/cel"
 )
 
-var defaultEnv *cel.Env
+var (
+	envMut     sync.Mutex
+	defaultEnv *cel.Env
+)
 
 func init() {
 	initDefaultEnv()
@@ -22,30 +28,127 @@ func init() {
 
 func initDefaultEnv() {
 	var err error
-	defaultEnv, err = cel.NewEnv(cel.Variable("self", cel.DynType))
+	defaultEnv, err = cel.NewEnv(
+		cel.Variable("self", cel.DynType),
+		cel.Variable("composition", cel.DynType),
+		conditionHelper)
 	if err != nil {
 		panic(fmt.Sprintf("failed to create default CEL environment: %v", err))
 	}
 }
 
+// conditionHelper registers `self.condition('Available')`, which returns the
+// matching condition object from the receiver's status.conditions (or null when
+// absent) so checks don't have to reinvent the list filtering, e.g.:
+//
+//	self.condition('Available').status == 'True'
+var conditionHelper = cel.Function("condition",
+	cel.MemberOverload("dyn_condition_string",
+		[]*cel.Type{cel.DynType, cel.StringType}, cel.DynType,
+		cel.BinaryBinding(func(lhs, rhs ref.Val) ref.Val {
+			obj, ok := lhs.Value().(map[string]any)
+			if !ok {
+				return celtypes.NullValue
+			}
+			condType, ok := rhs.Value().(string)
+			if !ok {
+				return celtypes.NullValue
+			}
+			status, ok := obj["status"].(map[string]any)
+			if !ok {
+				status = obj // also support receivers that hold the conditions directly e.g. self.status
+			}
+			for _, item := range conditionsOf(status) {
+				cond, ok := item.(map[string]any)
+				if !ok {
+					continue
+				}
+				if cond["type"] == condType {
+					return celtypes.NewDynamicMap(celtypes.DefaultTypeAdapter, cond)
+				}
+			}
+			return celtypes.NullValue
+		})))
+
+func conditionsOf(status map[string]any) []any {
+	switch conds := status["conditions"].(type) {
+	case []any:
+		return conds
+	case []map[string]any:
+		out := make([]any, len(conds))
+		for i := range conds {
+			out[i] = conds[i]
+		}
+		return out
+	}
+	return nil
+}
+
+// RegisterEnvOptions extends the CEL environment used by every readiness check,
+// e.g. with custom function libraries. Checks parsed after registration use the
+// extended environment for both compilation and evaluation, so register early -
+// typically from an init function or before controllers are started.
+func RegisterEnvOptions(opts ...cel.EnvOption) error {
+	envMut.Lock()
+	defer envMut.Unlock()
+	env, err := defaultEnv.Extend(opts...)
+	if err != nil {
+		return fmt.Errorf("extending CEL environment: %w", err)
+	}
+	defaultEnv = env
+	return nil
+}
+
+func currentEnv() *cel.Env {
+	envMut.Lock()
+	defer envMut.Unlock()
+	return defaultEnv
+}
+
 // Check represents a parsed readiness check CEL expression.
 type Check struct {
 	Name    string
 	program cel.Program
+
+	// Timeout bounds how long the check may remain not-ready before the
+	// resource is considered to have permanently failed. Zero means no bound.
+	Timeout time.Duration
 }
 
 // ParseCheck parses the given CEL expression in the context of an environment,
 // and returns a reusable execution handle.
+//
+// Expressions may be suffixed with `| timeout(5m)` to bound how long the check
+// can stay not-ready before the resource is reported as failed.
 func ParseCheck(expr string) (*Check, error) {
-	ast, iss := defaultEnv.Compile(expr)
+	expr, timeout, err := splitTimeout(expr)
+	if err != nil {
+		return nil, err
+	}
+	env := currentEnv()
+	ast, iss := env.Compile(expr)
 	if iss != nil && iss.Err() != nil {
 		return nil, iss.Err()
 	}
-	prgm, err := defaultEnv.Program(ast, cel.InterruptCheckFrequency(10))
+	prgm, err := env.Program(ast, cel.InterruptCheckFrequency(10))
 	if err != nil {
 		return nil, err
 	}
-	return &Check{program: prgm}, nil
+	return &Check{program: prgm, Timeout: timeout}, nil
+}
+
+var timeoutSuffix = regexp.MustCompile(`\|\s*timeout\(([^)]+)\)\s*$`)
+
+func splitTimeout(expr string) (string, time.Duration, error) {
+	match := timeoutSuffix.FindStringSubmatch(expr)
+	if match == nil {
+		return expr, 0, nil
+	}
+	timeout, err := time.ParseDuration(match[1])
+	if err != nil {
+		return "", 0, fmt.Errorf("parsing readiness check timeout: %w", err)
+	}
+	return strings.TrimSuffix(expr, match[0]), timeout, nil
 }
 
 // Eval executes the compiled check against a given resource.
@@ -53,7 +156,7 @@ func (r *Check) Eval(ctx context.Context, resource *unstructured.Unstructured) (
 	if resource == nil {
 		return nil, false
 	}
-	val, _, err := r.program.ContextEval(ctx, map[string]any{"self": resource.Object})
+	val, _, err := r.program.ContextEval(ctx, map[string]any{"self": resource.Object, "composition": compositionActivation(ctx)})
 	if err != nil {
 		return nil, false
 	}
@@ -116,6 +219,19 @@ func (r Checks) Eval(ctx context.Context, resource *unstructured.Unstructured) (
 	return all[0], true
 }
 
+// Timeout returns the shortest non-zero timeout across the set of checks,
+// i.e. the first deadline after which a still not-ready resource should be
+// considered failed. Zero when no checks declare a timeout.
+func (r Checks) Timeout() time.Duration {
+	var min time.Duration
+	for _, check := range r {
+		if check.Timeout > 0 && (min == 0 || check.Timeout < min) {
+			min = check.Timeout
+		}
+	}
+	return min
+}
+
 // EvalOptionally is identical to Eval, except it returns the current time in the status if no checks are set.
 func (r Checks) EvalOptionally(ctx context.Context, resource *unstructured.Unstructured) (*Status, bool) {
 	if len(r) == 0 {
@@ -128,3 +244,41 @@ type Status struct {
 	ReadyTime   metav1.Time
 	PreciseTime bool // true when time came from a condition, not the controller's metav1.Now
 }
+
+// CompositionResource is the view of a sibling resource exposed to readiness
+// expressions through the `composition` variable.
+type CompositionResource struct {
+	Group, Kind, Name, Namespace string
+	Ready, Reconciled, Deleted   bool
+}
+
+type compositionKey struct{}
+
+// NewCompositionContext exposes the latest known states of a composition's other
+// resources to readiness expressions evaluated with the returned context, e.g.:
+//
+//	composition.resources.exists(r, r.kind == 'ConfigMap' && r.ready)
+//
+// Resources whose state isn't known yet are exposed with ready/reconciled false,
+// so checks that reference them (including cycles between checks) evaluate to
+// not-ready instead of erroring.
+func NewCompositionContext(ctx context.Context, resources []CompositionResource) context.Context {
+	return context.WithValue(ctx, compositionKey{}, resources)
+}
+
+func compositionActivation(ctx context.Context) map[string]any {
+	resources, _ := ctx.Value(compositionKey{}).([]CompositionResource)
+	items := make([]any, len(resources))
+	for i, r := range resources {
+		items[i] = map[string]any{
+			"group":      r.Group,
+			"kind":       r.Kind,
+			"name":       r.Name,
+			"namespace":  r.Namespace,
+			"ready":      r.Ready,
+			"reconciled": r.Reconciled,
+			"deleted":    r.Deleted,
+		}
+	}
+	return map[string]any{"resources": items}
+}
@@ -2,9 +2,14 @@ package readiness
 
 import (
 	"context"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/cel-go/cel"
+	celtypes "github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -115,6 +120,38 @@ var evalCheckTests = []struct {
 		Expect:        false,
 		ExpectPrecise: false,
 	},
+	{
+		Name:     "condition-helper-hit",
+		Resource: &unstructured.Unstructured{Object: simpleConditionStatus},
+		Expr:     "self.condition('Test2').status == 'True'",
+		Expect:   true,
+	},
+	{
+		Name:     "condition-helper-miss",
+		Resource: &unstructured.Unstructured{Object: simpleConditionStatus},
+		Expr:     "self.condition('Test3').status == 'True'",
+		Expect:   false,
+	},
+	{
+		Name:     "condition-helper-missing-condition",
+		Resource: &unstructured.Unstructured{Object: simpleConditionStatus},
+		Expr:     "self.condition('DoesNotExist').status == 'True'",
+		Expect:   false,
+	},
+	{
+		Name:     "condition-helper-null-check",
+		Resource: &unstructured.Unstructured{Object: simpleConditionStatus},
+		Expr:     "self.condition('DoesNotExist') == null",
+		Expect:   true,
+	},
+	{
+		Name: "condition-helper-no-status",
+		Resource: &unstructured.Unstructured{
+			Object: map[string]any{"foo": "bar"},
+		},
+		Expr:   "self.condition('Test').status == 'True'",
+		Expect: false,
+	},
 }
 
 func TestEvalCheck(t *testing.T) {
@@ -290,3 +327,81 @@ func mustParse(expr string) *Check {
 	}
 	return check
 }
+
+func TestCompositionVariable(t *testing.T) {
+	check := mustParse("composition.resources.exists(r, r.kind == 'ConfigMap' && r.ready)")
+	resource := &unstructured.Unstructured{Object: map[string]any{}}
+
+	// The referenced resource hasn't been seen yet - not ready, not an error
+	_, ok := check.Eval(context.Background(), resource)
+	assert.False(t, ok)
+
+	// The referenced resource exists but isn't ready
+	ctx := NewCompositionContext(context.Background(), []CompositionResource{
+		{Kind: "ConfigMap", Name: "foo", Namespace: "default"},
+	})
+	_, ok = check.Eval(ctx, resource)
+	assert.False(t, ok)
+
+	// The referenced resource is ready
+	ctx = NewCompositionContext(context.Background(), []CompositionResource{
+		{Kind: "ConfigMap", Name: "foo", Namespace: "default", Ready: true, Reconciled: true},
+	})
+	status, ok := check.Eval(ctx, resource)
+	assert.True(t, ok)
+	assert.NotNil(t, status)
+}
+
+func TestParseCheckTimeout(t *testing.T) {
+	check := mustParse("self.foo == 'bar' | timeout(5m)")
+	assert.Equal(t, 5*time.Minute, check.Timeout)
+
+	// The expression still evaluates without its timeout suffix
+	status, ok := check.Eval(context.Background(), &unstructured.Unstructured{Object: map[string]any{"foo": "bar"}})
+	assert.True(t, ok)
+	assert.NotNil(t, status)
+
+	_, err := ParseCheck("true | timeout(bogus)")
+	require.ErrorContains(t, err, "parsing readiness check timeout")
+
+	// The set's timeout is the first deadline to fire
+	checks := Checks{check, mustParse("true | timeout(1m)"), mustParse("true")}
+	assert.Equal(t, time.Minute, checks.Timeout())
+	assert.Zero(t, Checks{mustParse("true")}.Timeout())
+}
+
+func TestRegisterEnvOptions(t *testing.T) {
+	// Unknown functions fail at parse time before registration
+	_, err := ParseCheck("semverGte(self.version, '1.2.0')")
+	require.Error(t, err)
+
+	semverGte := func(lhs, rhs ref.Val) ref.Val {
+		parse := func(str string) (parts [3]int) {
+			for i, s := range strings.SplitN(string(str), ".", 3) {
+				parts[i], _ = strconv.Atoi(s)
+			}
+			return parts
+		}
+		a := parse(string(lhs.(celtypes.String)))
+		b := parse(string(rhs.(celtypes.String)))
+		for i := range a {
+			if a[i] != b[i] {
+				return celtypes.Bool(a[i] > b[i])
+			}
+		}
+		return celtypes.True
+	}
+	require.NoError(t, RegisterEnvOptions(cel.Function("semverGte",
+		cel.Overload("semverGte_string_string", []*cel.Type{cel.StringType, cel.StringType}, cel.BoolType,
+			cel.BinaryBinding(semverGte)))))
+
+	check, err := ParseCheck("semverGte(self.version, '1.2.0')")
+	require.NoError(t, err)
+
+	status, ok := check.Eval(context.Background(), &unstructured.Unstructured{Object: map[string]any{"version": "1.10.3"}})
+	assert.True(t, ok)
+	assert.NotNil(t, status)
+
+	_, ok = check.Eval(context.Background(), &unstructured.Unstructured{Object: map[string]any{"version": "1.1.9"}})
+	assert.False(t, ok)
+}
@@ -2,10 +2,13 @@ package resource
 
 import (
 	"context"
+	"sort"
+	"strconv"
 	"sync"
 
 	apiv1 "github.com/Azure/eno/api/v1"
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 )
@@ -22,6 +25,8 @@ type Cache struct {
 	queue     workqueue.TypedRateLimitingInterface[Request]
 	syntheses map[string]*tree
 	synByComp map[types.NamespacedName][]string
+	sliceRVs  map[string]int64               // max slice resource version per synthesis at fill time
+	staleness map[types.NamespacedName]int64 // gap between observed and cached slice versions
 }
 
 func (c *Cache) initUnlocked() {
@@ -31,6 +36,12 @@ func (c *Cache) initUnlocked() {
 	if c.synByComp == nil {
 		c.synByComp = map[types.NamespacedName][]string{}
 	}
+	if c.sliceRVs == nil {
+		c.sliceRVs = map[string]int64{}
+	}
+	if c.staleness == nil {
+		c.staleness = map[types.NamespacedName]int64{}
+	}
 	if c.queue == nil {
 		panic("attempted to use resource cache without a queue")
 	}
@@ -57,6 +68,43 @@ func (c *Cache) Get(ctx context.Context, synthesisUUID string, ref Ref) (res *Re
 	return syn.Get(ref)
 }
 
+// ListForSynthesis returns every resource produced by the given synthesis,
+// or nil if the synthesis isn't cached.
+func (c *Cache) ListForSynthesis(synUUID string) []*Resource {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	syn, ok := c.syntheses[synUUID]
+	if !ok {
+		return nil
+	}
+	resources := make([]*Resource, 0, len(syn.byRef))
+	for _, idx := range syn.byRef {
+		resources = append(resources, idx.Resource)
+	}
+	return resources
+}
+
+// ManagedGVKs returns the distinct GVKs of every cached resource.
+func (c *Cache) ManagedGVKs() []schema.GroupVersionKind {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	seen := map[schema.GroupVersionKind]struct{}{}
+	gvks := []schema.GroupVersionKind{}
+	for _, syn := range c.syntheses {
+		for _, idx := range syn.byRef {
+			gvk := idx.Resource.GVK
+			if _, ok := seen[gvk]; ok {
+				continue
+			}
+			seen[gvk] = struct{}{}
+			gvks = append(gvks, gvk)
+		}
+	}
+	return gvks
+}
+
 // Visit takes a set of resource slices from the informers and updates the resource status in the cache.
 // Return false if the synthesis is not in the cache.
 func (c *Cache) Visit(ctx context.Context, comp *apiv1.Composition, synUUID string, items []apiv1.ResourceSlice) bool {
@@ -70,8 +118,12 @@ func (c *Cache) Visit(ctx context.Context, comp *apiv1.Composition, synUUID stri
 	}
 
 	compNSN := types.NamespacedName{Name: comp.Name, Namespace: comp.Namespace}
+	c.observeStalenessUnlocked(compNSN, synUUID, items)
 	for _, slice := range items {
-		for i := 0; i < len(slice.Spec.Resources); i++ {
+		// Manifests may expand to multiple resources (multi-document YAML), so the
+		// cached resource count is authoritative rather than spec.resources.
+		count := syn.sliceResourceCount(types.NamespacedName{Name: slice.Name, Namespace: slice.Namespace})
+		for i := 0; i < count; i++ {
 			var state apiv1.ResourceState
 			if len(slice.Status.Resources) > i {
 				state = slice.Status.Resources[i]
@@ -97,13 +149,13 @@ func (c *Cache) Fill(ctx context.Context, comp types.NamespacedName, synUUID str
 	var builder treeBuilder
 	for _, slice := range items {
 		slice := slice
-		for i := range slice.Spec.Resources {
-			res, err := NewResource(ctx, &slice, i)
-			if err != nil {
-				// This should be impossible since the synthesis executor process will not produce invalid resources
-				logger.Error(err, "invalid resource - cannot load into cache", "resourceSliceName", slice.Name, "resourceIndex", i)
-				return
-			}
+		resources, err := NewResources(ctx, &slice)
+		if err != nil {
+			// This should be impossible since the synthesis executor process will not produce invalid resources
+			logger.Error(err, "invalid resource - cannot load into cache", "resourceSliceName", slice.Name)
+			return
+		}
+		for _, res := range resources {
 			builder.Add(res)
 		}
 	}
@@ -113,10 +165,92 @@ func (c *Cache) Fill(ctx context.Context, comp types.NamespacedName, synUUID str
 	c.initUnlocked()
 	c.syntheses[synUUID] = tree
 	c.synByComp[comp] = append(c.synByComp[comp], synUUID)
+	c.sliceRVs[synUUID] = maxSliceResourceVersion(items)
+	c.staleness[comp] = 0
+	cacheStalenessGap.WithLabelValues(comp.Name, comp.Namespace).Set(0)
 	c.mut.Unlock()
 	logger.V(1).Info("resource cache filled", "synthesisUUID", synUUID)
 }
 
+// CacheDump is a point-in-time view of the cached resources of a single synthesis.
+type CacheDump struct {
+	SynthesisUUID string          `json:"synthesisUUID"`
+	Resources     []CacheDumpItem `json:"resources"`
+}
+
+type CacheDumpItem struct {
+	Ref                 string `json:"ref"`
+	SliceName           string `json:"sliceName"`
+	SliceIndex          int    `json:"sliceIndex"`
+	HasKnownState       bool   `json:"hasKnownState"`
+	PendingDependencies int    `json:"pendingDependencies"`
+}
+
+// Dump returns a snapshot of every synthesis cached for the given composition.
+// Only for debugging - the schema should not be expected to be stable.
+func (c *Cache) Dump(comp types.NamespacedName) []CacheDump {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	dumps := []CacheDump{}
+	for _, uuid := range c.synByComp[comp] {
+		syn, ok := c.syntheses[uuid]
+		if !ok {
+			continue
+		}
+
+		dump := CacheDump{SynthesisUUID: uuid, Resources: make([]CacheDumpItem, 0, len(syn.byRef))}
+		for _, idx := range syn.byRef {
+			dump.Resources = append(dump.Resources, CacheDumpItem{
+				Ref:                 idx.Resource.Ref.String(),
+				SliceName:           idx.Resource.ManifestRef.Slice.Name,
+				SliceIndex:          idx.Resource.ManifestRef.Index,
+				HasKnownState:       idx.Resource.latestKnownState.Load() != nil,
+				PendingDependencies: len(idx.PendingDependencies),
+			})
+		}
+		sort.Slice(dump.Resources, func(i, j int) bool { return dump.Resources[i].Ref < dump.Resources[j].Ref })
+		dumps = append(dumps, dump)
+	}
+	return dumps
+}
+
+// StalenessGap returns the difference between the newest slice resource version observed
+// by the informers and the newest version that has been loaded into the cache for the
+// given composition. A persistently non-zero gap suggests a stuck informer.
+func (c *Cache) StalenessGap(comp types.NamespacedName) int64 {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.staleness[comp]
+}
+
+func (c *Cache) observeStalenessUnlocked(comp types.NamespacedName, synUUID string, items []apiv1.ResourceSlice) {
+	cached, ok := c.sliceRVs[synUUID]
+	if !ok {
+		return
+	}
+	gap := maxSliceResourceVersion(items) - cached
+	if gap < 0 {
+		gap = 0
+	}
+	c.staleness[comp] = gap
+	cacheStalenessGap.WithLabelValues(comp.Name, comp.Namespace).Set(float64(gap))
+}
+
+func maxSliceResourceVersion(items []apiv1.ResourceSlice) int64 {
+	var max int64
+	for _, slice := range items {
+		rv, err := strconv.ParseInt(slice.ResourceVersion, 10, 64)
+		if err != nil {
+			continue // resource versions are opaque in theory, but always ints in practice
+		}
+		if rv > max {
+			max = rv
+		}
+	}
+	return max
+}
+
 // Purge removes all syntheses from the cache that are not part of the given composition.
 // If comp is nil, all syntheses will be purged.
 func (c *Cache) Purge(ctx context.Context, compNSN types.NamespacedName, comp *apiv1.Composition) {
@@ -134,7 +268,12 @@ func (c *Cache) Purge(ctx context.Context, compNSN types.NamespacedName, comp *a
 
 		logger.V(1).Info("resource cache purged", "synthesisUUID", uuid)
 		delete(c.syntheses, uuid)
+		delete(c.sliceRVs, uuid)
 	}
 
 	c.synByComp[compNSN] = remainingSyns
+	if comp == nil {
+		delete(c.staleness, compNSN)
+		cacheStalenessGap.DeleteLabelValues(compNSN.Name, compNSN.Namespace)
+	}
 }
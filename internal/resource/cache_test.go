@@ -88,6 +88,52 @@ func TestCacheBasics(t *testing.T) {
 	assert.False(t, c.Visit(ctx, comp, synUUID, slices))
 }
 
+func TestCacheDump(t *testing.T) {
+	ctx := context.Background()
+	var c Cache
+	queue := workqueue.NewTypedRateLimitingQueue[Request](workqueue.DefaultTypedControllerRateLimiter[Request]())
+	c.SetQueue(queue)
+
+	comp := &apiv1.Composition{}
+	comp.Name = "foo"
+	comp.Namespace = "bar"
+	compNSN := types.NamespacedName{Name: comp.Name, Namespace: comp.Namespace}
+	const synUUID = "foobar"
+
+	slices := []apiv1.ResourceSlice{{
+		ObjectMeta: metav1.ObjectMeta{Name: "slice-1"},
+		Spec: apiv1.ResourceSliceSpec{
+			Resources: []apiv1.Manifest{{
+				Manifest: `{ "apiVersion": "v1", "kind": "Pod", "metadata": { "name": "foo", "namespace": "default" } }`,
+			}, {
+				Manifest: `{ "apiVersion": "v1", "kind": "Pod", "metadata": { "name": "bar", "namespace": "default" } }`,
+			}},
+		},
+	}}
+
+	// Unknown compositions dump as empty
+	assert.Empty(t, c.Dump(compNSN))
+
+	c.Fill(ctx, compNSN, synUUID, slices)
+	dumps := c.Dump(compNSN)
+	if assert.Len(t, dumps, 1) {
+		assert.Equal(t, synUUID, dumps[0].SynthesisUUID)
+		if assert.Len(t, dumps[0].Resources, 2) {
+			assert.Equal(t, "(.Pod)/default/bar", dumps[0].Resources[0].Ref)
+			assert.Equal(t, "slice-1", dumps[0].Resources[0].SliceName)
+			assert.False(t, dumps[0].Resources[0].HasKnownState)
+		}
+	}
+
+	// Visiting the slices populates the known state
+	assert.True(t, c.Visit(ctx, comp, synUUID, slices))
+	dumps = c.Dump(compNSN)
+	if assert.Len(t, dumps, 1) && assert.Len(t, dumps[0].Resources, 2) {
+		assert.True(t, dumps[0].Resources[0].HasKnownState)
+		assert.True(t, dumps[0].Resources[1].HasKnownState)
+	}
+}
+
 func TestCachePurge(t *testing.T) {
 	for i := 0; i < 5; i++ {
 		ctx := context.Background()
@@ -208,3 +254,41 @@ func dumpQueue(q workqueue.TypedRateLimitingInterface[Request]) (slice []string)
 		q.Forget(req)
 	}
 }
+
+func TestCacheStalenessGap(t *testing.T) {
+	ctx := context.Background()
+	var c Cache
+	queue := workqueue.NewTypedRateLimitingQueue[Request](workqueue.DefaultTypedControllerRateLimiter[Request]())
+	c.SetQueue(queue)
+
+	comp := &apiv1.Composition{}
+	comp.Name = "foo"
+	comp.Namespace = "bar"
+	compNSN := types.NamespacedName{Name: comp.Name, Namespace: comp.Namespace}
+	const synUUID = "foobar"
+
+	newSlices := func(rv string) []apiv1.ResourceSlice {
+		return []apiv1.ResourceSlice{{
+			ObjectMeta: metav1.ObjectMeta{Name: "slice-1", ResourceVersion: rv},
+			Spec: apiv1.ResourceSliceSpec{
+				Resources: []apiv1.Manifest{{
+					Manifest: `{ "apiVersion": "v1", "kind": "Pod", "metadata": { "name": "foo", "namespace": "default" } }`,
+				}},
+			},
+		}}
+	}
+
+	// No gap after the initial fill
+	c.Fill(ctx, compNSN, synUUID, newSlices("10"))
+	assert.True(t, c.Visit(ctx, comp, synUUID, newSlices("10")))
+	assert.Equal(t, int64(0), c.StalenessGap(compNSN))
+
+	// A slice update that isn't reflected in the cache is reported as a gap
+	assert.True(t, c.Visit(ctx, comp, synUUID, newSlices("12")))
+	assert.Equal(t, int64(2), c.StalenessGap(compNSN))
+
+	// The gap closes once the cache is filled with the newer state
+	c.Fill(ctx, compNSN, synUUID, newSlices("12"))
+	assert.True(t, c.Visit(ctx, comp, synUUID, newSlices("12")))
+	assert.Equal(t, int64(0), c.StalenessGap(compNSN))
+}
@@ -0,0 +1,203 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v4/typed"
+	"sigs.k8s.io/structured-merge-diff/v4/value"
+)
+
+// FieldChange describes a single field-level difference that applying the
+// resource would cause, relative to the current state.
+type FieldChange struct {
+	// Path is the field path as rendered by structured-merge-diff,
+	// e.g. `.spec.template.spec.serviceAccountName`.
+	Path string
+
+	// Old and New hold the values before and after the merge.
+	// Nil represents an absent field.
+	Old any
+	New any
+}
+
+// MergeDiff returns the changes Merge would apply without any side effects.
+// It shares Merge's structured merge computation so the two cannot diverge.
+// Only types with a structured merge schema are supported.
+func (r *Resource) MergeDiff(ctx context.Context, old *Resource, current *unstructured.Unstructured, sg SchemaGetter) ([]FieldChange, error) {
+	typeref, schem, err := sg.Get(ctx, r.GVK)
+	if err != nil {
+		return nil, fmt.Errorf("looking up schema: %w", err)
+	}
+	if typeref == nil {
+		return nil, fmt.Errorf("no structured merge schema is available for %s", r.GVK)
+	}
+
+	merged, typedCurrent, err := r.mergeTyped(old, current, typeref, schem)
+	if err != nil {
+		return nil, err
+	}
+	cmp, err := typedCurrent.Compare(merged)
+	if err != nil {
+		return nil, fmt.Errorf("comparing current and merged states: %w", err)
+	}
+
+	return changesBetween(cmp, current.Object, merged.AsValue().Unstructured()), nil
+}
+
+// DryRunDiff returns the changes applying the resource would cause according to
+// the apiserver itself. The merged state is round-tripped through a server-side
+// dry-run, so unlike MergeDiff the result reflects server defaulting and admission
+// webhook mutations - at the cost of an extra apiserver request per call.
+func (r *Resource) DryRunDiff(ctx context.Context, old *Resource, current *unstructured.Unstructured, cli client.Client, sg SchemaGetter) ([]FieldChange, error) {
+	typeref, schem, err := sg.Get(ctx, r.GVK)
+	if err != nil {
+		return nil, fmt.Errorf("looking up schema: %w", err)
+	}
+	if typeref == nil {
+		return nil, fmt.Errorf("no structured merge schema is available for %s", r.GVK)
+	}
+
+	merged, typedCurrent, err := r.mergeTyped(old, current, typeref, schem)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &unstructured.Unstructured{Object: merged.AsValue().Unstructured().(map[string]any)}
+	if err := cli.Update(ctx, obj, client.DryRunAll); err != nil {
+		return nil, fmt.Errorf("applying dry-run update: %w", err)
+	}
+
+	typedResult := typed.AsTypedUnvalidated(value.NewValueInterface(obj.Object), schem, *typeref)
+	cmp, err := typedCurrent.Compare(typedResult)
+	if err != nil {
+		return nil, fmt.Errorf("comparing current and dry-run states: %w", err)
+	}
+
+	// Drop bookkeeping fields the apiserver touches on every write - they aren't
+	// meaningful when previewing what would change about the desired state
+	changes := changesBetween(cmp, current.Object, obj.Object)
+	filtered := changes[:0]
+	for _, change := range changes {
+		if serverManagedPath(change.Path) {
+			continue
+		}
+		filtered = append(filtered, change)
+	}
+	return filtered, nil
+}
+
+func serverManagedPath(path string) bool {
+	for _, prefix := range []string{".metadata.managedFields", ".metadata.resourceVersion", ".metadata.generation"} {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// changesBetween converts a structured-merge-diff comparison into FieldChanges,
+// resolving the before/after values against the given objects.
+func changesBetween(cmp *typed.Comparison, oldObj, newObj any) []FieldChange {
+	var changes []FieldChange
+	appendChanges := func(set *fieldpath.Set) {
+		set.Iterate(func(p fieldpath.Path) {
+			changes = append(changes, FieldChange{
+				Path: p.String(),
+				Old:  valueAtPath(oldObj, p),
+				New:  valueAtPath(newObj, p),
+			})
+		})
+	}
+	appendChanges(cmp.Added)
+	appendChanges(cmp.Modified)
+	appendChanges(cmp.Removed)
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// comparisonPaths returns the sorted field paths touched by a comparison,
+// e.g. for logging which fields forced an update.
+func comparisonPaths(cmp *typed.Comparison) []string {
+	if cmp == nil {
+		return nil
+	}
+	var paths []string
+	appendPaths := func(set *fieldpath.Set) {
+		set.Iterate(func(p fieldpath.Path) {
+			paths = append(paths, p.String())
+		})
+	}
+	appendPaths(cmp.Added)
+	appendPaths(cmp.Modified)
+	appendPaths(cmp.Removed)
+
+	sort.Strings(paths)
+	return paths
+}
+
+// valueAtPath resolves a structured-merge-diff field path against an
+// unstructured object, returning nil when the path doesn't exist.
+func valueAtPath(obj any, path fieldpath.Path) any {
+	cur := obj
+	for _, pe := range path {
+		switch {
+		case pe.FieldName != nil:
+			m, ok := cur.(map[string]any)
+			if !ok {
+				return nil
+			}
+			cur, ok = m[*pe.FieldName]
+			if !ok {
+				return nil
+			}
+
+		case pe.Index != nil:
+			s, ok := cur.([]any)
+			if !ok || *pe.Index < 0 || *pe.Index >= len(s) {
+				return nil
+			}
+			cur = s[*pe.Index]
+
+		case pe.Key != nil:
+			s, ok := cur.([]any)
+			if !ok {
+				return nil
+			}
+			cur = nil
+			for _, item := range s {
+				m, ok := item.(map[string]any)
+				if !ok {
+					continue
+				}
+				match := true
+				for _, field := range *pe.Key {
+					if !value.Equals(field.Value, value.NewValueInterface(m[field.Name])) {
+						match = false
+						break
+					}
+				}
+				if match {
+					cur = item
+					break
+				}
+			}
+			if cur == nil {
+				return nil
+			}
+
+		case pe.Value != nil:
+			return (*pe.Value).Unstructured()
+
+		default:
+			return nil
+		}
+	}
+	return cur
+}
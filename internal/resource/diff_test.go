@@ -0,0 +1,165 @@
+package resource
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+func TestMergeDiff(t *testing.T) {
+	ctx := context.Background()
+	sg := newTestSchemaGetter(t, "io.k8s.api.apps.v1.Deployment")
+
+	slice := &apiv1.ResourceSlice{
+		Spec: apiv1.ResourceSliceSpec{
+			Resources: []apiv1.Manifest{{
+				Manifest: `{
+				  "apiVersion": "apps/v1",
+				  "kind": "Deployment",
+				  "metadata": {
+				    "name": "foo"
+				  },
+				  "spec": {
+				    "replicas": 2,
+					"template": {
+					  "spec": {
+					    "serviceAccountName": "updated"
+					  }
+				    }
+				  }
+				}`,
+			}},
+		},
+	}
+	res, err := NewResource(ctx, slice, 0)
+	require.NoError(t, err)
+
+	current := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "foo", "resourceVersion": "1"},
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{
+					"serviceAccountName": "original",
+				},
+			},
+		},
+	}}
+
+	changes, err := res.MergeDiff(ctx, nil, current, sg)
+	require.NoError(t, err)
+	require.Equal(t, []FieldChange{
+		{Path: ".spec.replicas", Old: nil, New: int64(2)},
+		{Path: ".spec.template.spec.serviceAccountName", Old: "original", New: "updated"},
+	}, changes)
+
+	// The current state is never mutated
+	sa, _, _ := unstructured.NestedString(current.Object, "spec", "template", "spec", "serviceAccountName")
+	assert.Equal(t, "original", sa)
+
+	// No changes are reported when the states already converged
+	merged, _, err := res.Merge(ctx, nil, current, sg)
+	require.NoError(t, err)
+	changes, err = res.MergeDiff(ctx, nil, merged, sg)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	// Untyped resources aren't supported
+	noSchema := newTestSchemaGetter(t, "")
+	_, err = res.MergeDiff(ctx, nil, current, noSchema)
+	require.ErrorContains(t, err, "no structured merge schema")
+}
+
+func TestDryRunDiff(t *testing.T) {
+	ctx := context.Background()
+	sg := newTestSchemaGetter(t, "io.k8s.api.apps.v1.Deployment")
+
+	env := &envtest.Environment{}
+	t.Cleanup(func() {
+		err := env.Stop()
+		if err != nil {
+			panic(err)
+		}
+	})
+	var cfg *rest.Config
+	var err error
+	for i := 0; i < 2; i++ {
+		cfg, err = env.Start()
+		if err != nil {
+			t.Logf("failed to start test environment: %s", err)
+			continue
+		}
+		break
+	}
+	require.NoError(t, err)
+	cli, err := client.New(cfg, client.Options{})
+	require.NoError(t, err)
+
+	// The live deployment pins revisionHistoryLimit to 5
+	current := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "foo", "namespace": "default"},
+		"spec": map[string]any{
+			"revisionHistoryLimit": 5,
+			"selector":             map[string]any{"matchLabels": map[string]any{"app": "foo"}},
+			"template": map[string]any{
+				"metadata": map[string]any{"labels": map[string]any{"app": "foo"}},
+				"spec": map[string]any{
+					"containers": []any{map[string]any{"name": "main", "image": "nginx"}},
+				},
+			},
+		},
+	}}
+	require.NoError(t, cli.Create(ctx, current))
+
+	newManifest := func(revisionHistoryLimit string) apiv1.Manifest {
+		return apiv1.Manifest{Manifest: `{
+		  "apiVersion": "apps/v1",
+		  "kind": "Deployment",
+		  "metadata": {
+		    "name": "foo",
+		    "namespace": "default"
+		  },
+		  "spec": {` + revisionHistoryLimit + `
+		    "selector": {"matchLabels": {"app": "foo"}},
+			"template": {
+			  "metadata": {"labels": {"app": "foo"}},
+			  "spec": {
+			    "containers": [{"name": "main", "image": "nginx"}]
+			  }
+		    }
+		  }
+		}`}
+	}
+
+	oldRes, err := NewResource(ctx, &apiv1.ResourceSlice{Spec: apiv1.ResourceSliceSpec{Resources: []apiv1.Manifest{newManifest(`"revisionHistoryLimit": 5,`)}}}, 0)
+	require.NoError(t, err)
+
+	// The new desired state no longer sets revisionHistoryLimit
+	res, err := NewResource(ctx, &apiv1.ResourceSlice{Spec: apiv1.ResourceSliceSpec{Resources: []apiv1.Manifest{newManifest("")}}}, 0)
+	require.NoError(t, err)
+
+	// The client-side diff can only see the field being removed
+	changes, err := res.MergeDiff(ctx, oldRes, current, sg)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, ".spec.revisionHistoryLimit", changes[0].Path)
+	assert.Nil(t, changes[0].New)
+
+	// The dry-run diff captures the server re-defaulting it to 10
+	changes, err = res.DryRunDiff(ctx, oldRes, current, cli, sg)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, ".spec.revisionHistoryLimit", changes[0].Path)
+	assert.Equal(t, int64(5), changes[0].Old)
+	assert.Equal(t, int64(10), changes[0].New)
+}
@@ -0,0 +1,52 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ExportPath returns the file path of a resource relative to an export root.
+// Resources are grouped by namespace and kind, with cluster-scoped resources
+// under a reserved "_cluster" directory. Kinds are qualified by their group to
+// disambiguate identically-named kinds across groups.
+func ExportPath(r *Resource) string {
+	ns := r.Ref.Namespace
+	if ns == "" {
+		ns = "_cluster"
+	}
+	kind := r.GVK.Kind
+	if r.GVK.Group != "" {
+		kind += "." + r.GVK.Group
+	}
+	return filepath.Join(ns, kind, r.Ref.Name+".json")
+}
+
+// ExportDir writes each resource to its ExportPath under dir, one file per
+// resource. The output is deterministic: resources are written in Less order
+// and serialized as indented JSON, so repeated exports of the same synthesis
+// produce identical trees suitable for committing to Git.
+func ExportDir(dir string, resources []*Resource) error {
+	sorted := make([]*Resource, len(resources))
+	copy(sorted, resources)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Less(sorted[j]) })
+
+	for _, res := range sorted {
+		js, err := json.MarshalIndent(res.Unstructured().Object, "", "  ")
+		if err != nil {
+			return fmt.Errorf("serializing resource %s: %w", res.Ref.String(), err)
+		}
+
+		path := filepath.Join(dir, ExportPath(res))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, append(js, '\n'), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package resource
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+)
+
+func TestExportDir(t *testing.T) {
+	ctx := context.Background()
+	slice := &apiv1.ResourceSlice{}
+	slice.Spec.Resources = []apiv1.Manifest{
+		{Manifest: `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "foo", "namespace": "default"}, "data": {"key": "value"}}`},
+		{Manifest: `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "bar", "namespace": "default"}}`},
+		{Manifest: `{"apiVersion": "v1", "kind": "Namespace", "metadata": {"name": "default"}}`},
+	}
+
+	var resources []*Resource
+	for i := range slice.Spec.Resources {
+		res, err := NewResource(ctx, slice, i)
+		require.NoError(t, err)
+		resources = append(resources, res)
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, ExportDir(dir, resources))
+
+	var paths []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			rel, _ := filepath.Rel(dir, path)
+			paths = append(paths, rel)
+		}
+		return err
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join("_cluster", "Namespace", "default.json"),
+		filepath.Join("default", "ConfigMap", "foo.json"),
+		filepath.Join("default", "Deployment.apps", "bar.json"),
+	}, paths)
+
+	js, err := os.ReadFile(filepath.Join(dir, "default", "ConfigMap", "foo.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, slice.Spec.Resources[0].Manifest, string(js))
+
+	// Exporting again produces identical contents
+	again, err := os.ReadFile(filepath.Join(dir, "default", "ConfigMap", "foo.json"))
+	require.NoError(t, err)
+	require.NoError(t, ExportDir(dir, resources))
+	assert.Equal(t, string(again), string(js))
+}
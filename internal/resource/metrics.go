@@ -0,0 +1,19 @@
+package resource
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	cacheStalenessGap = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "eno_cache_staleness_gap",
+			Help: "Difference between the newest resource slice version observed by the informers and the newest version held in the cache, per composition",
+		}, []string{"composition", "namespace"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(cacheStalenessGap)
+}
@@ -0,0 +1,75 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ProjectFields returns a copy of obj containing only the given dot-separated
+// field paths. Identifying fields (apiVersion, kind, name, namespace) are
+// always retained so consumers can still address the resource.
+func ProjectFields(obj *unstructured.Unstructured, paths []string) *unstructured.Unstructured {
+	out := &unstructured.Unstructured{Object: map[string]any{}}
+	out.SetGroupVersionKind(obj.GroupVersionKind())
+	out.SetName(obj.GetName())
+	out.SetNamespace(obj.GetNamespace())
+
+	for _, path := range paths {
+		fields := strings.Split(path, ".")
+		val, found, err := unstructured.NestedFieldNoCopy(obj.Object, fields...)
+		if !found || err != nil {
+			continue
+		}
+		// Errors are only possible when an intermediate field isn't a map,
+		// which NestedFieldNoCopy has already ruled out
+		_ = unstructured.SetNestedField(out.Object, runtime.DeepCopyJSONValue(val), fields...)
+	}
+
+	return out
+}
+
+// HashContent returns a stable hash of the resource's content, excluding
+// metadata and status. Only the hash is retained - the content itself is
+// never stored, so it's safe to use with Secrets.
+func HashContent(obj *unstructured.Unstructured) string {
+	copy := obj.DeepCopy()
+	unstructured.RemoveNestedField(copy.Object, "metadata")
+	unstructured.RemoveNestedField(copy.Object, "status")
+
+	js, err := json.Marshal(copy.Object)
+	if err != nil {
+		return ""
+	}
+	hash := fnv.New64()
+	hash.Write(js)
+	return strconv.FormatUint(hash.Sum64(), 16)
+}
+
+// HashProjection returns a stable hash of the values at the given dot-separated
+// field paths. Missing fields contribute nothing, so adding an unset field to
+// the projection doesn't change the hash.
+func HashProjection(obj *unstructured.Unstructured, paths []string) string {
+	sorted := slices.Clone(paths)
+	slices.Sort(sorted)
+
+	hash := fnv.New64()
+	for _, path := range sorted {
+		val, found, err := unstructured.NestedFieldNoCopy(obj.Object, strings.Split(path, ".")...)
+		if !found || err != nil {
+			continue
+		}
+		js, err := json.Marshal(val)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(hash, "%s=%s;", path, js)
+	}
+	return strconv.FormatUint(hash.Sum64(), 16)
+}
@@ -0,0 +1,71 @@
+package resource
+
+import (
+	"testing"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestProjectFields(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": "foo", "namespace": "default", "resourceVersion": "123"},
+		"data":       map[string]any{"keep": "yes", "drop": "no"},
+	}}
+
+	out := ProjectFields(obj, []string{"data.keep", "data.missing"})
+	assert.Equal(t, map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": "foo", "namespace": "default"},
+		"data":       map[string]any{"keep": "yes"},
+	}, out.Object)
+
+	// The original is not mutated
+	assert.Equal(t, "no", obj.Object["data"].(map[string]any)["drop"])
+}
+
+func TestHashProjection(t *testing.T) {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"data": map[string]any{"keep": "yes", "drop": "no"},
+	}}
+	initial := HashProjection(obj, []string{"data.keep"})
+
+	// Path order doesn't matter
+	assert.Equal(t, HashProjection(obj, []string{"data.missing", "data.keep"}), HashProjection(obj, []string{"data.keep", "data.missing"}))
+
+	// Changing an unprojected field doesn't change the hash
+	obj.Object["data"].(map[string]any)["drop"] = "changed"
+	assert.Equal(t, initial, HashProjection(obj, []string{"data.keep"}))
+
+	// Changing a projected field does
+	obj.Object["data"].(map[string]any)["keep"] = "changed"
+	assert.NotEqual(t, initial, HashProjection(obj, []string{"data.keep"}))
+}
+
+func TestHashContent(t *testing.T) {
+	newSecret := func(resourceVersion, value string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata":   map[string]any{"name": "foo", "namespace": "default", "resourceVersion": resourceVersion},
+			"data":       map[string]any{"password": value},
+		}}
+	}
+	newRevs := func(obj *unstructured.Unstructured) apiv1.InputRevisions {
+		ir := NewInputRevisions(obj, "test-key")
+		ir.ProjectedHash = HashContent(obj)
+		return *ir
+	}
+
+	// Metadata-only changes (e.g. resource version bumps) don't change the hash
+	initial := newRevs(newSecret("1", "hunter2"))
+	assert.False(t, initial.Less(newRevs(newSecret("2", "hunter2"))))
+
+	// Data changes are seen whether or not the resource version was bumped
+	assert.True(t, initial.Less(newRevs(newSecret("1", "rotated"))))
+	assert.True(t, initial.Less(newRevs(newSecret("2", "rotated"))))
+}
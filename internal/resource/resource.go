@@ -6,10 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash/fnv"
+	"io"
 	"maps"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -20,9 +22,11 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	smdschema "sigs.k8s.io/structured-merge-diff/v4/schema"
 	"sigs.k8s.io/structured-merge-diff/v4/typed"
@@ -35,6 +39,14 @@ var patchGVK = schema.GroupVersionKind{
 	Kind:    "Patch",
 }
 
+// Identity labels stamped onto synthesized resources for compositions that opt into
+// label-based pruning of stale resources.
+const (
+	CompositionNameLabelKey      = "eno.azure.io/composition-name"
+	CompositionNamespaceLabelKey = "eno.azure.io/composition-namespace"
+	SynthesisUUIDLabelKey        = "eno.azure.io/synthesis-uuid"
+)
+
 // Ref refers to a specific synthesized resource.
 type Ref struct {
 	Name, Namespace, Group, Kind string
@@ -57,22 +69,125 @@ type Resource struct {
 	ManifestRef       ManifestRef
 	ManifestHash      []byte
 	ReconcileInterval *metav1.Duration
-	GVK               schema.GroupVersionKind
-	ReadinessChecks   readiness.Checks
-	Patch             jsonpatch.Patch
-	DisableUpdates    bool
-	ReadinessGroup    int
-	Labels            map[string]string
+
+	// ReconcileJitter is the max fraction of ReconcileInterval randomly added to
+	// requeue delays, overriding the controller's default when > 0.
+	ReconcileJitter float64
+	GVK             schema.GroupVersionKind
+	ReadinessChecks readiness.Checks
+
+	// ReadinessDwell is how long a readiness outcome must hold before its
+	// transition is recorded, suppressing rapid flapping. Zero disables it.
+	ReadinessDwell time.Duration
+
+	Patch jsonpatch.Patch
+
+	// MergePatch is an RFC 7386 merge patch. Mutually exclusive with Patch.
+	MergePatch json.RawMessage
+
+	DisableUpdates bool
+	CreateOnly     bool
+
+	// SealAfterApply freezes the resource once it has been reconciled: drift
+	// correction and the reconcile interval stop entirely until the composition
+	// is resynthesized.
+	SealAfterApply bool
+
+	// Orphan resources are left behind when their composition is deleted while
+	// the rest of its resources are garbage collected e.g. for PVCs or namespaces
+	// that should be preserved.
+	Orphan         bool
+	ForceConflicts bool
+	Replace        bool
+	DryRunFirst    bool
+	ReadinessGroup int
+
+	// ReadinessGroupName optionally names this resource's readiness group so
+	// other resources can order themselves after it without coordinating
+	// integers across synthesizers.
+	ReadinessGroupName string
+
+	// ReadinessAfter defers this resource until every resource in each named
+	// readiness group has become ready.
+	ReadinessAfter []string
+
+	// ReadinessOptional excludes this resource's readiness from the composition's
+	// aggregate readiness, and from gating later readiness groups. Its individual
+	// readiness state is still evaluated and recorded.
+	ReadinessOptional bool
+
+	Labels map[string]string
+
+	// ListMergeKeys maps dotted field paths to the item property used to merge keyed lists
+	// when no structured merge schema is available for the resource's type.
+	ListMergeKeys map[string]string
+
+	// PatchSelector scopes a patch to every matching resource in the patch's namespace
+	// rather than a single named target. Only additive operations are allowed.
+	PatchSelector labels.Selector
+
+	// TargetKubeconfigSecret names a secret in the composition's namespace whose
+	// "kubeconfig" key holds the kubeconfig of the cluster this resource should be
+	// reconciled into, overriding the composition's default target cluster.
+	TargetKubeconfigSecret string
+
+	// TargetCluster names a cluster from the controller's registry of spoke
+	// cluster clients that this resource should be reconciled into. Takes
+	// precedence over TargetKubeconfigSecret. Empty applies locally.
+	TargetCluster string
 
 	// DefinedGroupKind is set on CRDs to represent the resource type they define.
 	DefinedGroupKind *schema.GroupKind
 
-	value            value.Value
-	latestKnownState atomic.Pointer[apiv1.ResourceState]
+	value                value.Value
+	latestKnownState     atomic.Pointer[apiv1.ResourceState]
+	readinessObservation atomic.Pointer[readinessObservation]
+	validationErrors     []error
+}
+
+// ValidationErrors returns the non-fatal issues encountered while parsing the
+// resource e.g. readiness checks that failed to compile. The resource is still
+// usable - callers can surface these so typos don't go unnoticed.
+func (r *Resource) ValidationErrors() []error {
+	return r.validationErrors
+}
+
+type readinessObservation struct {
+	ready bool
+	since time.Time
+}
+
+// ObserveReadiness debounces readiness transitions. It returns true once the
+// given outcome has held for at least the resource's readiness dwell period,
+// meaning the transition can be recorded without churning on rapid flaps.
+// Always true for resources without a dwell period.
+func (r *Resource) ObserveReadiness(ready bool, now time.Time) bool {
+	if r.ReadinessDwell <= 0 {
+		return true
+	}
+	obs := r.readinessObservation.Load()
+	if obs == nil || obs.ready != ready {
+		r.readinessObservation.Store(&readinessObservation{ready: ready, since: now})
+		return false
+	}
+	return now.Sub(obs.since) >= r.ReadinessDwell
 }
 
 func (r *Resource) Deleted(comp *apiv1.Composition) bool {
-	return (comp.DeletionTimestamp != nil && !comp.ShouldOrphanResources()) || r.ManifestDeleted || (r.Patch != nil && r.patchSetsDeletionTimestamp())
+	return (comp.DeletionTimestamp != nil && !comp.ShouldOrphanResources()) || r.ManifestDeleted || (r.IsPatch() && r.patchSetsDeletionTimestamp())
+}
+
+// IsPatch returns true when the resource is an Eno patch pseudo-resource of either flavor.
+func (r *Resource) IsPatch() bool {
+	return r.Patch != nil || r.MergePatch != nil
+}
+
+// applyPatch applies the resource's patch (JSON patch or merge patch) to the given json document.
+func (r *Resource) applyPatch(js []byte) ([]byte, error) {
+	if r.MergePatch != nil {
+		return jsonpatch.MergePatch(js, r.MergePatch)
+	}
+	return r.Patch.Apply(js)
 }
 
 func (r *Resource) Unstructured() *unstructured.Unstructured {
@@ -82,7 +197,7 @@ func (r *Resource) Unstructured() *unstructured.Unstructured {
 func (r *Resource) State() *apiv1.ResourceState { return r.latestKnownState.Load() }
 
 func (r *Resource) NeedsToBePatched(current *unstructured.Unstructured) bool {
-	if r.Patch == nil || current == nil {
+	if !r.IsPatch() || current == nil {
 		return false
 	}
 
@@ -91,7 +206,7 @@ func (r *Resource) NeedsToBePatched(current *unstructured.Unstructured) bool {
 		return false
 	}
 
-	patchedjson, err := r.Patch.Apply(curjson)
+	patchedjson, err := r.applyPatch(curjson)
 	if err != nil {
 		return false
 	}
@@ -105,14 +220,31 @@ func (r *Resource) NeedsToBePatched(current *unstructured.Unstructured) bool {
 	return !equality.Semantic.DeepEqual(current, patched)
 }
 
+// PatchIsNoOp returns true when applying the patch can never modify a target,
+// e.g. when it contains only test ops. Lets callers skip listing candidate
+// targets for selector-scoped patches.
+func (r *Resource) PatchIsNoOp() bool {
+	if r.MergePatch != nil {
+		return false
+	}
+	for _, op := range r.Patch {
+		if op.Kind() != "test" {
+			return false
+		}
+	}
+	return true
+}
+
 func (r *Resource) patchSetsDeletionTimestamp() bool {
-	if r.Patch == nil {
+	// Selector-scoped patches target many resources and are restricted to
+	// additive ops - never treat them as deletions of the patch resource.
+	if !r.IsPatch() || r.PatchSelector != nil {
 		return false
 	}
 
 	// Apply the patch to a minimally-viable unstructured resource.
 	// This is needed to satisfy the validation logic of the unstructured json parser, which requires a kind/apiVersion.
-	patchedjson, err := r.Patch.Apply([]byte(`{"apiVersion": "eno.azure.io/v1", "kind":"PatchPlaceholder", "metadata":{}}`))
+	patchedjson, err := r.applyPatch([]byte(`{"apiVersion": "eno.azure.io/v1", "kind":"PatchPlaceholder", "metadata":{}}`))
 	if err != nil {
 		return false
 	}
@@ -131,6 +263,44 @@ type SchemaGetter interface {
 	Get(ctx context.Context, gvk schema.GroupVersionKind) (typeref *smdschema.TypeRef, schem *smdschema.Schema, err error)
 }
 
+// NewCachedSchemaGetter wraps a SchemaGetter with per-GVK memoization so
+// merging many resources of the same kind shares a single schema lookup.
+// Entries are never invalidated, so scope instances to one pass over a
+// synthesis rather than holding them across resyntheses. Safe for concurrent use.
+func NewCachedSchemaGetter(next SchemaGetter) SchemaGetter {
+	return &cachedSchemaGetter{next: next, entries: map[schema.GroupVersionKind]*schemaCacheEntry{}}
+}
+
+type cachedSchemaGetter struct {
+	next    SchemaGetter
+	mut     sync.Mutex
+	entries map[schema.GroupVersionKind]*schemaCacheEntry
+}
+
+type schemaCacheEntry struct {
+	typeref *smdschema.TypeRef
+	schem   *smdschema.Schema
+}
+
+func (c *cachedSchemaGetter) Get(ctx context.Context, gvk schema.GroupVersionKind) (*smdschema.TypeRef, *smdschema.Schema, error) {
+	c.mut.Lock()
+	entry, ok := c.entries[gvk]
+	c.mut.Unlock()
+	if ok {
+		return entry.typeref, entry.schem, nil
+	}
+
+	typeref, schem, err := c.next.Get(ctx, gvk)
+	if err != nil {
+		return nil, nil, err // don't cache failures - they may be transient
+	}
+
+	c.mut.Lock()
+	c.entries[gvk] = &schemaCacheEntry{typeref: typeref, schem: schem}
+	c.mut.Unlock()
+	return typeref, schem, nil
+}
+
 // Merge performs a three-way merge between the resource, it's old/previous Resource, and the current state.
 // Falls back to a non-structured three-way merge if the SchemaGetter returns a nil TypeRef.
 func (r *Resource) Merge(ctx context.Context, old *Resource, current *unstructured.Unstructured, sg SchemaGetter) (*unstructured.Unstructured, bool /* typed */, error) {
@@ -174,44 +344,197 @@ func (r *Resource) Merge(ctx context.Context, old *Resource, current *unstructur
 			return nil, false, fmt.Errorf("parsing patched resource: %w", err)
 		}
 
-		if equality.Semantic.DeepEqual(current, patched) {
+		// JSON merge patches replace lists wholesale, so honor any configured merge key hints
+		// to preserve keyed list items that exist only in the current state.
+		for path, key := range r.ListMergeKeys {
+			mergeKeyedLists(r.Unstructured().Object, current.Object, patched.Object, strings.Split(path, "."), key)
+		}
+
+		// Compare normalized representations of both sides so differences that only
+		// exist because of the json round trip (number types, explicit nulls) don't
+		// register as changes - otherwise semantically equal states cause phantom updates.
+		normalizedCurrent := &unstructured.Unstructured{}
+		err = normalizedCurrent.UnmarshalJSON(currentJS)
+		if err != nil {
+			return nil, false, fmt.Errorf("parsing current resource: %w", err)
+		}
+		if equality.Semantic.DeepEqual(dropNulls(normalizedCurrent.Object), dropNulls(patched.Object)) {
 			return nil, false, nil
 		}
+		logr.FromContextOrDiscard(ctx).V(1).Info("resource merge requires an update", "gvk", r.GVK.String(), "typed", false)
 		return patched, false, nil
 	}
 
+	merged, typedCurrent, err := r.mergeTyped(old, current, typeref, schem)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Bail out if no changes are required
+	cmp, err := merged.Compare(typedCurrent)
+	if err == nil && cmp.IsSame() {
+		return nil, true, nil // no changes
+	}
+	logr.FromContextOrDiscard(ctx).V(1).Info("resource merge requires an update", "gvk", r.GVK.String(), "typed", true, "changedPaths", comparisonPaths(cmp))
+
+	copy := &unstructured.Unstructured{Object: merged.AsValue().Unstructured().(map[string]any)}
+	return copy, true, nil
+}
+
+// dropNulls returns a copy of the given json-compatible value with null object
+// properties removed, since merge patching strips them while live objects may
+// still carry them.
+func dropNulls(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, val := range v {
+			if val == nil {
+				continue
+			}
+			out[key] = dropNulls(val)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, val := range v {
+			out[i] = dropNulls(val)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// mergeTyped implements the structured three-way merge shared by Merge and MergeDiff.
+func (r *Resource) mergeTyped(old *Resource, current *unstructured.Unstructured, typeref *smdschema.TypeRef, schem *smdschema.Schema) (merged, typedCurrent *typed.TypedValue, err error) {
 	// Convert to SMD values
 	currentVal := value.NewValueInterface(current.Object)
 	typedNew := typed.AsTypedUnvalidated(r.value, schem, *typeref)
-	typedCurrent := typed.AsTypedUnvalidated(currentVal, schem, *typeref)
+	typedCurrent = typed.AsTypedUnvalidated(currentVal, schem, *typeref)
 
 	// Merge properties that are set in the new state onto the current state
-	merged, err := typedCurrent.Merge(typedNew)
+	merged, err = typedCurrent.Merge(typedNew)
 	if err != nil {
-		return nil, false, fmt.Errorf("merging new state into current: %w", err)
+		return nil, nil, fmt.Errorf("merging new state into current: %w", err)
 	}
 
 	// Prune properties that were present in the old state but not the new
 	if old != nil {
 		typedOld, err := typed.AsTyped(old.value, schem, *typeref)
 		if err != nil {
-			return nil, false, fmt.Errorf("converting old version to typed: %w", err)
+			return nil, nil, fmt.Errorf("converting old version to typed: %w", err)
 		}
 		toOld, err := typedOld.Compare(typedNew)
 		if err != nil {
-			return nil, false, fmt.Errorf("comparing new and old states: %w", err)
+			return nil, nil, fmt.Errorf("comparing new and old states: %w", err)
 		}
 		merged = merged.RemoveItems(toOld.Removed)
 	}
 
-	// Bail out if no changes are required
-	cmp, err := merged.Compare(typedCurrent)
-	if err == nil && cmp.IsSame() {
-		return nil, true, nil // no changes
+	return merged, typedCurrent, nil
+}
+
+// ManifestError describes why a manifest within a resource slice couldn't be
+// parsed into a Resource. Callers can use errors.As to recover the failing
+// manifest's index and a machine-readable reason.
+type ManifestError struct {
+	Index  int // position of the manifest within its slice
+	Reason string
+	Err    error // underlying cause, if any
+}
+
+func (e *ManifestError) Error() string {
+	if e.Err == nil {
+		return e.Reason
 	}
+	return fmt.Sprintf("%s: %s", e.Reason, e.Err)
+}
 
-	copy := &unstructured.Unstructured{Object: merged.AsValue().Unstructured().(map[string]any)}
-	return copy, true, nil
+func (e *ManifestError) Unwrap() error { return e.Err }
+
+// NewResources parses every manifest of the slice in one pass, expanding
+// multi-document YAML streams into one Resource per document. Prefer it over
+// per-manifest NewResource calls when loading whole slices.
+func NewResources(ctx context.Context, slice *apiv1.ResourceSlice) ([]*Resource, error) {
+	resources := make([]*Resource, 0, len(slice.Spec.Resources))
+	for i := range slice.Spec.Resources {
+		expanded, err := expandManifest(ctx, slice, i, len(resources))
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, expanded...)
+	}
+	return resources, nil
+}
+
+// expandManifest parses the manifest at the given index like NewResource, but
+// expands multi-document YAML streams into one Resource per document.
+// ManifestRef indexes increment from refIndex, which equals index unless a
+// preceding manifest in the slice contained more than one document.
+// Each resource's manifest hash covers only its own document so ordering via
+// Less stays stable regardless of how the documents are bundled.
+func expandManifest(ctx context.Context, slice *apiv1.ResourceSlice, index, refIndex int) ([]*Resource, error) {
+	docs, err := splitManifestDocuments(slice.Spec.Resources[index].Manifest)
+	if err != nil {
+		return nil, &ManifestError{Index: index, Reason: "invalid yaml stream", Err: err}
+	}
+
+	resources := make([]*Resource, 0, len(docs))
+	for i, doc := range docs {
+		sub := &apiv1.ResourceSlice{}
+		sub.Name = slice.Name
+		sub.Namespace = slice.Namespace
+		sub.Spec.Resources = make([]apiv1.Manifest, refIndex+i+1)
+		mani := slice.Spec.Resources[index]
+		mani.Manifest = doc
+		sub.Spec.Resources[refIndex+i] = mani
+
+		res, err := NewResource(ctx, sub, refIndex+i)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, res)
+	}
+	return resources, nil
+}
+
+// CountManifestDocuments returns the number of resources the given manifest
+// expands to i.e. the number of documents in a YAML stream, or 1 for
+// single-object JSON manifests. Manifests that can't be parsed count as one.
+func CountManifestDocuments(manifest string) int {
+	docs, err := splitManifestDocuments(manifest)
+	if err != nil || len(docs) == 0 {
+		return 1
+	}
+	return len(docs)
+}
+
+// splitManifestDocuments returns each document of the manifest as JSON.
+// Single-object JSON manifests are passed through byte-for-byte so their
+// manifest hashes are unchanged from previous versions of Eno.
+func splitManifestDocuments(manifest string) ([]string, error) {
+	if trimmed := strings.TrimSpace(manifest); len(trimmed) > 0 && trimmed[0] == '{' {
+		return []string{manifest}, nil // single json object - the common case
+	}
+
+	dec := k8syaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096)
+	var docs []string
+	for {
+		var doc json.RawMessage
+		err := dec.Decode(&doc)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if trimmed := bytes.TrimSpace(doc); len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+			continue // empty document
+		}
+		docs = append(docs, string(doc))
+	}
+	return docs, nil
 }
 
 func NewResource(ctx context.Context, slice *apiv1.ResourceSlice, index int) (*Resource, error) {
@@ -235,7 +558,7 @@ func NewResource(ctx context.Context, slice *apiv1.ResourceSlice, index int) (*R
 	parsed := &unstructured.Unstructured{}
 	err := parsed.UnmarshalJSON([]byte(resource.Manifest))
 	if err != nil {
-		return nil, fmt.Errorf("invalid json: %w", err)
+		return nil, &ManifestError{Index: index, Reason: "invalid json", Err: err}
 	}
 
 	// Prune out the status/creation time.
@@ -256,7 +579,7 @@ func NewResource(ctx context.Context, slice *apiv1.ResourceSlice, index int) (*R
 	logger = logger.WithValues("resourceKind", parsed.GetKind(), "resourceName", parsed.GetName(), "resourceNamespace", parsed.GetNamespace())
 
 	if res.Ref.Name == "" || res.Ref.Kind == "" || parsed.GetAPIVersion() == "" {
-		return nil, fmt.Errorf("missing name, kind, or apiVersion")
+		return nil, &ManifestError{Index: index, Reason: "missing name, kind, or apiVersion"}
 	}
 
 	if res.GVK == patchGVK {
@@ -265,16 +588,46 @@ func NewResource(ctx context.Context, slice *apiv1.ResourceSlice, index int) (*R
 		}{}
 		err = json.Unmarshal([]byte(resource.Manifest), &obj)
 		if err != nil {
-			return nil, fmt.Errorf("parsing patch json: %w", err)
+			return nil, &ManifestError{Index: index, Reason: "parsing patch json", Err: err}
 		}
 		gv, err := schema.ParseGroupVersion(obj.Patch.APIVersion)
 		if err != nil {
-			return nil, fmt.Errorf("parsing patch apiVersion: %w", err)
+			return nil, &ManifestError{Index: index, Reason: "parsing patch apiVersion", Err: err}
 		}
 		res.GVK.Group = gv.Group
 		res.GVK.Version = gv.Version
 		res.GVK.Kind = obj.Patch.Kind
 		res.Patch = obj.Patch.Ops
+
+		if len(obj.Patch.MergePatch) > 0 {
+			if len(obj.Patch.Ops) > 0 {
+				return nil, &ManifestError{Index: index, Reason: "patches cannot set both ops and mergePatch"}
+			}
+			if len(obj.Patch.LabelSelector) > 0 || obj.Patch.TargetSelector != nil {
+				return nil, &ManifestError{Index: index, Reason: "patches scoped by label selector do not support mergePatch"}
+			}
+			res.MergePatch = obj.Patch.MergePatch
+		}
+
+		if len(obj.Patch.LabelSelector) > 0 || obj.Patch.TargetSelector != nil {
+			if len(obj.Patch.LabelSelector) > 0 && obj.Patch.TargetSelector != nil {
+				return nil, &ManifestError{Index: index, Reason: "patches cannot set both labelSelector and targetSelector"}
+			}
+			for _, op := range obj.Patch.Ops {
+				if kind := op.Kind(); kind != "add" && kind != "test" {
+					return nil, &ManifestError{Index: index, Reason: fmt.Sprintf("patches scoped by label selector only support additive operations, got %q", kind)}
+				}
+			}
+			ls := obj.Patch.TargetSelector
+			if ls == nil {
+				ls = &metav1.LabelSelector{MatchLabels: obj.Patch.LabelSelector}
+			}
+			selector, err := metav1.LabelSelectorAsSelector(ls)
+			if err != nil {
+				return nil, &ManifestError{Index: index, Reason: "parsing patch targetSelector", Err: err}
+			}
+			res.PatchSelector = selector
+		}
 	}
 
 	if res.GVK.Group == "apiextensions.k8s.io" && res.GVK.Kind == "CustomResourceDefinition" {
@@ -298,9 +651,80 @@ func NewResource(ctx context.Context, slice *apiv1.ResourceSlice, index int) (*R
 		res.ReconcileInterval = &metav1.Duration{Duration: reconcileInterval}
 	}
 
+	const reconcileJitterKey = "eno.azure.io/reconcile-jitter"
+	if str, ok := anno[reconcileJitterKey]; ok && str != "" {
+		switch {
+		case res.ReconcileInterval == nil || res.ReconcileInterval.Duration <= 0:
+			logger.V(0).Info("reconcile jitter requires a reconcile interval - ignoring")
+		case strings.HasSuffix(str, "%"):
+			pct, err := strconv.ParseFloat(strings.TrimSuffix(str, "%"), 64)
+			if err != nil || pct < 0 {
+				logger.V(0).Info("invalid reconcile jitter - ignoring")
+			} else {
+				res.ReconcileJitter = pct / 100
+			}
+		default:
+			dur, err := time.ParseDuration(str)
+			if err != nil || dur < 0 {
+				logger.V(0).Info("invalid reconcile jitter - ignoring")
+			} else {
+				res.ReconcileJitter = dur.Seconds() / res.ReconcileInterval.Duration.Seconds()
+			}
+		}
+	}
+
+	const readinessDwellKey = "eno.azure.io/readiness-dwell"
+	if str, ok := anno[readinessDwellKey]; ok && str != "" {
+		dwell, err := time.ParseDuration(str)
+		if err != nil || dwell < 0 {
+			logger.V(0).Info("invalid readiness dwell - ignoring")
+		} else {
+			res.ReadinessDwell = dwell
+		}
+	}
+
 	const disableUpdatesKey = "eno.azure.io/disable-updates"
 	res.DisableUpdates = anno[disableUpdatesKey] == "true"
 
+	const createOnlyKey = "eno.azure.io/create-only"
+	res.CreateOnly = anno[createOnlyKey] == "true"
+
+	const sealAfterApplyKey = "eno.azure.io/seal-after-apply"
+	res.SealAfterApply = anno[sealAfterApplyKey] == "true"
+
+	const orphanKey = "eno.azure.io/orphan"
+	res.Orphan = anno[orphanKey] == "true"
+
+	const targetKubeconfigSecretKey = "eno.azure.io/target-kubeconfig-secret"
+	res.TargetKubeconfigSecret = anno[targetKubeconfigSecretKey]
+
+	const targetClusterKey = "eno.azure.io/target-cluster"
+	res.TargetCluster = anno[targetClusterKey]
+
+	const forceConflictsKey = "eno.azure.io/force-conflicts"
+	res.ForceConflicts = anno[forceConflictsKey] == "true"
+
+	const replaceOnConflictKey = "eno.azure.io/replace-on-conflict"
+	res.Replace = anno[replaceOnConflictKey] == "true"
+
+	const dryRunFirstKey = "eno.azure.io/dry-run-first"
+	res.DryRunFirst = anno[dryRunFirstKey] == "true"
+
+	const listMergeKeysKey = "eno.azure.io/list-merge-keys"
+	if str, ok := anno[listMergeKeysKey]; ok && str != "" {
+		for _, hint := range strings.Split(str, ",") {
+			path, key, found := strings.Cut(hint, "=")
+			if !found || path == "" || key == "" {
+				logger.V(0).Info("invalid list merge key hint - ignoring", "hint", hint)
+				continue
+			}
+			if res.ListMergeKeys == nil {
+				res.ListMergeKeys = map[string]string{}
+			}
+			res.ListMergeKeys[strings.TrimSpace(path)] = strings.TrimSpace(key)
+		}
+	}
+
 	const readinessGroupKey = "eno.azure.io/readiness-group"
 	if str, ok := anno[readinessGroupKey]; ok {
 		rg, err := strconv.Atoi(str)
@@ -309,10 +733,31 @@ func NewResource(ctx context.Context, slice *apiv1.ResourceSlice, index int) (*R
 		} else {
 			res.ReadinessGroup = rg
 		}
+	} else {
+		res.ReadinessGroup = defaultReadinessGroup(res.GVK)
+	}
+
+	const readinessGroupNameKey = "eno.azure.io/readiness-group-name"
+	res.ReadinessGroupName = anno[readinessGroupNameKey]
+
+	const readinessAfterKey = "eno.azure.io/readiness-after"
+	if str, ok := anno[readinessAfterKey]; ok && str != "" {
+		for _, name := range strings.Split(str, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				res.ReadinessAfter = append(res.ReadinessAfter, name)
+			}
+		}
 	}
 
+	const readinessOptionalKey = "eno.azure.io/readiness-optional"
+	res.ReadinessOptional = anno[readinessOptionalKey] == "true"
+
 	for key, value := range anno {
-		if !strings.HasPrefix(key, "eno.azure.io/readiness") || key == readinessGroupKey {
+		if !strings.HasPrefix(key, "eno.azure.io/readiness") {
+			continue
+		}
+		switch key {
+		case readinessGroupKey, readinessGroupNameKey, readinessAfterKey, readinessDwellKey, readinessOptionalKey:
 			continue
 		}
 
@@ -324,6 +769,7 @@ func NewResource(ctx context.Context, slice *apiv1.ResourceSlice, index int) (*R
 		check, err := readiness.ParseCheck(value)
 		if err != nil {
 			logger.Error(err, "invalid cel expression")
+			res.validationErrors = append(res.validationErrors, fmt.Errorf("readiness check %q failed to compile: %w", name, err))
 			continue
 		}
 		check.Name = name
@@ -337,8 +783,110 @@ func NewResource(ctx context.Context, slice *apiv1.ResourceSlice, index int) (*R
 	return res, nil
 }
 
+// mergeKeyedLists walks the expected, current, and patched trees along the given path
+// and merges the lists found at its end by the given key. Intermediate lists are traversed
+// positionally, pairing patched elements with the current/expected elements at the same index.
+func mergeKeyedLists(expected, current, patched map[string]any, path []string, key string) {
+	if len(path) == 0 {
+		return
+	}
+	field := path[0]
+	if len(path) == 1 {
+		exp, _ := expected[field].([]any)
+		cur, _ := current[field].([]any)
+		if exp == nil && cur == nil {
+			return
+		}
+		patched[field] = mergeListByKey(exp, cur, key)
+		return
+	}
+
+	switch next := patched[field].(type) {
+	case map[string]any:
+		exp, _ := expected[field].(map[string]any)
+		cur, _ := current[field].(map[string]any)
+		mergeKeyedLists(exp, cur, next, path[1:], key)
+	case []any:
+		exp, _ := expected[field].([]any)
+		cur, _ := current[field].([]any)
+		for i, item := range next {
+			itemMap, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			var expItem, curItem map[string]any
+			if i < len(exp) {
+				expItem, _ = exp[i].(map[string]any)
+			}
+			if i < len(cur) {
+				curItem, _ = cur[i].(map[string]any)
+			}
+			mergeKeyedLists(expItem, curItem, itemMap, path[1:], key)
+		}
+	}
+}
+
+// mergeListByKey overlays the expected list onto the current one, replacing items that
+// share the same key and appending the rest. Duplicate keys are dropped.
+func mergeListByKey(expected, current []any, key string) []any {
+	itemKey := func(item any) (string, bool) {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return "", false
+		}
+		val, ok := m[key].(string)
+		return val, ok
+	}
+
+	out := make([]any, 0, len(current)+len(expected))
+	index := map[string]int{}
+	for _, item := range current {
+		k, ok := itemKey(item)
+		if !ok {
+			out = append(out, item)
+			continue
+		}
+		if _, dup := index[k]; dup {
+			continue
+		}
+		index[k] = len(out)
+		out = append(out, item)
+	}
+	for _, item := range expected {
+		k, ok := itemKey(item)
+		if !ok {
+			out = append(out, item)
+			continue
+		}
+		if i, ok := index[k]; ok {
+			out[i] = item
+			continue
+		}
+		index[k] = len(out)
+		out = append(out, item)
+	}
+	return out
+}
+
+// retainedMetadataKeys allowlists eno-prefixed annotation/label keys that
+// survive pruneMetadata. Empty by default - configured once at startup.
+var retainedMetadataKeys = map[string]struct{}{}
+
+// RetainMetadataKeys exempts the given eno.azure.io annotation and label keys
+// from metadata pruning, so they're written to the cluster with the rest of
+// the resource e.g. for external debugging tools. Call before any resources
+// are parsed - registration is not concurrency safe.
+func RetainMetadataKeys(keys ...string) {
+	for _, key := range keys {
+		retainedMetadataKeys[key] = struct{}{}
+	}
+}
+
 func pruneMetadata(m map[string]string) map[string]string {
 	maps.DeleteFunc(m, func(key string, value string) bool {
+		if _, ok := retainedMetadataKeys[key]; ok {
+			return false
+		}
 		return strings.HasPrefix(key, "eno.azure.io/")
 	})
 	if len(m) == 0 {
@@ -347,6 +895,20 @@ func pruneMetadata(m map[string]string) map[string]string {
 	return m
 }
 
+// defaultReadinessGroup orders kinds that other resources commonly depend on ahead of the rest
+// when no explicit readiness group is set. Negative groups keep explicitly configured orderings,
+// which conventionally start at zero, intact.
+func defaultReadinessGroup(gvk schema.GroupVersionKind) int {
+	switch {
+	case gvk.Group == "" && gvk.Kind == "Namespace":
+		return -2
+	case gvk.Group == "apiextensions.k8s.io" && gvk.Kind == "CustomResourceDefinition":
+		return -1
+	default:
+		return 0
+	}
+}
+
 // Less returns true when r < than.
 // Used to establish determinstic ordering for conflicting resources.
 func (r *Resource) Less(than *Resource) bool {
@@ -354,16 +916,59 @@ func (r *Resource) Less(than *Resource) bool {
 }
 
 type patchMeta struct {
-	APIVersion string          `json:"apiVersion"`
-	Kind       string          `json:"kind"`
-	Ops        jsonpatch.Patch `json:"ops"`
+	APIVersion    string            `json:"apiVersion"`
+	Kind          string            `json:"kind"`
+	Ops           jsonpatch.Patch   `json:"ops"`
+	MergePatch    json.RawMessage   `json:"mergePatch"`
+	LabelSelector map[string]string `json:"labelSelector"`
+
+	// TargetSelector is the full-fidelity form of labelSelector, supporting
+	// matchExpressions in addition to exact label matches. Mutually exclusive
+	// with labelSelector.
+	TargetSelector *metav1.LabelSelector `json:"targetSelector"`
+}
+
+// RevisionExtractor computes the InputRevisions of an input resource, e.g. to
+// version inputs by status.observedGeneration or a spec hash instead of the
+// default annotation-based logic so resynthesis is triggered by semantically
+// meaningful changes rather than every resourceVersion bump.
+type RevisionExtractor interface {
+	ExtractRevisions(obj client.Object, refKey string, projection ...string) *apiv1.InputRevisions
+}
+
+var (
+	revisionExtractorMut sync.Mutex
+	revisionExtractors   = map[schema.GroupVersionKind]RevisionExtractor{}
+)
+
+// RegisterRevisionExtractor overrides how InputRevisions are computed for
+// inputs of the given GVK. Register early - typically from an init function or
+// before controllers are started - since inputs observed before registration
+// use the default logic.
+func RegisterRevisionExtractor(gvk schema.GroupVersionKind, ex RevisionExtractor) {
+	revisionExtractorMut.Lock()
+	defer revisionExtractorMut.Unlock()
+	revisionExtractors[gvk] = ex
+}
+
+func revisionExtractorFor(gvk schema.GroupVersionKind) RevisionExtractor {
+	revisionExtractorMut.Lock()
+	defer revisionExtractorMut.Unlock()
+	return revisionExtractors[gvk]
 }
 
-func NewInputRevisions(obj client.Object, refKey string) *apiv1.InputRevisions {
+func NewInputRevisions(obj client.Object, refKey string, projection ...string) *apiv1.InputRevisions {
+	if ex := revisionExtractorFor(obj.GetObjectKind().GroupVersionKind()); ex != nil {
+		return ex.ExtractRevisions(obj, refKey, projection...)
+	}
+
 	ir := apiv1.InputRevisions{
 		Key:             refKey,
 		ResourceVersion: obj.GetResourceVersion(),
 	}
+	if u, ok := obj.(*unstructured.Unstructured); ok && len(projection) > 0 {
+		ir.ProjectedHash = HashProjection(u, projection)
+	}
 	if rev, _ := strconv.Atoi(obj.GetAnnotations()["eno.azure.io/revision"]); rev != 0 {
 		ir.Revision = &rev
 	}
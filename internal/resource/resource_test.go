@@ -2,12 +2,17 @@ package resource
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
 	openapi_v2 "github.com/google/gnostic-models/openapiv2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,6 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/kube-openapi/pkg/schemaconv"
 	"k8s.io/kube-openapi/pkg/util/proto"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	smdschema "sigs.k8s.io/structured-merge-diff/v4/schema"
 )
 
@@ -55,6 +61,154 @@ var newResourceTests = []struct {
 			assert.Equal(t, int(250), r.ReadinessGroup)
 		},
 	},
+	{
+		Name: "create-only",
+		Manifest: `{
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"metadata": {
+				"name": "foo",
+				"annotations": {
+					"eno.azure.io/create-only": "true"
+				}
+			}
+		}`,
+		Assert: func(t *testing.T, r *Resource) {
+			assert.True(t, r.CreateOnly)
+		},
+	},
+	{
+		Name: "seal-after-apply",
+		Manifest: `{
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"metadata": {
+				"name": "foo",
+				"annotations": {
+					"eno.azure.io/seal-after-apply": "true"
+				}
+			}
+		}`,
+		Assert: func(t *testing.T, r *Resource) {
+			assert.True(t, r.SealAfterApply)
+		},
+	},
+	{
+		Name: "orphan",
+		Manifest: `{
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"metadata": {
+				"name": "foo",
+				"annotations": {
+					"eno.azure.io/orphan": "true"
+				}
+			}
+		}`,
+		Assert: func(t *testing.T, r *Resource) {
+			assert.True(t, r.Orphan)
+		},
+	},
+	{
+		Name: "force-conflicts",
+		Manifest: `{
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"metadata": {
+				"name": "foo",
+				"annotations": {
+					"eno.azure.io/force-conflicts": "true"
+				}
+			}
+		}`,
+		Assert: func(t *testing.T, r *Resource) {
+			assert.True(t, r.ForceConflicts)
+		},
+	},
+	{
+		Name: "replace-on-conflict",
+		Manifest: `{
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"metadata": {
+				"name": "foo",
+				"annotations": {
+					"eno.azure.io/replace-on-conflict": "true"
+				}
+			}
+		}`,
+		Assert: func(t *testing.T, r *Resource) {
+			assert.True(t, r.Replace)
+			assert.Nil(t, r.Unstructured().GetAnnotations())
+		},
+	},
+	{
+		Name: "reconcile-jitter-percent",
+		Manifest: `{
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"metadata": {
+				"name": "foo",
+				"annotations": {
+					"eno.azure.io/reconcile-interval": "10s",
+					"eno.azure.io/reconcile-jitter": "20%"
+				}
+			}
+		}`,
+		Assert: func(t *testing.T, r *Resource) {
+			assert.Equal(t, 0.2, r.ReconcileJitter)
+		},
+	},
+	{
+		Name: "reconcile-jitter-duration",
+		Manifest: `{
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"metadata": {
+				"name": "foo",
+				"annotations": {
+					"eno.azure.io/reconcile-interval": "10s",
+					"eno.azure.io/reconcile-jitter": "2s"
+				}
+			}
+		}`,
+		Assert: func(t *testing.T, r *Resource) {
+			assert.Equal(t, 0.2, r.ReconcileJitter)
+		},
+	},
+	{
+		Name: "reconcile-jitter-without-interval",
+		Manifest: `{
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"metadata": {
+				"name": "foo",
+				"annotations": {
+					"eno.azure.io/reconcile-jitter": "20%"
+				}
+			}
+		}`,
+		Assert: func(t *testing.T, r *Resource) {
+			assert.Zero(t, r.ReconcileJitter)
+		},
+	},
+	{
+		Name: "readiness-dwell",
+		Manifest: `{
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"metadata": {
+				"name": "foo",
+				"annotations": {
+					"eno.azure.io/readiness-dwell": "30s"
+				}
+			}
+		}`,
+		Assert: func(t *testing.T, r *Resource) {
+			assert.Equal(t, 30*time.Second, r.ReadinessDwell)
+			assert.Nil(t, r.Unstructured().GetAnnotations())
+		},
+	},
 	{
 		Name: "zero-readiness-group",
 		Manifest: `{
@@ -87,6 +241,84 @@ var newResourceTests = []struct {
 			assert.Equal(t, int(-10), r.ReadinessGroup)
 		},
 	},
+	{
+		Name: "default-crd-readiness-group",
+		Manifest: `{
+			"apiVersion": "apiextensions.k8s.io/v1",
+			"kind": "CustomResourceDefinition",
+			"metadata": {
+				"name": "foos.example.com"
+			}
+		}`,
+		Assert: func(t *testing.T, r *Resource) {
+			assert.Equal(t, int(-1), r.ReadinessGroup)
+		},
+	},
+	{
+		Name: "default-namespace-readiness-group",
+		Manifest: `{
+			"apiVersion": "v1",
+			"kind": "Namespace",
+			"metadata": {
+				"name": "foo"
+			}
+		}`,
+		Assert: func(t *testing.T, r *Resource) {
+			assert.Equal(t, int(-2), r.ReadinessGroup)
+		},
+	},
+	{
+		Name: "default-crd-readiness-group-override",
+		Manifest: `{
+			"apiVersion": "apiextensions.k8s.io/v1",
+			"kind": "CustomResourceDefinition",
+			"metadata": {
+				"name": "foos.example.com",
+				"annotations": {
+					"eno.azure.io/readiness-group": "4"
+				}
+			}
+		}`,
+		Assert: func(t *testing.T, r *Resource) {
+			assert.Equal(t, int(4), r.ReadinessGroup)
+		},
+	},
+	{
+		Name: "named-readiness-groups",
+		Manifest: `{
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"metadata": {
+				"name": "foo",
+				"annotations": {
+					"eno.azure.io/readiness-group-name": "db-ready",
+					"eno.azure.io/readiness-after": "migrations, certs,"
+				}
+			}
+		}`,
+		Assert: func(t *testing.T, r *Resource) {
+			assert.Equal(t, "db-ready", r.ReadinessGroupName)
+			assert.Equal(t, []string{"migrations", "certs"}, r.ReadinessAfter)
+			assert.Empty(t, r.ReadinessChecks)
+		},
+	},
+	{
+		Name: "readiness-optional",
+		Manifest: `{
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"metadata": {
+				"name": "foo",
+				"annotations": {
+					"eno.azure.io/readiness-optional": "true"
+				}
+			}
+		}`,
+		Assert: func(t *testing.T, r *Resource) {
+			assert.True(t, r.ReadinessOptional)
+			assert.Empty(t, r.ReadinessChecks)
+		},
+	},
 	{
 		Name: "deployment",
 		Manifest: `{
@@ -155,6 +387,47 @@ var newResourceTests = []struct {
 			assert.True(t, r.patchSetsDeletionTimestamp())
 		},
 	},
+	{
+		Name: "mergePatch",
+		Manifest: `{
+			"apiVersion": "eno.azure.io/v1",
+			"kind": "Patch",
+			"metadata": {
+				"name": "foo",
+				"namespace": "bar"
+			},
+			"patch": {
+				"apiVersion": "v1",
+				"kind": "ConfigMap",
+				"mergePatch": { "data": { "foo": "bar" } }
+			}
+		}`,
+		Assert: func(t *testing.T, r *Resource) {
+			assert.Equal(t, schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, r.GVK)
+			assert.Nil(t, r.Patch)
+			assert.True(t, r.IsPatch())
+			assert.False(t, r.patchSetsDeletionTimestamp())
+		},
+	},
+	{
+		Name: "deletionMergePatch",
+		Manifest: `{
+			"apiVersion": "eno.azure.io/v1",
+			"kind": "Patch",
+			"metadata": {
+				"name": "foo",
+				"namespace": "bar"
+			},
+			"patch": {
+				"apiVersion": "v1",
+				"kind": "ConfigMap",
+				"mergePatch": { "metadata": { "deletionTimestamp": "anything" } }
+			}
+		}`,
+		Assert: func(t *testing.T, r *Resource) {
+			assert.True(t, r.patchSetsDeletionTimestamp())
+		},
+	},
 	{
 		Name: "crd",
 		Manifest: `{
@@ -299,6 +572,63 @@ func TestNewResource(t *testing.T) {
 	}
 }
 
+func TestNewResourcesMultiDoc(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("single json object", func(t *testing.T) {
+		manifest := `{ "apiVersion": "v1", "kind": "ConfigMap", "metadata": { "name": "foo", "namespace": "default" } }`
+		slice := &apiv1.ResourceSlice{Spec: apiv1.ResourceSliceSpec{Resources: []apiv1.Manifest{{Manifest: manifest}}}}
+
+		resources, err := NewResources(ctx, slice)
+		require.NoError(t, err)
+		require.Len(t, resources, 1)
+
+		// Identical to the single-document parser, including the manifest hash
+		single, err := NewResource(ctx, slice, 0)
+		require.NoError(t, err)
+		assert.Equal(t, single.ManifestRef, resources[0].ManifestRef)
+		assert.Equal(t, single.ManifestHash, resources[0].ManifestHash)
+	})
+
+	t.Run("yaml stream", func(t *testing.T) {
+		manifest := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: foo\n  namespace: default\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: bar\n  namespace: default\n"
+		slice := &apiv1.ResourceSlice{Spec: apiv1.ResourceSliceSpec{Resources: []apiv1.Manifest{{Manifest: manifest}}}}
+
+		resources, err := NewResources(ctx, slice)
+		require.NoError(t, err)
+		require.Len(t, resources, 2)
+
+		assert.Equal(t, 0, resources[0].ManifestRef.Index)
+		assert.Equal(t, "foo", resources[0].Ref.Name)
+		assert.Equal(t, 1, resources[1].ManifestRef.Index)
+		assert.Equal(t, "bar", resources[1].Ref.Name)
+
+		// Each hash covers only its own document
+		assert.NotEqual(t, resources[0].ManifestHash, resources[1].ManifestHash)
+	})
+
+	t.Run("ref index offset", func(t *testing.T) {
+		stream := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: foo\n  namespace: default\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: bar\n  namespace: default\n"
+		manifest := `{ "apiVersion": "v1", "kind": "ConfigMap", "metadata": { "name": "baz", "namespace": "default" } }`
+		slice := &apiv1.ResourceSlice{Spec: apiv1.ResourceSliceSpec{Resources: []apiv1.Manifest{{Manifest: stream}, {Manifest: manifest}}}}
+
+		// The multi-document stream shifts later manifests' ref indexes
+		resources, err := NewResources(ctx, slice)
+		require.NoError(t, err)
+		require.Len(t, resources, 3)
+		assert.Equal(t, 2, resources[2].ManifestRef.Index)
+		assert.Equal(t, "baz", resources[2].Ref.Name)
+	})
+}
+
+func TestCountManifestDocuments(t *testing.T) {
+	assert.Equal(t, 1, CountManifestDocuments(`{ "kind": "ConfigMap" }`))
+	assert.Equal(t, 1, CountManifestDocuments("kind: ConfigMap"))
+	assert.Equal(t, 2, CountManifestDocuments("kind: ConfigMap\n---\nkind: Secret"))
+	assert.Equal(t, 1, CountManifestDocuments("")) // pruned manifests degrade to a count of one
+	assert.Equal(t, 1, CountManifestDocuments("{ not json"))
+}
+
 func TestMergeBasics(t *testing.T) {
 	testMergeBasics(t, "io.k8s.api.apps.v1.Deployment")
 }
@@ -435,14 +765,70 @@ func testMergeBasics(t *testing.T, schemaName string) {
 	merged, typed, err = newState.Merge(ctx, oldState, expected, sg)
 	require.NoError(t, err)
 	assert.Equal(t, schemaName != "", typed)
+	assert.Nil(t, merged)
 
+	// Explicit nulls in the current state don't register as changes on the
+	// schemaless path. The typed path rejects unknown fields outright.
 	if schemaName == "" {
-		assert.NotNil(t, merged)
-	} else {
+		expected.Object["spec"].(map[string]any)["explicitlyNull"] = nil
+		merged, _, err = newState.Merge(ctx, oldState, expected, sg)
+		require.NoError(t, err)
 		assert.Nil(t, merged)
 	}
 }
 
+func TestMergeLogging(t *testing.T) {
+	var logs []string
+	logger := funcr.New(func(prefix, args string) {
+		logs = append(logs, args)
+	}, funcr.Options{Verbosity: 1})
+	ctx := logr.NewContext(context.Background(), logger)
+
+	sg := newTestSchemaGetter(t, "io.k8s.api.apps.v1.Deployment")
+
+	slice := &apiv1.ResourceSlice{
+		Spec: apiv1.ResourceSliceSpec{
+			Resources: []apiv1.Manifest{{
+				Manifest: `{
+				  "apiVersion": "apps/v1",
+				  "kind": "Deployment",
+				  "metadata": {
+				    "name": "foo"
+				  },
+				  "spec": {
+				    "replicas": 2
+				  }
+				}`,
+			}},
+		},
+	}
+	res, err := NewResource(ctx, slice, 0)
+	require.NoError(t, err)
+
+	current := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]any{"name": "foo"},
+		"spec":       map[string]any{"replicas": int64(1)},
+	}}
+
+	// An update logs the GVK, merge path, and changed field paths
+	merged, _, err := res.Merge(ctx, nil, current, sg)
+	require.NoError(t, err)
+	require.NotNil(t, merged)
+	require.Len(t, logs, 1)
+	assert.Contains(t, logs[0], `"gvk"="apps/v1, Kind=Deployment"`)
+	assert.Contains(t, logs[0], `"typed"=true`)
+	assert.Contains(t, logs[0], ".spec.replicas")
+
+	// No-ops don't log
+	logs = nil
+	merged, _, err = res.Merge(ctx, nil, merged, sg)
+	require.NoError(t, err)
+	assert.Nil(t, merged)
+	assert.Empty(t, logs)
+}
+
 func TestResourceOrdering(t *testing.T) {
 	resources := []*Resource{
 		{ManifestHash: []byte("a")},
@@ -464,6 +850,36 @@ func TestResourceOrdering(t *testing.T) {
 	}, resources)
 }
 
+type observedGenerationExtractor struct{}
+
+func (observedGenerationExtractor) ExtractRevisions(obj client.Object, refKey string, projection ...string) *apiv1.InputRevisions {
+	gen, _, _ := unstructured.NestedInt64(obj.(*unstructured.Unstructured).Object, "status", "observedGeneration")
+	rev := int(gen)
+	return &apiv1.InputRevisions{Key: refKey, Revision: &rev}
+}
+
+func TestRevisionExtractorRegistration(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "test.eno.azure.io", Version: "v1", Kind: "Extracted"}
+	RegisterRevisionExtractor(gvk, observedGenerationExtractor{})
+
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "test.eno.azure.io/v1",
+		"kind":       "Extracted",
+		"metadata":   map[string]any{"name": "foo", "resourceVersion": "123"},
+		"status":     map[string]any{"observedGeneration": int64(42)},
+	}}
+	ir := NewInputRevisions(obj, "test-key")
+	require.NotNil(t, ir.Revision)
+	assert.Equal(t, 42, *ir.Revision)
+	assert.Empty(t, ir.ResourceVersion)
+
+	// Unregistered types keep the default annotation-based logic
+	obj.SetKind("NotExtracted")
+	ir = NewInputRevisions(obj, "test-key")
+	assert.Nil(t, ir.Revision)
+	assert.Equal(t, "123", ir.ResourceVersion)
+}
+
 type testSchemaGetter struct {
 	name   string
 	schema *smdschema.Schema
@@ -476,7 +892,7 @@ func (t *testSchemaGetter) Get(ctx context.Context, gvk schema.GroupVersionKind)
 	return &smdschema.TypeRef{NamedType: &t.name}, t.schema, nil
 }
 
-func newTestSchemaGetter(t *testing.T, name string) *testSchemaGetter {
+func newTestSchemaGetter(t testing.TB, name string) *testSchemaGetter {
 	oapiJS, err := os.ReadFile("fixtures/openapi.json")
 	require.NoError(t, err)
 
@@ -492,3 +908,355 @@ func newTestSchemaGetter(t *testing.T, name string) *testSchemaGetter {
 
 	return &testSchemaGetter{schema: schem, name: name}
 }
+
+// benchmarkSlice builds a slice of n single-document configmap manifests.
+func benchmarkSlice(n int) *apiv1.ResourceSlice {
+	slice := &apiv1.ResourceSlice{}
+	for i := 0; i < n; i++ {
+		slice.Spec.Resources = append(slice.Spec.Resources, apiv1.Manifest{
+			Manifest: fmt.Sprintf(`{ "apiVersion": "v1", "kind": "ConfigMap", "metadata": { "name": "cm-%d", "namespace": "default" }, "data": { "key": "value" } }`, i),
+		})
+	}
+	return slice
+}
+
+func BenchmarkNewResources(b *testing.B) {
+	ctx := context.Background()
+	slice := benchmarkSlice(500)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := NewResources(ctx, slice); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMergeCachedSchemaGetter(b *testing.B) {
+	ctx := context.Background()
+	resources, err := NewResources(ctx, benchmarkSlice(500))
+	if err != nil {
+		b.Fatal(err)
+	}
+	const schemaName = "io.k8s.api.core.v1.ConfigMap"
+
+	for _, tc := range []struct {
+		Name string
+		SG   SchemaGetter
+	}{
+		{Name: "direct", SG: newTestSchemaGetter(b, schemaName)},
+		{Name: "cached", SG: NewCachedSchemaGetter(newTestSchemaGetter(b, schemaName))},
+	} {
+		b.Run(tc.Name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				for _, res := range resources {
+					if _, _, err := res.Merge(ctx, nil, res.Unstructured(), tc.SG); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestMergeKeyedListNoSchema proves that list merge key hints cause keyed lists to be
+// merged item-wise in the untyped branch of Merge, unlike the naive wholesale replacement.
+func TestMergeKeyedListNoSchema(t *testing.T) {
+	ctx := context.Background()
+	sg := newTestSchemaGetter(t, "") // no schema - force the untyped branch
+
+	newManifest := func(anno string) *apiv1.ResourceSlice {
+		return &apiv1.ResourceSlice{
+			Spec: apiv1.ResourceSliceSpec{
+				Resources: []apiv1.Manifest{{
+					Manifest: `{
+					  "apiVersion": "test.azure.io/v1",
+					  "kind": "TestResource",
+					  "metadata": {
+					    "name": "foo"` + anno + `
+					  },
+					  "spec": {
+					    "env": [
+						  {"name": "FOO", "value": "updated"},
+						  {"name": "NEW", "value": "new"}
+					    ]
+					  }
+					}`,
+				}},
+			},
+		}
+	}
+
+	current := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "test.azure.io/v1",
+			"kind":       "TestResource",
+			"metadata":   map[string]any{"name": "foo"},
+			"spec": map[string]any{
+				"env": []any{
+					map[string]any{"name": "FOO", "value": "original"},
+					map[string]any{"name": "UNMANAGED", "value": "keep-me"},
+				},
+			},
+		}}
+	}
+
+	// Without the hint the expected list replaces the current one wholesale,
+	// dropping the unmanaged item.
+	naive, err := NewResource(ctx, newManifest(""), 0)
+	require.NoError(t, err)
+	merged, typed, err := naive.Merge(ctx, nil, current(), sg)
+	require.NoError(t, err)
+	assert.False(t, typed)
+	env, _, _ := unstructured.NestedSlice(merged.Object, "spec", "env")
+	assert.Len(t, env, 2)
+
+	// With the hint the lists are merged by key.
+	hinted, err := NewResource(ctx, newManifest(`,
+		"annotations": {"eno.azure.io/list-merge-keys": "spec.env=name"}`), 0)
+	require.NoError(t, err)
+	merged, typed, err = hinted.Merge(ctx, nil, current(), sg)
+	require.NoError(t, err)
+	assert.False(t, typed)
+	env, _, _ = unstructured.NestedSlice(merged.Object, "spec", "env")
+	assert.Equal(t, []any{
+		map[string]any{"name": "FOO", "value": "updated"},
+		map[string]any{"name": "UNMANAGED", "value": "keep-me"},
+		map[string]any{"name": "NEW", "value": "new"},
+	}, env)
+
+	// Repeated merges against the previous output do not duplicate items.
+	again, _, err := hinted.Merge(ctx, nil, merged, sg)
+	require.NoError(t, err)
+	if again != nil {
+		env, _, _ = unstructured.NestedSlice(again.Object, "spec", "env")
+		assert.Len(t, env, 3)
+	}
+}
+
+func TestSelectorPatchValidation(t *testing.T) {
+	slice := &apiv1.ResourceSlice{}
+	slice.Spec.Resources = []apiv1.Manifest{{
+		Manifest: `{
+			"apiVersion": "eno.azure.io/v1",
+			"kind": "Patch",
+			"metadata": {"name": "test", "namespace": "default"},
+			"patch": {
+				"apiVersion": "v1",
+				"kind": "ConfigMap",
+				"labelSelector": {"foo": "bar"},
+				"ops": [{"op": "remove", "path": "/data"}]
+			}
+		}`,
+	}}
+
+	_, err := NewResource(context.Background(), slice, 0)
+	require.EqualError(t, err, "patches scoped by label selector only support additive operations, got \"remove\"")
+
+	slice.Spec.Resources[0].Manifest = `{
+		"apiVersion": "eno.azure.io/v1",
+		"kind": "Patch",
+		"metadata": {"name": "test", "namespace": "default"},
+		"patch": {
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"labelSelector": {"foo": "bar"},
+			"ops": [{"op": "add", "path": "/data", "value": {}}]
+		}
+	}`
+	res, err := NewResource(context.Background(), slice, 0)
+	require.NoError(t, err)
+	require.Equal(t, "foo=bar", res.PatchSelector.String())
+
+	// targetSelector supports matchExpressions
+	slice.Spec.Resources[0].Manifest = `{
+		"apiVersion": "eno.azure.io/v1",
+		"kind": "Patch",
+		"metadata": {"name": "test", "namespace": "default"},
+		"patch": {
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"targetSelector": {
+				"matchLabels": {"app": "web"},
+				"matchExpressions": [{"key": "env", "operator": "NotIn", "values": ["dev"]}]
+			},
+			"ops": [{"op": "add", "path": "/data", "value": {}}]
+		}
+	}`
+	res, err = NewResource(context.Background(), slice, 0)
+	require.NoError(t, err)
+	require.Equal(t, "app=web,env notin (dev)", res.PatchSelector.String())
+	assert.False(t, res.PatchIsNoOp())
+	assert.False(t, res.Deleted(&apiv1.Composition{}))
+
+	// Patches containing only test ops can't change anything
+	slice.Spec.Resources[0].Manifest = `{
+		"apiVersion": "eno.azure.io/v1",
+		"kind": "Patch",
+		"metadata": {"name": "test", "namespace": "default"},
+		"patch": {
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"targetSelector": {"matchLabels": {"app": "web"}},
+			"ops": [{"op": "test", "path": "/data", "value": {}}]
+		}
+	}`
+	res, err = NewResource(context.Background(), slice, 0)
+	require.NoError(t, err)
+	assert.True(t, res.PatchIsNoOp())
+
+	// The two selector forms are mutually exclusive
+	slice.Spec.Resources[0].Manifest = `{
+		"apiVersion": "eno.azure.io/v1",
+		"kind": "Patch",
+		"metadata": {"name": "test", "namespace": "default"},
+		"patch": {
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"labelSelector": {"foo": "bar"},
+			"targetSelector": {"matchLabels": {"foo": "bar"}},
+			"ops": [{"op": "add", "path": "/data", "value": {}}]
+		}
+	}`
+	_, err = NewResource(context.Background(), slice, 0)
+	require.EqualError(t, err, "patches cannot set both labelSelector and targetSelector")
+}
+
+func TestMergePatchValidation(t *testing.T) {
+	slice := &apiv1.ResourceSlice{}
+	slice.Spec.Resources = []apiv1.Manifest{{
+		Manifest: `{
+			"apiVersion": "eno.azure.io/v1",
+			"kind": "Patch",
+			"metadata": {"name": "test", "namespace": "default"},
+			"patch": {
+				"apiVersion": "v1",
+				"kind": "ConfigMap",
+				"mergePatch": {"data": {"foo": "bar"}},
+				"ops": [{"op": "add", "path": "/data", "value": {}}]
+			}
+		}`,
+	}}
+
+	_, err := NewResource(context.Background(), slice, 0)
+	require.EqualError(t, err, "patches cannot set both ops and mergePatch")
+
+	slice.Spec.Resources[0].Manifest = `{
+		"apiVersion": "eno.azure.io/v1",
+		"kind": "Patch",
+		"metadata": {"name": "test", "namespace": "default"},
+		"patch": {
+			"apiVersion": "v1",
+			"kind": "ConfigMap",
+			"labelSelector": {"foo": "bar"},
+			"mergePatch": {"data": {"foo": "bar"}}
+		}
+	}`
+	_, err = NewResource(context.Background(), slice, 0)
+	require.EqualError(t, err, "patches scoped by label selector do not support mergePatch")
+}
+
+func TestNeedsToBePatchedMergePatch(t *testing.T) {
+	slice := &apiv1.ResourceSlice{}
+	slice.Spec.Resources = []apiv1.Manifest{{
+		Manifest: `{
+			"apiVersion": "eno.azure.io/v1",
+			"kind": "Patch",
+			"metadata": {"name": "test", "namespace": "default"},
+			"patch": {
+				"apiVersion": "v1",
+				"kind": "ConfigMap",
+				"mergePatch": {"data": {"foo": "bar"}}
+			}
+		}`,
+	}}
+	res, err := NewResource(context.Background(), slice, 0)
+	require.NoError(t, err)
+
+	current := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": "test", "namespace": "default"},
+	}}
+	assert.True(t, res.NeedsToBePatched(current))
+
+	// The patch is a no-op once the current state already reflects it
+	current.Object["data"] = map[string]any{"foo": "bar"}
+	assert.False(t, res.NeedsToBePatched(current))
+}
+
+func TestNewResourceManifestError(t *testing.T) {
+	ctx := context.Background()
+	slice := &apiv1.ResourceSlice{
+		Spec: apiv1.ResourceSliceSpec{
+			Resources: []apiv1.Manifest{
+				{Manifest: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"ok"}}`},
+				{Manifest: `not json`},
+				{Manifest: `{"apiVersion":"v1","kind":"ConfigMap"}`},
+			},
+		},
+	}
+
+	// Parse failures carry the manifest index and preserve the error string
+	_, err := NewResource(ctx, slice, 1)
+	require.Error(t, err)
+	manifestErr := &ManifestError{}
+	require.True(t, errors.As(err, &manifestErr))
+	assert.Equal(t, 1, manifestErr.Index)
+	assert.Equal(t, "invalid json", manifestErr.Reason)
+	assert.True(t, strings.HasPrefix(err.Error(), "invalid json: "))
+
+	// Validation failures are distinguishable by reason
+	_, err = NewResource(ctx, slice, 2)
+	require.True(t, errors.As(err, &manifestErr))
+	assert.Equal(t, 2, manifestErr.Index)
+	assert.Equal(t, "missing name, kind, or apiVersion", err.Error())
+}
+
+func TestObserveReadiness(t *testing.T) {
+	start := time.Now()
+	res := &Resource{ReadinessDwell: time.Minute}
+
+	// Flapping faster than the dwell period never records a transition
+	assert.False(t, res.ObserveReadiness(true, start))
+	assert.False(t, res.ObserveReadiness(false, start.Add(10*time.Second)))
+	assert.False(t, res.ObserveReadiness(true, start.Add(20*time.Second)))
+	assert.False(t, res.ObserveReadiness(false, start.Add(30*time.Second)))
+
+	// A stable outcome is recorded once it has held for the dwell period
+	assert.False(t, res.ObserveReadiness(true, start.Add(40*time.Second)))
+	assert.False(t, res.ObserveReadiness(true, start.Add(50*time.Second)))
+	assert.True(t, res.ObserveReadiness(true, start.Add(101*time.Second)))
+
+	// Resources without a dwell period record transitions immediately
+	assert.True(t, (&Resource{}).ObserveReadiness(true, start))
+}
+
+func TestRetainMetadataKeys(t *testing.T) {
+	ctx := context.Background()
+	RetainMetadataKeys("eno.azure.io/reconcile-interval")
+	t.Cleanup(func() { retainedMetadataKeys = map[string]struct{}{} })
+
+	r, err := NewResource(ctx, &apiv1.ResourceSlice{
+		Spec: apiv1.ResourceSliceSpec{
+			Resources: []apiv1.Manifest{{Manifest: `{
+				"apiVersion": "v1",
+				"kind": "ConfigMap",
+				"metadata": {
+					"name": "foo",
+					"annotations": {
+						"eno.azure.io/reconcile-interval": "10s",
+						"eno.azure.io/readiness-group": "2"
+					}
+				}
+			}`}},
+		},
+	}, 0)
+	require.NoError(t, err)
+
+	// Allowlisted annotations survive pruning and the rest are still removed
+	assert.Equal(t, map[string]string{"eno.azure.io/reconcile-interval": "10s"}, r.Unstructured().GetAnnotations())
+	assert.Equal(t, time.Second*10, r.ReconcileInterval.Duration)
+	assert.Equal(t, 2, r.ReadinessGroup)
+}
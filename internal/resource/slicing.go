@@ -2,6 +2,9 @@ package resource
 
 import (
 	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
 
 	apiv1 "github.com/Azure/eno/api/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,28 +17,37 @@ import (
 // - New and updated resources are partitioned across slices per maxJsonBytes
 // - Removed resources are converted into "tombstones" i.e. manifests with Deleted == true
 func Slice(comp *apiv1.Composition, previous []*apiv1.ResourceSlice, outputs []*unstructured.Unstructured, maxJsonBytes int) ([]*apiv1.ResourceSlice, error) {
+	var slices []*apiv1.ResourceSlice
+	err := StreamSlices(comp, previous, outputs, maxJsonBytes, func(slice *apiv1.ResourceSlice) error {
+		slices = append(slices, slice)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slices, nil
+}
+
+// StreamSlices builds the same slices as Slice, but hands each one to flush as
+// soon as it's full instead of accumulating them all. Only one slice's worth of
+// encoded manifests is held in memory at a time, bounding peak memory for very
+// large outputs.
+func StreamSlices(comp *apiv1.Composition, previous []*apiv1.ResourceSlice, outputs []*unstructured.Unstructured, maxJsonBytes int, flush func(*apiv1.ResourceSlice) error) error {
 	refs := map[resourceRef]struct{}{}
-	manifests := []apiv1.Manifest{}
-	for i, output := range outputs {
-		js, err := output.MarshalJSON()
-		if err != nil {
-			return nil, reconcile.TerminalError(fmt.Errorf("encoding output %d: %w", i, err))
-		}
-		manifests = append(manifests, apiv1.Manifest{
-			Manifest: string(js),
-		})
+	for _, output := range outputs {
 		refs[newResourceRef(output)] = struct{}{}
 	}
 
 	// Build tombstones by diffing the new state against the current state
 	// Existing tombstones are passed down if they haven't yet been reconciled to avoid orphaning resources
+	tombstones := []apiv1.Manifest{}
 	for _, slice := range previous {
 		for i, res := range slice.Spec.Resources {
 			res := res
 			obj := &unstructured.Unstructured{}
 			err := obj.UnmarshalJSON([]byte(res.Manifest))
 			if err != nil {
-				return nil, reconcile.TerminalError(fmt.Errorf("decoding resource %d of slice %s: %w", i, slice.Name, err))
+				return reconcile.TerminalError(fmt.Errorf("decoding resource %d of slice %s: %w", i, slice.Name, err))
 			}
 
 			if obj.GetObjectKind().GroupVersionKind() == patchGVK {
@@ -49,19 +61,23 @@ func Slice(comp *apiv1.Composition, previous []*apiv1.ResourceSlice, outputs []*
 			}
 
 			res.Deleted = true
-			manifests = append(manifests, res)
+			tombstones = append(tombstones, res)
 		}
 	}
 
-	// Build the slice resources
+	// Partition the manifests across slices, flushing each slice once the next would overflow it
 	var (
-		slices             []*apiv1.ResourceSlice
 		sliceBytes         int
 		slice              *apiv1.ResourceSlice
 		blockOwnerDeletion = true
 	)
-	for _, manifest := range manifests {
+	appendManifest := func(manifest apiv1.Manifest) error {
 		if slice == nil || sliceBytes >= maxJsonBytes {
+			if slice != nil {
+				if err := flush(slice); err != nil {
+					return err
+				}
+			}
 			sliceBytes = 0
 			slice = &apiv1.ResourceSlice{}
 			slice.GenerateName = comp.Name + "-"
@@ -80,13 +96,36 @@ func Slice(comp *apiv1.Composition, previous []*apiv1.ResourceSlice, outputs []*
 				slice.Spec.Attempt = comp.Status.CurrentSynthesis.Attempts
 			}
 			slice.Spec.CompositionGeneration = comp.Generation
-			slices = append(slices, slice)
 		}
 		sliceBytes += len(manifest.Manifest)
 		slice.Spec.Resources = append(slice.Spec.Resources, manifest)
+		return nil
 	}
 
-	return slices, nil
+	for i, output := range outputs {
+		js, err := output.MarshalJSON()
+		if err != nil {
+			return reconcile.TerminalError(fmt.Errorf("encoding output %d: %w", i, err))
+		}
+		manifest := apiv1.Manifest{
+			Manifest:          string(js),
+			Optional:          output.GetAnnotations()["eno.azure.io/optional"] == "true",
+			ReadinessOptional: output.GetAnnotations()["eno.azure.io/readiness-optional"] == "true",
+		}
+		if err := appendManifest(manifest); err != nil {
+			return err
+		}
+	}
+	for _, manifest := range tombstones {
+		if err := appendManifest(manifest); err != nil {
+			return err
+		}
+	}
+
+	if slice != nil {
+		return flush(slice)
+	}
+	return nil
 }
 
 type resourceRef struct {
@@ -113,3 +152,45 @@ func newResourceRef(obj *unstructured.Unstructured) resourceRef {
 		Group:     obj.GroupVersionKind().Group,
 	}
 }
+
+// BundleHash returns a hash covering every resource manifest in the given slices.
+// It is invariant to resource ordering: two syntheses producing the same set of
+// manifests share a bundle hash regardless of how they're sliced or ordered.
+// Tombstones are excluded since they don't contribute to the rendered output.
+func BundleHash(slices []*apiv1.ResourceSlice) string {
+	hasher := &BundleHasher{}
+	for _, slice := range slices {
+		hasher.Add(slice)
+	}
+	return hasher.Sum()
+}
+
+// BundleHasher computes the same hash as BundleHash, but incrementally so
+// streaming callers don't have to hold every slice in memory. The zero value is
+// ready to use.
+type BundleHasher struct {
+	sums []uint64
+}
+
+// Add folds a slice's manifests into the hash.
+func (b *BundleHasher) Add(slice *apiv1.ResourceSlice) {
+	for _, res := range slice.Spec.Resources {
+		if res.Deleted {
+			continue
+		}
+		hash := fnv.New64()
+		hash.Write([]byte(res.Manifest))
+		b.sums = append(b.sums, hash.Sum64())
+	}
+}
+
+// Sum returns the hash of every slice added so far.
+func (b *BundleHasher) Sum() string {
+	sort.Slice(b.sums, func(i, j int) bool { return b.sums[i] < b.sums[j] })
+
+	hash := fnv.New64()
+	for _, sum := range b.sums {
+		fmt.Fprintf(hash, "%016x", sum)
+	}
+	return strconv.FormatUint(hash.Sum64(), 16)
+}
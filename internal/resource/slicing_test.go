@@ -1,6 +1,8 @@
 package resource
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	apiv1 "github.com/Azure/eno/api/v1"
@@ -154,3 +156,99 @@ func TestSliceTombstonesVersionSemantics(t *testing.T) {
 	require.Len(t, slices, 1)
 	require.Len(t, slices[0].Spec.Resources, 2)
 }
+
+func TestSliceOptional(t *testing.T) {
+	outputs := []*unstructured.Unstructured{{
+		Object: map[string]interface{}{
+			"kind":       "Test",
+			"apiVersion": "mygroup/v1",
+			"metadata": map[string]interface{}{
+				"name":        "test-resource",
+				"namespace":   "test-ns",
+				"annotations": map[string]interface{}{"eno.azure.io/optional": "true"},
+			},
+		},
+	}, {
+		Object: map[string]interface{}{
+			"kind":       "Test",
+			"apiVersion": "mygroup/v1",
+			"metadata": map[string]interface{}{
+				"name":      "another-resource",
+				"namespace": "test-ns",
+			},
+		},
+	}}
+
+	slices, err := Slice(&apiv1.Composition{}, []*apiv1.ResourceSlice{}, outputs, 100000)
+	require.NoError(t, err)
+	require.Len(t, slices, 1)
+	require.Len(t, slices[0].Spec.Resources, 2)
+	assert.True(t, slices[0].Spec.Resources[0].Optional)
+	assert.False(t, slices[0].Spec.Resources[1].Optional)
+}
+
+func TestStreamSlices(t *testing.T) {
+	outputs := []*unstructured.Unstructured{}
+	for i := 0; i < 500; i++ {
+		outputs = append(outputs, &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"kind":       "Test",
+				"apiVersion": "mygroup/v1",
+				"metadata": map[string]interface{}{
+					"name":      fmt.Sprintf("test-resource-%d", i),
+					"namespace": "test-ns",
+				},
+			},
+		})
+	}
+
+	expected, err := Slice(&apiv1.Composition{}, []*apiv1.ResourceSlice{}, outputs, 2048)
+	require.NoError(t, err)
+	require.Greater(t, len(expected), 1)
+
+	// Streaming produces the same slices as Slice while only ever buffering one at a time
+	streamed := []*apiv1.ResourceSlice{}
+	hasher := &BundleHasher{}
+	err = StreamSlices(&apiv1.Composition{}, []*apiv1.ResourceSlice{}, outputs, 2048, func(slice *apiv1.ResourceSlice) error {
+		streamed = append(streamed, slice)
+		hasher.Add(slice)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expected, streamed)
+	assert.Equal(t, BundleHash(expected), hasher.Sum())
+
+	// Flush errors are returned immediately without building the remaining slices
+	calls := 0
+	err = StreamSlices(&apiv1.Composition{}, []*apiv1.ResourceSlice{}, outputs, 2048, func(slice *apiv1.ResourceSlice) error {
+		calls++
+		return errors.New("flush failure")
+	})
+	require.EqualError(t, err, "flush failure")
+	assert.Equal(t, 1, calls)
+}
+
+func TestBundleHash(t *testing.T) {
+	newSlices := func(manifests ...string) []*apiv1.ResourceSlice {
+		slice := &apiv1.ResourceSlice{}
+		for _, m := range manifests {
+			slice.Spec.Resources = append(slice.Spec.Resources, apiv1.Manifest{Manifest: m})
+		}
+		return []*apiv1.ResourceSlice{slice}
+	}
+
+	a := newSlices(`{"kind":"ConfigMap"}`, `{"kind":"Secret"}`)
+	b := newSlices(`{"kind":"Secret"}`, `{"kind":"ConfigMap"}`)
+	c := newSlices(`{"kind":"ConfigMap"}`, `{"kind":"Deployment"}`)
+
+	// Identical outputs share a hash regardless of ordering
+	assert.Equal(t, BundleHash(a), BundleHash(b))
+
+	// Any change flips the hash
+	assert.NotEqual(t, BundleHash(a), BundleHash(c))
+
+	// Tombstones don't contribute
+	d := newSlices(`{"kind":"ConfigMap"}`, `{"kind":"Secret"}`)
+	d[0].Spec.Resources = append(d[0].Spec.Resources, apiv1.Manifest{Manifest: `{"kind":"Deployment"}`, Deleted: true})
+	assert.Equal(t, BundleHash(a), BundleHash(d))
+}
@@ -0,0 +1,52 @@
+package resource
+
+import (
+	"errors"
+	"fmt"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ErrRefNotFound is returned when no resource matching the given ref exists in
+// the composition's current synthesis.
+var ErrRefNotFound = errors.New("no resource matching the ref was found in the composition's current synthesis")
+
+// FindResourceState resolves the state of a single synthesized resource without
+// reconstituting the entire composition. The given slices should include those
+// referenced by the composition's current synthesis - others are ignored.
+//
+// An empty state is returned for resources that exist but haven't been
+// reconciled yet.
+func FindResourceState(comp *apiv1.Composition, slices []apiv1.ResourceSlice, ref Ref) (*apiv1.ResourceState, error) {
+	if comp.Status.CurrentSynthesis == nil {
+		return nil, ErrRefNotFound
+	}
+
+	referenced := map[string]struct{}{}
+	for _, sr := range comp.Status.CurrentSynthesis.ResourceSlices {
+		referenced[sr.Name] = struct{}{}
+	}
+
+	for _, slice := range slices {
+		if _, ok := referenced[slice.Name]; !ok {
+			continue
+		}
+		for i, res := range slice.Spec.Resources {
+			parsed := &unstructured.Unstructured{}
+			if err := parsed.UnmarshalJSON([]byte(res.Manifest)); err != nil {
+				return nil, fmt.Errorf("parsing manifest %d of slice %q: %w", i, slice.Name, err)
+			}
+			if parsed.GetName() != ref.Name || parsed.GetNamespace() != ref.Namespace || parsed.GetKind() != ref.Kind || parsed.GroupVersionKind().Group != ref.Group {
+				continue
+			}
+			if i >= len(slice.Status.Resources) {
+				return &apiv1.ResourceState{}, nil // not visited by the reconciler yet
+			}
+			state := slice.Status.Resources[i]
+			return &state, nil
+		}
+	}
+
+	return nil, ErrRefNotFound
+}
@@ -0,0 +1,62 @@
+package resource
+
+import (
+	"testing"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFindResourceState(t *testing.T) {
+	now := metav1.Now()
+
+	comp := &apiv1.Composition{}
+	comp.Status.CurrentSynthesis = &apiv1.Synthesis{
+		ResourceSlices: []*apiv1.ResourceSliceRef{{Name: "slice-1"}},
+	}
+
+	slice := apiv1.ResourceSlice{}
+	slice.Name = "slice-1"
+	slice.Spec.Resources = []apiv1.Manifest{
+		{Manifest: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"foo","namespace":"default"}}`},
+		{Manifest: `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"bar","namespace":"default"}}`},
+	}
+	slice.Status.Resources = []apiv1.ResourceState{
+		{Reconciled: true, Ready: &now},
+		{Reconciled: true},
+	}
+
+	// Slices not referenced by the current synthesis are ignored
+	stale := apiv1.ResourceSlice{}
+	stale.Name = "slice-stale"
+	stale.Spec.Resources = slice.Spec.Resources
+	slices := []apiv1.ResourceSlice{stale, slice}
+
+	// Ready resource
+	state, err := FindResourceState(comp, slices, Ref{Name: "foo", Namespace: "default", Kind: "ConfigMap"})
+	require.NoError(t, err)
+	assert.True(t, state.Reconciled)
+	assert.NotNil(t, state.Ready)
+
+	// Reconciled but not ready
+	state, err = FindResourceState(comp, slices, Ref{Name: "bar", Namespace: "default", Group: "apps", Kind: "Deployment"})
+	require.NoError(t, err)
+	assert.True(t, state.Reconciled)
+	assert.Nil(t, state.Ready)
+
+	// Missing ref
+	_, err = FindResourceState(comp, slices, Ref{Name: "missing", Namespace: "default", Kind: "ConfigMap"})
+	assert.ErrorIs(t, err, ErrRefNotFound)
+
+	// Resource exists but hasn't been reconciled yet
+	slice.Status.Resources = nil
+	state, err = FindResourceState(comp, []apiv1.ResourceSlice{slice}, Ref{Name: "foo", Namespace: "default", Kind: "ConfigMap"})
+	require.NoError(t, err)
+	assert.False(t, state.Reconciled)
+
+	// Compositions that have never been synthesized
+	_, err = FindResourceState(&apiv1.Composition{}, slices, Ref{Name: "foo", Namespace: "default", Kind: "ConfigMap"})
+	assert.ErrorIs(t, err, ErrRefNotFound)
+}
@@ -7,6 +7,7 @@ import (
 	apiv1 "github.com/Azure/eno/api/v1"
 	"github.com/emirpasic/gods/v2/trees/redblacktree"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 type indexedResource struct {
@@ -21,6 +22,7 @@ type indexedResource struct {
 type treeBuilder struct {
 	byRef        map[Ref]*indexedResource                    // fast key/value lookup by group/kind/ns/name
 	byGroup      *redblacktree.Tree[int, []*indexedResource] // fast search for sparse readiness groups
+	byGroupName  map[string][]*indexedResource               // index resources by their named readiness group
 	byDefiningGK map[schema.GroupKind]*indexedResource       // index CRDs by the GK they define
 	byGK         map[schema.GroupKind]*indexedResource       // index all resources by their GK
 }
@@ -32,6 +34,9 @@ func (b *treeBuilder) init() {
 	if b.byGroup == nil {
 		b.byGroup = redblacktree.New[int, []*indexedResource]()
 	}
+	if b.byGroupName == nil {
+		b.byGroupName = map[string][]*indexedResource{}
+	}
 	if b.byDefiningGK == nil {
 		b.byDefiningGK = map[schema.GroupKind]*indexedResource{}
 	}
@@ -57,6 +62,9 @@ func (b *treeBuilder) Add(resource *Resource) {
 	b.byRef[resource.Ref] = idx
 	current, _ := b.byGroup.Get(resource.ReadinessGroup)
 	b.byGroup.Put(resource.ReadinessGroup, append(current, idx))
+	if resource.ReadinessGroupName != "" {
+		b.byGroupName[resource.ReadinessGroupName] = append(b.byGroupName[resource.ReadinessGroupName], idx)
+	}
 	b.byGK[resource.GVK.GroupKind()] = idx
 	if resource.DefinedGroupKind != nil {
 		b.byDefiningGK[*resource.DefinedGroupKind] = idx
@@ -69,6 +77,18 @@ func (b *treeBuilder) Build() *tree {
 		byManiRef: map[ManifestRef]*indexedResource{},
 	}
 
+	// Named readiness ordering is dropped entirely when it contains a cycle.
+	// The executor rejects cycles at synthesis time, but slices written by
+	// older versions shouldn't be able to deadlock reconciliation.
+	namedOrdering := len(b.byGroupName) > 0
+	if namedOrdering {
+		resources := make([]*Resource, 0, len(b.byRef))
+		for _, idx := range b.byRef {
+			resources = append(resources, idx.Resource)
+		}
+		namedOrdering = FindReadinessCycle(resources) == nil
+	}
+
 	for _, idx := range b.byRef {
 		t.byManiRef[idx.Resource.ManifestRef] = idx
 
@@ -80,25 +100,140 @@ func (b *treeBuilder) Build() *tree {
 			crd.Dependents[idx.Resource.Ref] = idx
 		}
 
-		// Depend on any resources in the previous readiness group
+		// Depend on any resources in the previous readiness group.
+		// Readiness-optional resources never gate their group.
 		if i.Prev() {
 			for _, dep := range i.Value() {
+				if dep.Resource.ReadinessOptional {
+					continue
+				}
 				idx.PendingDependencies[dep.Resource.Ref] = struct{}{}
 			}
 		}
 		i.Next() // Prev always moves the cursor, even if it returns false
 
 		// Any resources in the next readiness group depend on us
-		if i.Next() && i.Key() > idx.Resource.ReadinessGroup {
+		if !idx.Resource.ReadinessOptional && i.Next() && i.Key() > idx.Resource.ReadinessGroup {
 			for _, cur := range i.Value() {
 				idx.Dependents[cur.Resource.Ref] = cur
 			}
 		}
+
+		// Depend on every resource in the readiness groups named by the annotation
+		if !namedOrdering {
+			continue
+		}
+		for _, name := range idx.Resource.ReadinessAfter {
+			for _, dep := range b.byGroupName[name] {
+				if dep == idx || dep.Resource.ReadinessOptional {
+					continue
+				}
+				idx.PendingDependencies[dep.Resource.Ref] = struct{}{}
+				dep.Dependents[idx.Resource.Ref] = idx
+			}
+		}
 	}
 
 	return t
 }
 
+// ApplyOrder returns the resources in an order that's safe to apply
+// sequentially: ascending readiness group, with every CRD sequenced ahead of
+// the custom resources it defines - even when their readiness groups disagree -
+// so CRs don't fail with "no matches for kind". The tree gives CRs an implicit
+// readiness wait on their CRD; this covers callers that apply a flat list.
+func ApplyOrder(resources []*Resource) []*Resource {
+	ordered := make([]*Resource, len(resources))
+	copy(ordered, resources)
+	slices.SortStableFunc(ordered, func(a, b *Resource) int {
+		if a.ReadinessGroup != b.ReadinessGroup {
+			return a.ReadinessGroup - b.ReadinessGroup
+		}
+		if a.Less(b) {
+			return -1
+		}
+		if b.Less(a) {
+			return 1
+		}
+		return 0
+	})
+
+	// Hoist CRDs ahead of the first resource they define.
+	// Bounded since each CRD can only move forward.
+	for range ordered {
+		position := map[schema.GroupKind]int{}
+		for i, res := range ordered {
+			if res.DefinedGroupKind != nil {
+				position[*res.DefinedGroupKind] = i
+			}
+		}
+
+		moved := false
+		for i, res := range ordered {
+			j, ok := position[res.GVK.GroupKind()]
+			if !ok || j <= i {
+				continue
+			}
+			crd := ordered[j]
+			ordered = slices.Delete(ordered, j, j+1)
+			ordered = slices.Insert(ordered, i, crd)
+			moved = true
+			break
+		}
+		if !moved {
+			break
+		}
+	}
+
+	return ordered
+}
+
+// FindReadinessCycle returns the named readiness groups along any cycle formed
+// by the resources' ReadinessAfter references, or nil when the ordering is
+// acyclic. Cyclic orderings can never become ready, so the executor rejects
+// them at synthesis time.
+func FindReadinessCycle(resources []*Resource) []string {
+	edges := map[string][]string{}
+	for _, res := range resources {
+		if res.ReadinessGroupName == "" || len(res.ReadinessAfter) == 0 {
+			continue
+		}
+		edges[res.ReadinessGroupName] = append(edges[res.ReadinessGroupName], res.ReadinessAfter...)
+	}
+
+	const inProgress, done = 1, 2
+	states := map[string]int{}
+	var walk func(name string, path []string) []string
+	walk = func(name string, path []string) []string {
+		switch states[name] {
+		case inProgress:
+			return append(path, name)
+		case done:
+			return nil
+		}
+		states[name] = inProgress
+		for _, next := range edges[name] {
+			if cycle := walk(next, append(path, name)); cycle != nil {
+				return cycle
+			}
+		}
+		states[name] = done
+		return nil
+	}
+
+	names := make([]string, 0, len(edges))
+	for name := range edges {
+		names = append(names, name)
+	}
+	slices.Sort(names) // deterministic output for error messages
+	for _, name := range names {
+		if cycle := walk(name, nil); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
 // tree is an optimized, indexed representation of a set of resources.
 // NOT CONCURRENCY SAFE.
 type tree struct {
@@ -106,6 +241,17 @@ type tree struct {
 	byManiRef map[ManifestRef]*indexedResource
 }
 
+// sliceResourceCount returns the number of resources indexed from the given slice.
+func (t *tree) sliceResourceCount(slice types.NamespacedName) int {
+	var n int
+	for ref := range t.byManiRef {
+		if ref.Slice == slice {
+			n++
+		}
+	}
+	return n
+}
+
 // Get returns the resource and determines if it's visible based on the state of its dependencies.
 func (t *tree) Get(key Ref) (res *Resource, visible bool, found bool) {
 	idx, ok := t.byRef[key]
@@ -115,6 +115,37 @@ func TestTreeBuilderSanity(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "named-readiness-groups",
+			Resources: []*Resource{
+				{
+					Ref:                newTestRef("db"),
+					ReadinessGroupName: "db-ready",
+				},
+				{
+					Ref:            newTestRef("app"),
+					ReadinessAfter: []string{"db-ready"},
+				},
+				{
+					Ref: newTestRef("unrelated"),
+				},
+			},
+		},
+		{
+			Name: "named-readiness-cycle",
+			Resources: []*Resource{
+				{
+					Ref:                newTestRef("test-a"),
+					ReadinessGroupName: "a",
+					ReadinessAfter:     []string{"b"},
+				},
+				{
+					Ref:                newTestRef("test-b"),
+					ReadinessGroupName: "b",
+					ReadinessAfter:     []string{"a"},
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -317,3 +348,95 @@ func TestTreeRefConflicts(t *testing.T) {
 	assert.True(t, visible)
 	assert.Equal(t, "b", string(res.ManifestHash))
 }
+
+func TestTreeNamedReadinessGroups(t *testing.T) {
+	var b treeBuilder
+	b.Add(&Resource{
+		Ref:                newTestRef("db"),
+		ReadinessGroupName: "db-ready",
+		ManifestRef:        ManifestRef{Index: 1},
+	})
+	b.Add(&Resource{
+		Ref:            newTestRef("app"),
+		ReadinessAfter: []string{"db-ready"},
+		ManifestRef:    ManifestRef{Index: 2},
+	})
+
+	tree := b.Build()
+
+	// The app isn't visible until the db becomes ready
+	_, visible, found := tree.Get(newTestRef("app"))
+	assert.True(t, found)
+	assert.False(t, visible)
+
+	var enqueued []string
+	tree.UpdateState(&apiv1.Composition{}, ManifestRef{Index: 1}, &apiv1.ResourceState{Ready: &metav1.Time{}}, func(r Ref) {
+		enqueued = append(enqueued, r.Name)
+	})
+	assert.ElementsMatch(t, []string{"db", "app"}, enqueued)
+
+	_, visible, found = tree.Get(newTestRef("app"))
+	assert.True(t, found)
+	assert.True(t, visible)
+}
+
+func TestApplyOrder(t *testing.T) {
+	widgetGK := schema.GroupKind{Group: "example.com", Kind: "Widget"}
+
+	crd := &Resource{
+		Ref:              newTestRef("widget-crd"),
+		GVK:              schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"},
+		DefinedGroupKind: &widgetGK,
+		ReadinessGroup:   2,
+	}
+	cr := &Resource{
+		Ref: newTestRef("widget"),
+		GVK: schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"},
+	}
+	unrelated := &Resource{
+		Ref:            newTestRef("unrelated"),
+		GVK:            schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		ReadinessGroup: 1,
+	}
+
+	// Readiness groups order resources that aren't related by a CRD
+	ordered := ApplyOrder([]*Resource{unrelated, cr})
+	assert.Equal(t, []*Resource{cr, unrelated}, ordered)
+
+	// CRDs are hoisted ahead of the resources they define even when their
+	// readiness group would place them later
+	ordered = ApplyOrder([]*Resource{cr, unrelated, crd})
+	assert.Equal(t, []*Resource{crd, cr, unrelated}, ordered)
+
+	// The input slice isn't mutated
+	input := []*Resource{cr, unrelated, crd}
+	ApplyOrder(input)
+	assert.Equal(t, []*Resource{cr, unrelated, crd}, input)
+}
+
+func TestFindReadinessCycle(t *testing.T) {
+	// Acyclic orderings pass, including chains through several groups
+	assert.Nil(t, FindReadinessCycle([]*Resource{
+		{Ref: newTestRef("a"), ReadinessGroupName: "a"},
+		{Ref: newTestRef("b"), ReadinessGroupName: "b", ReadinessAfter: []string{"a"}},
+		{Ref: newTestRef("c"), ReadinessAfter: []string{"a", "b"}},
+	}))
+
+	// Resources that wait on a group without belonging to one can't form cycles
+	assert.Nil(t, FindReadinessCycle([]*Resource{
+		{Ref: newTestRef("a"), ReadinessAfter: []string{"missing"}},
+	}))
+
+	// Self cycles
+	assert.NotNil(t, FindReadinessCycle([]*Resource{
+		{Ref: newTestRef("a"), ReadinessGroupName: "a", ReadinessAfter: []string{"a"}},
+	}))
+
+	// Transitive cycles
+	cycle := FindReadinessCycle([]*Resource{
+		{Ref: newTestRef("a"), ReadinessGroupName: "a", ReadinessAfter: []string{"b"}},
+		{Ref: newTestRef("b"), ReadinessGroupName: "b", ReadinessAfter: []string{"c"}},
+		{Ref: newTestRef("c"), ReadinessGroupName: "c", ReadinessAfter: []string{"a"}},
+	})
+	assert.Equal(t, []string{"a", "b", "c", "a"}, cycle)
+}
@@ -0,0 +1,58 @@
+// Package dryrun executes synthesizers locally, without creating pods or
+// touching a cluster. It's intended for CI and unit tests of synthesizer
+// images, where the pod-based executor is too heavy.
+package dryrun
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/Azure/eno/internal/execution"
+	krmv1 "github.com/Azure/eno/pkg/krm/functions/api/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Synthesize runs the synthesizer's command in the local environment, speaking
+// the same stdin/stdout KRM protocol the production executor uses, and returns
+// the would-be output. Inputs are keyed the same way the executor keys bound
+// inputs, so synthesizers read them identically. The synthesizer's ExecTimeout
+// is honored.
+func Synthesize(ctx context.Context, syn *apiv1.Synthesizer, inputs map[string]client.Object) (*krmv1.ResourceList, error) {
+	rl := &krmv1.ResourceList{
+		Kind:       krmv1.ResourceListKind,
+		APIVersion: krmv1.SchemeGroupVersion.String(),
+	}
+
+	// Sort inputs by key so outputs are deterministic across runs
+	keys := make([]string, 0, len(inputs))
+	for key := range inputs {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(inputs[key].DeepCopyObject())
+		if err != nil {
+			return nil, fmt.Errorf("converting input %q: %w", key, err)
+		}
+		obj := &unstructured.Unstructured{Object: content}
+
+		anno := obj.GetAnnotations()
+		if anno == nil {
+			anno = map[string]string{}
+		}
+		anno["eno.azure.io/input-key"] = key
+		obj.SetAnnotations(anno)
+		rl.Items = append(rl.Items, obj)
+	}
+
+	output, err := execution.NewExecHandler()(ctx, syn, rl)
+	if err != nil {
+		return nil, fmt.Errorf("executing synthesizer: %w", err)
+	}
+	return output, nil
+}
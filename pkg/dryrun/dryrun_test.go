@@ -0,0 +1,45 @@
+package dryrun
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apiv1 "github.com/Azure/eno/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSynthesize(t *testing.T) {
+	syn := &apiv1.Synthesizer{}
+	syn.Spec.Command = []string{"/bin/sh", "-c", "cat /dev/stdin > /dev/stdout"}
+
+	input := &corev1.ConfigMap{}
+	input.APIVersion = "v1"
+	input.Kind = "ConfigMap"
+	input.Name = "test-input"
+	input.Namespace = "default"
+	input.Data = map[string]string{"foo": "bar"}
+
+	// The synthesizer echos its input, which carries the expected input key annotation
+	out, err := Synthesize(context.Background(), syn, map[string]client.Object{"test-key": input})
+	require.NoError(t, err)
+	require.Len(t, out.Items, 1)
+	assert.Equal(t, "test-input", out.Items[0].GetName())
+	assert.Equal(t, "test-key", out.Items[0].GetAnnotations()["eno.azure.io/input-key"])
+
+	// The caller's object wasn't mutated
+	assert.Nil(t, input.Annotations)
+}
+
+func TestSynthesizeExecTimeout(t *testing.T) {
+	syn := &apiv1.Synthesizer{}
+	syn.Spec.Command = []string{"/bin/sh", "-c", "sleep 1"}
+	syn.Spec.ExecTimeout = &metav1.Duration{Duration: time.Millisecond}
+
+	_, err := Synthesize(context.Background(), syn, nil)
+	require.ErrorContains(t, err, "executing synthesizer")
+}